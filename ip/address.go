@@ -3,6 +3,7 @@ package ip
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"strings"
 
 	"gopkg.in/addrs.v1/ipv4"
@@ -13,7 +14,9 @@ import (
 type Address interface {
 	String() string
 	ToNetIP() net.IP
+	ToNetipAddr() netip.Addr
 	NumBits() int
+	ReverseName() string
 }
 
 var _ Address = ipv4.Address{}
@@ -27,10 +30,35 @@ func AddressFromString(address string) (Address, error) {
 	return ipv4.AddressFromString(address)
 }
 
-// AddressFromNetIP returns an instance of an ipv4.Address or ipv6.Address
+// AddressFromStringStrict is like AddressFromString but uses the strict parser
+// from the ipv4 or ipv6 package which accepts only the canonical form
+func AddressFromStringStrict(address string) (Address, error) {
+	if strings.Contains(address, ":") {
+		return ipv6.AddressFromStringStrict(address)
+	}
+	return ipv4.AddressFromStringStrict(address)
+}
+
+// AddressFromReverseName returns an instance of an ipv4.Address or
+// ipv6.Address parsed from the name of a PTR record in in-addr.arpa or
+// ip6.arpa respectively
+func AddressFromReverseName(name string) (Address, error) {
+	if strings.HasSuffix(strings.ToLower(strings.TrimSuffix(name, ".")), "ip6.arpa") {
+		return ipv6.AddressFromReverseName(name)
+	}
+	return ipv4.AddressFromReverseName(name)
+}
+
+// AddressFromNetIP returns an instance of an ipv4.Address or ipv6.Address. The
+// net package represents IPv4 addresses as 16-byte IPv4-mapped addresses (e.g.
+// the result of net.ParseIP("203.0.113.17")) so those are returned as an
+// ipv4.Address too.
 func AddressFromNetIP(ip net.IP) (Address, error) {
 	switch len(ip) {
 	case net.IPv6len:
+		if v4 := ip.To4(); v4 != nil {
+			return ipv4.AddressFromNetIP(v4)
+		}
 		return ipv6.AddressFromNetIP(ip)
 	case net.IPv4len:
 		return ipv4.AddressFromNetIP(ip)
@@ -39,6 +67,21 @@ func AddressFromNetIP(ip net.IP) (Address, error) {
 	}
 }
 
+// AddressFromNetipAddr returns an instance of an ipv4.Address or ipv6.Address
+// depending on the family of the given netip.Addr. An IPv4-mapped IPv6 address
+// (e.g. ::ffff:203.0.113.17) is returned as an ipv6.Address. Call Unmap() on
+// it first to get an ipv4.Address instead.
+func AddressFromNetipAddr(addr netip.Addr) (Address, error) {
+	switch {
+	case addr.Is4():
+		return ipv4.AddressFromNetipAddr(addr)
+	case addr.Is6():
+		return ipv6.AddressFromNetipAddr(addr)
+	default:
+		return nil, fmt.Errorf("invalid netip.Addr")
+	}
+}
+
 // PrefixFromAddress returns the host route prefix (i.e. /32) from the given
 // route. If the address passed in is not an ipv4.Address or ipv6.Address, then
 // nil is returned.
@@ -2,6 +2,7 @@ package ip
 
 import (
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,27 @@ func TestParseAddress(t *testing.T) {
 	})
 }
 
+func TestAddressFromReverseName(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AddressFromReverseName("example.com.")
+		assert.NotNil(t, err)
+	})
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddressFromReverseName("17.113.0.203.in-addr.arpa.")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Address{}, a)
+		assert.Equal(t, "203.0.113.17", a.String())
+		assert.Equal(t, "17.113.0.203.in-addr.arpa.", a.ReverseName())
+	})
+	t.Run("v6", func(t *testing.T) {
+		a, err := AddressFromReverseName("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.IP6.ARPA")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Address{}, a)
+		assert.Equal(t, "2001:db8::1", a.String())
+		assert.Equal(t, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", a.ReverseName())
+	})
+}
+
 func TestAddressFromNetIP(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		_, err := AddressFromNetIP(net.IP{})
@@ -39,6 +61,12 @@ func TestAddressFromNetIP(t *testing.T) {
 		assert.IsType(t, ipv4.Address{}, a)
 		assert.Equal(t, "203.0.113.29", a.String())
 	})
+	t.Run("v4 mapped", func(t *testing.T) {
+		a, err := AddressFromNetIP(net.ParseIP("203.0.113.29"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Address{}, a)
+		assert.Equal(t, "203.0.113.29", a.String())
+	})
 	t.Run("v6", func(t *testing.T) {
 		a, err := AddressFromNetIP(net.ParseIP("2001:db8::1"))
 		assert.Nil(t, err)
@@ -65,3 +93,46 @@ func TestPrefixFromAddress(t *testing.T) {
 		assert.Equal(t, "2001:db8::1/128", p.String())
 	})
 }
+
+func TestAddressFromNetipAddr(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AddressFromNetipAddr(netip.Addr{})
+		assert.NotNil(t, err)
+	})
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddressFromNetipAddr(netip.MustParseAddr("203.0.113.29"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Address{}, a)
+		assert.Equal(t, "203.0.113.29", a.String())
+		assert.Equal(t, netip.MustParseAddr("203.0.113.29"), a.ToNetipAddr())
+	})
+	t.Run("v6", func(t *testing.T) {
+		a, err := AddressFromNetipAddr(netip.MustParseAddr("2001:db8::1"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Address{}, a)
+		assert.Equal(t, "2001:db8::1", a.String())
+		assert.Equal(t, netip.MustParseAddr("2001:db8::1"), a.ToNetipAddr())
+	})
+	t.Run("v4 mapped", func(t *testing.T) {
+		a, err := AddressFromNetipAddr(netip.MustParseAddr("::ffff:203.0.113.29"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Address{}, a)
+	})
+}
+
+func TestAddressFromStringStrict(t *testing.T) {
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddressFromStringStrict("203.0.113.17")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Address{}, a)
+	})
+	t.Run("v6", func(t *testing.T) {
+		a, err := AddressFromStringStrict("2001:db8::1")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Address{}, a)
+	})
+	t.Run("non-canonical", func(t *testing.T) {
+		_, err := AddressFromStringStrict("2001:0db8::1")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,75 @@
+package ip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"gopkg.in/addrs.v1/ipv4"
+	"gopkg.in/addrs.v1/ipv6"
+)
+
+// AddrPort is the common interface implemented by both IPv4 and IPv6 address
+// and port pairs
+type AddrPort interface {
+	String() string
+	Port() uint16
+	ToNetTCPAddr() *net.TCPAddr
+	ToNetUDPAddr() *net.UDPAddr
+	ToNetipAddrPort() netip.AddrPort
+}
+
+var _ AddrPort = ipv4.AddrPort{}
+var _ AddrPort = ipv6.AddrPort{}
+
+// AddrPortFromString returns an instance of an ipv4.AddrPort or ipv6.AddrPort
+// depending on whether the address is enclosed in brackets (e.g.
+// 203.0.113.17:80 or [2001:db8::1]:443)
+func AddrPortFromString(addrPort string) (AddrPort, error) {
+	if strings.HasPrefix(addrPort, "[") {
+		return ipv6.AddrPortFromString(addrPort)
+	}
+	return ipv4.AddrPortFromString(addrPort)
+}
+
+// AddrPortFromNetTCPAddr returns an instance of an ipv4.AddrPort or
+// ipv6.AddrPort. As with AddressFromNetIP, a 16 byte IPv4-mapped IP is
+// returned as an ipv4.AddrPort.
+func AddrPortFromNetTCPAddr(addr *net.TCPAddr) (AddrPort, error) {
+	if addr == nil {
+		return nil, fmt.Errorf("failed to convert nil *net.TCPAddr")
+	}
+	if addr.IP.To4() != nil {
+		return ipv4.AddrPortFromNetTCPAddr(addr)
+	}
+	return ipv6.AddrPortFromNetTCPAddr(addr)
+}
+
+// AddrPortFromNetUDPAddr returns an instance of an ipv4.AddrPort or
+// ipv6.AddrPort. As with AddressFromNetIP, a 16 byte IPv4-mapped IP is
+// returned as an ipv4.AddrPort.
+func AddrPortFromNetUDPAddr(addr *net.UDPAddr) (AddrPort, error) {
+	if addr == nil {
+		return nil, fmt.Errorf("failed to convert nil *net.UDPAddr")
+	}
+	if addr.IP.To4() != nil {
+		return ipv4.AddrPortFromNetUDPAddr(addr)
+	}
+	return ipv6.AddrPortFromNetUDPAddr(addr)
+}
+
+// AddrPortFromNetipAddrPort returns an instance of an ipv4.AddrPort or
+// ipv6.AddrPort depending on the family of the address. As with
+// AddressFromNetipAddr, an IPv4-mapped IPv6 address is returned as an
+// ipv6.AddrPort.
+func AddrPortFromNetipAddrPort(addrPort netip.AddrPort) (AddrPort, error) {
+	switch addr := addrPort.Addr(); {
+	case addr.Is4():
+		return ipv4.AddrPortFromNetipAddrPort(addrPort)
+	case addr.Is6():
+		return ipv6.AddrPortFromNetipAddrPort(addrPort)
+	default:
+		return nil, fmt.Errorf("invalid netip.AddrPort")
+	}
+}
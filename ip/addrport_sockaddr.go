@@ -0,0 +1,25 @@
+//go:build !plan9
+// +build !plan9
+
+package ip
+
+import (
+	"fmt"
+	"syscall"
+
+	"gopkg.in/addrs.v1/ipv4"
+	"gopkg.in/addrs.v1/ipv6"
+)
+
+// AddrPortFromSockaddr returns an ipv4.AddrPort for a *syscall.SockaddrInet4
+// or an ipv6.AddrPort for a *syscall.SockaddrInet6
+func AddrPortFromSockaddr(sa syscall.Sockaddr) (AddrPort, error) {
+	switch sa.(type) {
+	case *syscall.SockaddrInet4:
+		return ipv4.AddrPortFromSockaddr(sa)
+	case *syscall.SockaddrInet6:
+		return ipv6.AddrPortFromSockaddr(sa)
+	default:
+		return nil, fmt.Errorf("unsupported syscall.Sockaddr type %T", sa)
+	}
+}
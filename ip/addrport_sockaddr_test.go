@@ -0,0 +1,32 @@
+//go:build !plan9
+// +build !plan9
+
+package ip
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/addrs.v1/ipv4"
+	"gopkg.in/addrs.v1/ipv6"
+)
+
+func TestAddrPortFromSockaddr(t *testing.T) {
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddrPortFromSockaddr(&syscall.SockaddrInet4{Port: 80, Addr: [4]byte{203, 0, 113, 17}})
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.AddrPort{}, a)
+		assert.Equal(t, "203.0.113.17:80", a.String())
+	})
+	t.Run("v6", func(t *testing.T) {
+		a, err := AddrPortFromSockaddr(&syscall.SockaddrInet6{Port: 443, Addr: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}})
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.AddrPort{}, a)
+		assert.Equal(t, "[2001:db8::1]:443", a.String())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AddrPortFromSockaddr(&syscall.SockaddrUnix{Name: "/tmp/socket"})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+package ip
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/addrs.v1/ipv4"
+	"gopkg.in/addrs.v1/ipv6"
+)
+
+func TestAddrPortFromString(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AddrPortFromString("bogus")
+		assert.NotNil(t, err)
+	})
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddrPortFromString("203.0.113.17:80")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.AddrPort{}, a)
+		assert.Equal(t, "203.0.113.17:80", a.String())
+		assert.Equal(t, uint16(80), a.Port())
+	})
+	t.Run("v6", func(t *testing.T) {
+		a, err := AddrPortFromString("[2001:db8::1]:443")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.AddrPort{}, a)
+		assert.Equal(t, "[2001:db8::1]:443", a.String())
+	})
+}
+
+func TestAddrPortFromNet(t *testing.T) {
+	t.Run("v4 tcp", func(t *testing.T) {
+		a, err := AddrPortFromNetTCPAddr(&net.TCPAddr{IP: net.ParseIP("203.0.113.17"), Port: 80})
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.AddrPort{}, a)
+		assert.Equal(t, "203.0.113.17:80", a.String())
+	})
+	t.Run("v6 udp", func(t *testing.T) {
+		a, err := AddrPortFromNetUDPAddr(&net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 53, Zone: "eth0"})
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.AddrPort{}, a)
+		assert.Equal(t, "[fe80::1%eth0]:53", a.String())
+		assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 53, Zone: "eth0"}, a.ToNetUDPAddr())
+	})
+	t.Run("nil", func(t *testing.T) {
+		_, err := AddrPortFromNetTCPAddr(nil)
+		assert.NotNil(t, err)
+		_, err = AddrPortFromNetUDPAddr(nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAddrPortFromNetipAddrPort(t *testing.T) {
+	t.Run("v4", func(t *testing.T) {
+		a, err := AddrPortFromNetipAddrPort(netip.MustParseAddrPort("203.0.113.17:80"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.AddrPort{}, a)
+	})
+	t.Run("v4 mapped", func(t *testing.T) {
+		a, err := AddrPortFromNetipAddrPort(netip.MustParseAddrPort("[::ffff:203.0.113.17]:80"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.AddrPort{}, a)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AddrPortFromNetipAddrPort(netip.AddrPort{})
+		assert.NotNil(t, err)
+	})
+}
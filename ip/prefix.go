@@ -3,6 +3,7 @@ package ip
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"strings"
 
 	"gopkg.in/addrs.v1/ipv4"
@@ -14,6 +15,7 @@ type Prefix interface {
 	Length() int
 	String() string
 	ToNetIPNet() *net.IPNet
+	ToNetipPrefix() netip.Prefix
 }
 
 var _ Prefix = ipv4.Prefix{}
@@ -44,6 +46,15 @@ func PrefixFromString(prefix string) (Prefix, error) {
 	return ipv4.PrefixFromString(prefix)
 }
 
+// PrefixFromStringStrict is like PrefixFromString but uses the strict parser
+// from the ipv4 or ipv6 package which accepts only the canonical form
+func PrefixFromStringStrict(prefix string) (Prefix, error) {
+	if strings.Contains(prefix, ":") {
+		return ipv6.PrefixFromStringStrict(prefix)
+	}
+	return ipv4.PrefixFromStringStrict(prefix)
+}
+
 func PrefixFromNetIPNet(ipn *net.IPNet) (Prefix, error) {
 	if ipn == nil {
 		return nil, fmt.Errorf("cannot create Prefix from nil")
@@ -58,6 +69,20 @@ func PrefixFromNetIPNet(ipn *net.IPNet) (Prefix, error) {
 	}
 }
 
+// PrefixFromNetipPrefix returns an instance of an ipv4.Prefix or ipv6.Prefix
+// depending on the family of the given netip.Prefix's address. As with
+// AddressFromNetipAddr, an IPv4-mapped IPv6 address results in an ipv6.Prefix.
+func PrefixFromNetipPrefix(prefix netip.Prefix) (Prefix, error) {
+	switch {
+	case !prefix.IsValid():
+		return nil, fmt.Errorf("invalid netip.Prefix")
+	case prefix.Addr().Is4():
+		return ipv4.PrefixFromNetipPrefix(prefix)
+	default:
+		return ipv6.PrefixFromNetipPrefix(prefix)
+	}
+}
+
 // AddressFromPrefix returns the address part of the given prefix. If the
 // prefix passed in is not an ipv4.Prefix or ipv6.Prefix, then nil is returned.
 func AddressFromPrefix(prefix Prefix) Address {
@@ -2,6 +2,7 @@ package ip
 
 import (
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -158,3 +159,41 @@ func TestRangeFromPrefix(t *testing.T) {
 		assert.Equal(t, "[2001:db8::,2001:db8::ffff:ffff:ffff:ffff]", r.String())
 	})
 }
+
+func TestPrefixFromNetipPrefix(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := PrefixFromNetipPrefix(netip.Prefix{})
+		assert.NotNil(t, err)
+	})
+	t.Run("v4", func(t *testing.T) {
+		p, err := PrefixFromNetipPrefix(netip.MustParsePrefix("203.0.113.29/24"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Prefix{}, p)
+		assert.Equal(t, "203.0.113.29/24", p.String())
+		assert.Equal(t, netip.MustParsePrefix("203.0.113.29/24"), p.ToNetipPrefix())
+	})
+	t.Run("v6", func(t *testing.T) {
+		p, err := PrefixFromNetipPrefix(netip.MustParsePrefix("2001:db8::1/64"))
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Prefix{}, p)
+		assert.Equal(t, "2001:db8::1/64", p.String())
+		assert.Equal(t, netip.MustParsePrefix("2001:db8::1/64"), p.ToNetipPrefix())
+	})
+}
+
+func TestPrefixFromStringStrict(t *testing.T) {
+	t.Run("v4", func(t *testing.T) {
+		p, err := PrefixFromStringStrict("203.0.113.0/24")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Prefix{}, p)
+	})
+	t.Run("v6", func(t *testing.T) {
+		p, err := PrefixFromStringStrict("2001:db8::/32")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Prefix{}, p)
+	})
+	t.Run("non-canonical", func(t *testing.T) {
+		_, err := PrefixFromStringStrict("203.0.113.0/024")
+		assert.NotNil(t, err)
+	})
+}
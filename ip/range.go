@@ -2,6 +2,7 @@ package ip
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/addrs.v1/ipv4"
 	"gopkg.in/addrs.v1/ipv6"
@@ -35,6 +36,16 @@ func RangeFromAddresses(first, last Address) (Range, bool, error) {
 	return nil, false, fmt.Errorf("unknown first address family")
 }
 
+// RangeFromString returns an instance of an ipv4.Range or ipv6.Range parsed
+// from two addresses separated by a dash. See RangeFromString in those
+// packages for the shorthand forms accepted.
+func RangeFromString(r string) (Range, error) {
+	if strings.Contains(r, ":") {
+		return ipv6.RangeFromString(r)
+	}
+	return ipv4.RangeFromString(r)
+}
+
 // FirstFromRange returns the first address of the given range. If the range
 // passed in is not an ipv4.Range or ipv6.Range, then nil is returned.
 func FirstFromRange(r Range) Range {
@@ -113,3 +113,22 @@ func TestLastFromRange(t *testing.T) {
 		assert.Equal(t, "2001:db8::ffff:ffff:ffff:ffff", a.String())
 	})
 }
+
+func TestRangeFromString(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := RangeFromString("bogus")
+		assert.NotNil(t, err)
+	})
+	t.Run("v4", func(t *testing.T) {
+		r, err := RangeFromString("10.0.0.1-254")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv4.Range{}, r)
+		assert.Equal(t, "[10.0.0.1,10.0.0.254]", r.String())
+	})
+	t.Run("v6", func(t *testing.T) {
+		r, err := RangeFromString("2001:db8::1-ff")
+		assert.Nil(t, err)
+		assert.IsType(t, ipv6.Range{}, r)
+		assert.Equal(t, "[2001:db8::1,2001:db8::ff]", r.String())
+	})
+}
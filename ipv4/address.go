@@ -1,8 +1,12 @@
 package ipv4
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
+	"strings"
 )
 
 const (
@@ -40,21 +44,50 @@ func AddressFromNetIP(ip net.IP) (Address, error) {
 	return fromSlice(ip.To4())
 }
 
+// AddressFromNetipAddr converts a netip.Addr to an Address. It does not
+// allocate. The netip.Addr must be an IPv4 address. An IPv4-mapped IPv6 address
+// (e.g. ::ffff:203.0.113.17) is an IPv6 address and is rejected; call Unmap()
+// on it first to convert it explicitly.
+func AddressFromNetipAddr(addr netip.Addr) (Address, error) {
+	if !addr.Is4() {
+		if addr.Is4In6() {
+			return Address{}, fmt.Errorf("address is IPv4-mapped IPv6 (use Unmap() to convert it): %s", addr)
+		}
+		return Address{}, fmt.Errorf("address is not IPv4: %s", addr)
+	}
+	a := addr.As4()
+	return AddressFromBytes(a[0], a[1], a[2], a[3]), nil
+}
+
 // AddressFromString returns the Address represented by `addr` in dotted-quad
 // notation. If it cannot be parsed, then error is non-nil and the Address
 // returned must be ignored.
+//
+// It does not allocate unless it fails. Octets with leading zeros are rejected.
+// For compatibility, an IPv4-mapped IPv6 address (e.g. ::ffff:203.0.113.17) is
+// also accepted but that is much slower; use AddressFromStringStrict to reject
+// it. Errors that occur while parsing are of type *ParseError.
 func AddressFromString(address string) (Address, error) {
-	netIP := net.ParseIP(address)
-	if netIP == nil {
-		return Address{}, fmt.Errorf("failed to parse address: %s", address)
+	addr, pos, msg := parseAddress(address)
+	if msg != "" {
+		if strings.IndexByte(address, ':') >= 0 {
+			if netIP := net.ParseIP(address).To4(); netIP != nil {
+				return AddressFromNetIP(netIP)
+			}
+		}
+		return Address{}, &ParseError{Input: address, Pos: pos, Msg: msg}
 	}
+	return addr, nil
+}
 
-	netIPv4 := netIP.To4()
-	if netIPv4 == nil {
-		return Address{}, fmt.Errorf("address is not IPv4: %s", address)
+// AddressFromStringStrict is like AddressFromString but accepts only the
+// canonical dotted-quad form of an address, which is what String returns.
+func AddressFromStringStrict(address string) (Address, error) {
+	addr, pos, msg := parseAddress(address)
+	if msg != "" {
+		return Address{}, &ParseError{Input: address, Pos: pos, Msg: msg}
 	}
-
-	return AddressFromNetIP(netIPv4)
+	return addr, nil
 }
 
 // minAddress returns the address, a or b, which comes first in lexigraphical order
@@ -73,6 +106,13 @@ func maxAddress(a, b Address) Address {
 	return a
 }
 
+// ToNetipAddr returns the netip.Addr representation of the address. It does
+// not allocate.
+func (me Address) ToNetipAddr() netip.Addr {
+	a, b, c, d := me.toBytes()
+	return netip.AddrFrom4([4]byte{a, b, c, d})
+}
+
 // ToNetIP returns a net.IP representation of the address which always has 4 bytes
 func (me Address) ToNetIP() net.IP {
 	a, b, c, d := me.toBytes()
@@ -101,6 +141,66 @@ func (me Address) String() string {
 	return fmt.Sprintf("%d.%d.%d.%d", a, b, c, d)
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Address) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using AddressFromString
+func (me *Address) UnmarshalText(text []byte) error {
+	address, err := AddressFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = address
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The address is encoded as a JSON
+// string.
+func (me Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the address
+// unchanged.
+func (me *Address) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
+// Next returns the address immediately after this one. If this is the last
+// address (255.255.255.255), it wraps around to 0.0.0.0 and overflow is true.
+func (me Address) Next() (next Address, overflow bool) {
+	return me.Add(1)
+}
+
+// Prev returns the address immediately before this one. If this is the first
+// address (0.0.0.0), it wraps around to 255.255.255.255 and underflow is true.
+func (me Address) Prev() (prev Address, underflow bool) {
+	return me.Sub(1)
+}
+
+// Add returns the address n addresses after this one. If that would go past
+// the end of the address space, the result wraps around and overflow is true.
+func (me Address) Add(n uint32) (result Address, overflow bool) {
+	sum := me.ui + n
+	return Address{sum}, sum < me.ui
+}
+
+// Sub returns the address n addresses before this one. If that would go past
+// the beginning of the address space, the result wraps around and underflow is
+// true.
+func (me Address) Sub(n uint32) (result Address, underflow bool) {
+	return Address{me.ui - n}, n > me.ui
+}
+
+// Distance returns the number of addresses from this address to other. It is
+// negative if other comes before this address. It always fits in an int64.
+func (me Address) Distance(other Address) int64 {
+	return int64(other.ui) - int64(me.ui)
+}
+
 // NumBits returns the size of an address (always 32)
 func (me Address) NumBits() int {
 	return addressSize
@@ -130,3 +230,17 @@ func fromSlice(s []byte) (Address, error) {
 	}
 	return AddressFromBytes(s[0], s[1], s[2], s[3]), nil
 }
+
+// unmarshalJSONText decodes a JSON string and passes its contents to the given
+// TextUnmarshaler. A JSON null is ignored, as is the convention for
+// json.Unmarshaler.
+func unmarshalJSONText(data []byte, u encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(text))
+}
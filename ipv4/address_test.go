@@ -1,7 +1,9 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -189,3 +191,173 @@ func TestAddressAsMapKey(t *testing.T) {
 
 	assert.True(t, m[_a("203.0.113.1")])
 }
+
+func TestAddressMarshalText(t *testing.T) {
+	text, err := _a("10.224.24.1").MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.224.24.1", string(text))
+
+	var a Address
+	assert.Nil(t, a.UnmarshalText(text))
+	assert.Equal(t, _a("10.224.24.1"), a)
+
+	assert.NotNil(t, a.UnmarshalText([]byte("10.224.24.256")))
+	assert.Equal(t, _a("10.224.24.1"), a)
+}
+
+func TestAddressMarshalJSON(t *testing.T) {
+	type config struct {
+		Gateway Address   `json:"gateway"`
+		Servers []Address `json:"servers"`
+	}
+	c := config{
+		Gateway: _a("10.0.0.1"),
+		Servers: []Address{_a("10.0.0.2"), _a("10.0.0.3")},
+	}
+	data, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"gateway":"10.0.0.1","servers":["10.0.0.2","10.0.0.3"]}`, string(data))
+
+	var decoded config
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, c, decoded)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"gateway":null}`), &decoded))
+	assert.Equal(t, _a("10.0.0.1"), decoded.Gateway)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"gateway":"2001:db8::1"}`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"gateway":167772161}`), &decoded))
+}
+
+func TestAddressFromNetipAddr(t *testing.T) {
+	tests := []struct {
+		description string
+		addr        netip.Addr
+		expected    Address
+		isErr       bool
+	}{
+		{
+			description: "zero",
+			addr:        netip.Addr{},
+			isErr:       true,
+		},
+		{
+			description: "ipv6",
+			addr:        netip.MustParseAddr("2001:db8::1"),
+			isErr:       true,
+		},
+		{
+			description: "ipv4 mapped",
+			addr:        netip.MustParseAddr("::ffff:10.224.24.1"),
+			isErr:       true,
+		},
+		{
+			description: "ipv4",
+			addr:        netip.MustParseAddr("10.224.24.1"),
+			expected:    AddressFromUint32(0x0ae01801),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			addr, err := AddressFromNetipAddr(tt.addr)
+			if tt.isErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, addr)
+				assert.Equal(t, tt.addr, addr.ToNetipAddr())
+			}
+		})
+	}
+}
+
+func TestAddressNetipAllocs(t *testing.T) {
+	addr := netip.MustParseAddr("10.224.24.1")
+	allocs := testing.AllocsPerRun(100, func() {
+		a, _ := AddressFromNetipAddr(addr)
+		_ = a.ToNetipAddr()
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestAddressNextPrev(t *testing.T) {
+	next, overflow := _a("10.224.24.255").Next()
+	assert.Equal(t, _a("10.224.25.0"), next)
+	assert.False(t, overflow)
+
+	next, overflow = _a("255.255.255.255").Next()
+	assert.Equal(t, _a("0.0.0.0"), next)
+	assert.True(t, overflow)
+
+	prev, underflow := _a("10.224.25.0").Prev()
+	assert.Equal(t, _a("10.224.24.255"), prev)
+	assert.False(t, underflow)
+
+	prev, underflow = _a("0.0.0.0").Prev()
+	assert.Equal(t, _a("255.255.255.255"), prev)
+	assert.True(t, underflow)
+}
+
+func TestAddressAddSub(t *testing.T) {
+	tests := []struct {
+		description string
+		a           Address
+		n           uint32
+		sum         Address
+		overflow    bool
+		difference  Address
+		underflow   bool
+	}{
+		{
+			description: "zero",
+			a:           _a("10.224.24.1"),
+			n:           0,
+			sum:         _a("10.224.24.1"),
+			difference:  _a("10.224.24.1"),
+		}, {
+			description: "typical",
+			a:           _a("10.224.24.1"),
+			n:           0x100,
+			sum:         _a("10.224.25.1"),
+			difference:  _a("10.224.23.1"),
+		}, {
+			description: "edges",
+			a:           _a("0.0.0.0"),
+			n:           0xffffffff,
+			sum:         _a("255.255.255.255"),
+			difference:  _a("0.0.0.1"),
+			underflow:   true,
+		}, {
+			description: "overflow",
+			a:           _a("255.255.255.0"),
+			n:           0x101,
+			sum:         _a("0.0.0.1"),
+			overflow:    true,
+			difference:  _a("255.255.253.255"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			sum, overflow := tt.a.Add(tt.n)
+			assert.Equal(t, tt.sum, sum)
+			assert.Equal(t, tt.overflow, overflow)
+
+			difference, underflow := tt.a.Sub(tt.n)
+			assert.Equal(t, tt.difference, difference)
+			assert.Equal(t, tt.underflow, underflow)
+
+			if !overflow {
+				assert.Equal(t, int64(tt.n), tt.a.Distance(sum))
+				assert.Equal(t, -int64(tt.n), sum.Distance(tt.a))
+			}
+		})
+	}
+}
+
+func TestAddressDistance(t *testing.T) {
+	assert.Equal(t, int64(0), _a("10.224.24.1").Distance(_a("10.224.24.1")))
+	assert.Equal(t, int64(0xffffffff), _a("0.0.0.0").Distance(_a("255.255.255.255")))
+	assert.Equal(t, -int64(0xffffffff), _a("255.255.255.255").Distance(_a("0.0.0.0")))
+}
@@ -0,0 +1,176 @@
+package ipv4
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// AddrPort is an Address plus a port number (e.g. 203.0.113.17:80). It is
+// comparable and can be used as a map key.
+type AddrPort struct {
+	addr Address
+	port uint16
+}
+
+// AddrPortFromAddressPort returns an AddrPort with the given address and port
+func AddrPortFromAddressPort(addr Address, port uint16) AddrPort {
+	return AddrPort{addr, port}
+}
+
+// AddrPortFromString parses an address in dotted-quad notation and a decimal
+// port separated by a colon (e.g. 203.0.113.17:80). It does not allocate
+// unless it fails. Errors that occur while parsing are of type *ParseError.
+func AddrPortFromString(addrPort string) (AddrPort, error) {
+	addrPortParsed, pos, msg := parseAddrPort(addrPort)
+	if msg != "" {
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: pos, Msg: msg}
+	}
+	return addrPortParsed, nil
+}
+
+// AddrPortFromNetTCPAddr converts a *net.TCPAddr to an AddrPort. Its IP must
+// be an IPv4 address. As with AddressFromNetIP, the 16 byte form used by the
+// net package is accepted.
+func AddrPortFromNetTCPAddr(addr *net.TCPAddr) (AddrPort, error) {
+	if addr == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert nil *net.TCPAddr")
+	}
+	return addrPortFromNetIPPort(addr.IP, addr.Port)
+}
+
+// AddrPortFromNetUDPAddr converts a *net.UDPAddr to an AddrPort. Its IP must
+// be an IPv4 address. As with AddressFromNetIP, the 16 byte form used by the
+// net package is accepted.
+func AddrPortFromNetUDPAddr(addr *net.UDPAddr) (AddrPort, error) {
+	if addr == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert nil *net.UDPAddr")
+	}
+	return addrPortFromNetIPPort(addr.IP, addr.Port)
+}
+
+// AddrPortFromNetipAddrPort converts a netip.AddrPort to an AddrPort. It does
+// not allocate. As with AddressFromNetipAddr, its address must be an IPv4
+// address and not an IPv4-mapped IPv6 address.
+func AddrPortFromNetipAddrPort(addrPort netip.AddrPort) (AddrPort, error) {
+	addr, err := AddressFromNetipAddr(addrPort.Addr())
+	if err != nil {
+		return AddrPort{}, err
+	}
+	return AddrPort{addr, addrPort.Port()}, nil
+}
+
+// addrPortFromNetIPPort converts the fields of a *net.TCPAddr or *net.UDPAddr
+func addrPortFromNetIPPort(ip net.IP, port int) (AddrPort, error) {
+	addr, err := AddressFromNetIP(ip)
+	if err != nil {
+		return AddrPort{}, err
+	}
+	if port < 0 || port > 0xffff {
+		return AddrPort{}, fmt.Errorf("port is out of range: %d", port)
+	}
+	return AddrPort{addr, uint16(port)}, nil
+}
+
+// Address returns the address
+func (me AddrPort) Address() Address {
+	return me.addr
+}
+
+// Port returns the port
+func (me AddrPort) Port() uint16 {
+	return me.port
+}
+
+// ToNetTCPAddr returns a *net.TCPAddr with the address and port
+func (me AddrPort) ToNetTCPAddr() *net.TCPAddr {
+	return &net.TCPAddr{IP: me.addr.ToNetIP(), Port: int(me.port)}
+}
+
+// ToNetUDPAddr returns a *net.UDPAddr with the address and port
+func (me AddrPort) ToNetUDPAddr() *net.UDPAddr {
+	return &net.UDPAddr{IP: me.addr.ToNetIP(), Port: int(me.port)}
+}
+
+// ToNetipAddrPort returns the netip.AddrPort representation. It does not
+// allocate.
+func (me AddrPort) ToNetipAddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(me.addr.ToNetipAddr(), me.port)
+}
+
+// String returns the address in dotted-quad notation and the port separated by
+// a colon (e.g. 203.0.113.17:80)
+func (me AddrPort) String() string {
+	return fmt.Sprintf("%s:%d", me.addr, me.port)
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me AddrPort) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using AddrPortFromString
+func (me *AddrPort) UnmarshalText(text []byte) error {
+	addrPort, err := AddrPortFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = addrPort
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The AddrPort is encoded as a JSON
+// string.
+func (me AddrPort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the AddrPort
+// unchanged.
+func (me *AddrPort) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
+// parseAddrPort parses s which must be exactly an address and port separated
+// by a colon
+func parseAddrPort(s string) (addrPort AddrPort, pos int, msg string) {
+	colon := strings.IndexByte(s, ':')
+	if colon < 0 {
+		return AddrPort{}, len(s), "expected ':'"
+	}
+	addr, pos, msg := parseAddress(s[:colon])
+	if msg != "" {
+		return AddrPort{}, pos, msg
+	}
+	port, pos, msg := parsePort(s, colon+1)
+	if msg != "" {
+		return AddrPort{}, pos, msg
+	}
+	return AddrPort{addr, port}, 0, ""
+}
+
+// parsePort parses the decimal port in s starting at position i through the
+// end of s
+func parsePort(s string, i int) (port uint16, pos int, msg string) {
+	start := i
+	var val uint32
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if i > start && s[start] == '0' {
+			return 0, start, "port has a leading zero"
+		}
+		val = val*10 + uint32(s[i]-'0')
+		if val > 0xffff {
+			return 0, start, "port is greater than 65535"
+		}
+	}
+	if i == start {
+		return 0, i, "expected a decimal port"
+	}
+	if i != len(s) {
+		return 0, i, "unexpected character"
+	}
+	return uint16(val), 0, ""
+}
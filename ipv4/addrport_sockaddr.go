@@ -0,0 +1,32 @@
+//go:build !plan9
+// +build !plan9
+
+package ipv4
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// AddrPortFromSockaddr converts a syscall.Sockaddr to an AddrPort. It must be
+// a *syscall.SockaddrInet4.
+func AddrPortFromSockaddr(sa syscall.Sockaddr) (AddrPort, error) {
+	sa4, ok := sa.(*syscall.SockaddrInet4)
+	if !ok || sa4 == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert %T to an IPv4 AddrPort", sa)
+	}
+	if sa4.Port < 0 || sa4.Port > 0xffff {
+		return AddrPort{}, fmt.Errorf("port is out of range: %d", sa4.Port)
+	}
+	return AddrPort{
+		AddressFromBytes(sa4.Addr[0], sa4.Addr[1], sa4.Addr[2], sa4.Addr[3]),
+		uint16(sa4.Port),
+	}, nil
+}
+
+// ToSockaddr returns a *syscall.SockaddrInet4 with the address and port. The
+// error is always nil. It is there to match ipv6.AddrPort.
+func (me AddrPort) ToSockaddr() (syscall.Sockaddr, error) {
+	a, b, c, d := me.addr.toBytes()
+	return &syscall.SockaddrInet4{Port: int(me.port), Addr: [4]byte{a, b, c, d}}, nil
+}
@@ -0,0 +1,27 @@
+//go:build !plan9
+// +build !plan9
+
+package ipv4
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddrPortSockaddr(t *testing.T) {
+	addrPort := AddrPortFromAddressPort(_a("203.0.113.17"), 80)
+	sa, err := addrPort.ToSockaddr()
+	assert.Nil(t, err)
+	assert.Equal(t, &syscall.SockaddrInet4{Port: 80, Addr: [4]byte{203, 0, 113, 17}}, sa)
+
+	converted, err := AddrPortFromSockaddr(sa)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	_, err = AddrPortFromSockaddr(&syscall.SockaddrInet6{Port: 80})
+	assert.NotNil(t, err)
+	_, err = AddrPortFromSockaddr(nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,119 @@
+package ipv4
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddrPortFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		address Address
+		port    uint16
+	}{
+		{"203.0.113.17:80", _a("203.0.113.17"), 80},
+		{"0.0.0.0:0", _a("0.0.0.0"), 0},
+		{"255.255.255.255:65535", _a("255.255.255.255"), 65535},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			addrPort, err := AddrPortFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addrPort.Address())
+			assert.Equal(t, tt.port, addrPort.Port())
+			assert.Equal(t, AddrPortFromAddressPort(tt.address, tt.port), addrPort)
+			assert.Equal(t, tt.input, addrPort.String())
+		})
+	}
+}
+
+func TestAddrPortFromStringErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"203.0.113.17", 12},
+		{"203.0.113.17:", 13},
+		{"203.0.113.17:65536", 13},
+		{"203.0.113.17:080", 13},
+		{"203.0.113.17:80a", 15},
+		{"203.0.113:80", 9},
+		{"[203.0.113.17]:80", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := AddrPortFromString(tt.input)
+			assert.NotNil(t, err)
+			if parseErr, ok := err.(*ParseError); assert.True(t, ok) {
+				assert.Equal(t, tt.pos, parseErr.Pos)
+			}
+		})
+	}
+}
+
+func TestAddrPortComparable(t *testing.T) {
+	a := AddrPortFromAddressPort(_a("203.0.113.17"), 80)
+	m := map[AddrPort]int{a: 1}
+	assert.Equal(t, 1, m[AddrPortFromAddressPort(_a("203.0.113.17"), 80)])
+	assert.Equal(t, 0, m[AddrPortFromAddressPort(_a("203.0.113.17"), 443)])
+}
+
+func TestAddrPortNetConversions(t *testing.T) {
+	addrPort := AddrPortFromAddressPort(_a("203.0.113.17"), 80)
+
+	tcp := addrPort.ToNetTCPAddr()
+	assert.Equal(t, &net.TCPAddr{IP: net.IP{203, 0, 113, 17}, Port: 80}, tcp)
+	converted, err := AddrPortFromNetTCPAddr(tcp)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	udp := addrPort.ToNetUDPAddr()
+	assert.Equal(t, &net.UDPAddr{IP: net.IP{203, 0, 113, 17}, Port: 80}, udp)
+	converted, err = AddrPortFromNetUDPAddr(udp)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	// The net package often uses the 16 byte form
+	converted, err = AddrPortFromNetTCPAddr(&net.TCPAddr{IP: net.ParseIP("203.0.113.17"), Port: 80})
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	_, err = AddrPortFromNetTCPAddr(nil)
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetUDPAddr(nil)
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetTCPAddr(&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 80})
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetUDPAddr(&net.UDPAddr{IP: net.IP{203, 0, 113, 17}, Port: 65536})
+	assert.NotNil(t, err)
+}
+
+func TestAddrPortNetipAddrPort(t *testing.T) {
+	addrPort := AddrPortFromAddressPort(_a("203.0.113.17"), 80)
+	assert.Equal(t, netip.MustParseAddrPort("203.0.113.17:80"), addrPort.ToNetipAddrPort())
+
+	converted, err := AddrPortFromNetipAddrPort(netip.MustParseAddrPort("203.0.113.17:80"))
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	_, err = AddrPortFromNetipAddrPort(netip.MustParseAddrPort("[::ffff:203.0.113.17]:80"))
+	assert.NotNil(t, err)
+}
+
+func TestAddrPortJSON(t *testing.T) {
+	addrPort := AddrPortFromAddressPort(_a("203.0.113.17"), 80)
+	data, err := json.Marshal(addrPort)
+	assert.Nil(t, err)
+	assert.Equal(t, `"203.0.113.17:80"`, string(data))
+
+	var decoded AddrPort
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, addrPort, decoded)
+	assert.NotNil(t, json.Unmarshal([]byte(`"203.0.113.17"`), &decoded))
+}
@@ -0,0 +1,154 @@
+package ipv4
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The verbs are:
+//
+//	%s, %v  dotted-quad notation as returned by String
+//	%q      the same in double quotes
+//	%x, %X  the 32 bits in lowercase or uppercase hexadecimal, zero-padded
+//	%b      the 32 bits in binary, zero-padded
+//	%d      the 32 bits as a decimal integer
+//
+// The '#' flag adds a "0x" or "0b" prefix to %x, %X and %b. With %v, it
+// writes the Go syntax representation. A width pads the result with spaces,
+// on the left unless the '-' flag is given.
+func (me Address) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv4.Address{ui:%#v}", me.ui)
+		return
+	}
+	formatValue(f, verb, "ipv4.Address", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		return appendFormatUint32(b, me.ui, verb, sharp)
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address
+func (me Mask) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv4.Mask{ui:%#v}", me.ui)
+		return
+	}
+	formatValue(f, verb, "ipv4.Mask", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		return appendFormatUint32(b, me.ui, verb, sharp)
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to the address and the length is always decimal.
+func (me Prefix) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv4.Prefix{addr:%#v, length:%#v}", me.addr, me.length)
+		return
+	}
+	formatValue(f, verb, "ipv4.Prefix", me.String(), me.appendFormatInt)
+}
+
+// appendFormatInt appends the prefix with the address formatted for one of
+// the integer verbs supported by Format
+func (me Prefix) appendFormatInt(b []byte, verb rune, sharp bool) ([]byte, bool) {
+	b, ok := appendFormatUint32(b, me.addr.ui, verb, sharp)
+	b = append(b, '/')
+	return strconv.AppendUint(b, uint64(me.length), 10), ok
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to both addresses.
+func (me Range) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv4.Range{first:%#v, last:%#v}", me.first, me.last)
+		return
+	}
+	formatValue(f, verb, "ipv4.Range", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		b = append(b, '[')
+		b, ok := appendFormatUint32(b, me.first.ui, verb, sharp)
+		b = append(b, ',')
+		b, _ = appendFormatUint32(b, me.last.ui, verb, sharp)
+		return append(b, ']'), ok
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to each prefix in the set.
+func (me Set) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv4.Set{trie:%#v}", me.trie)
+		return
+	}
+	formatValue(f, verb, "ipv4.Set", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		ok := true
+		b = append(b, '[')
+		first := true
+		me.WalkPrefixes(func(p Prefix) bool {
+			if !first {
+				b = append(b, ',', ' ')
+			}
+			first = false
+			b, ok = p.appendFormatInt(b, verb, sharp)
+			return ok
+		})
+		return append(b, ']'), ok
+	})
+}
+
+// appendFormatUint32 appends the number formatted for one of the integer verbs
+// supported by Format. If the verb isn't supported, ok is false.
+func appendFormatUint32(b []byte, ui uint32, verb rune, sharp bool) (result []byte, ok bool) {
+	var base, width int
+	switch verb {
+	case 'x', 'X':
+		base, width = 16, 8
+		if sharp {
+			b = append(b, '0', byte(verb))
+		}
+	case 'b':
+		base, width = 2, 32
+		if sharp {
+			b = append(b, '0', 'b')
+		}
+	case 'd':
+		return strconv.AppendUint(b, uint64(ui), 10), true
+	default:
+		return b, false
+	}
+
+	digits := strconv.FormatUint(uint64(ui), base)
+	if verb == 'X' {
+		digits = strings.ToUpper(digits)
+	}
+	b = append(b, strings.Repeat("0", width-len(digits))...)
+	return append(b, digits...), true
+}
+
+// formatValue implements the parts of fmt.Formatter that are common to all of
+// the value types. str is the result for %s and %v. appendInt appends the
+// result for the integer verbs or returns false if the verb isn't supported.
+func formatValue(f fmt.State, verb rune, typeName, str string, appendInt func(b []byte, verb rune, sharp bool) ([]byte, bool)) {
+	var b []byte
+	switch verb {
+	case 's', 'v':
+		b = []byte(str)
+	case 'q':
+		b = strconv.AppendQuote(nil, str)
+	default:
+		var ok bool
+		if b, ok = appendInt(nil, verb, f.Flag('#')); !ok {
+			fmt.Fprintf(f, "%%!%c(%s=%s)", verb, typeName, str)
+			return
+		}
+	}
+
+	if width, ok := f.Width(); ok && width > len(b) {
+		padding := strings.Repeat(" ", width-len(b))
+		if f.Flag('-') {
+			b = append(b, padding...)
+		} else {
+			b = append([]byte(padding), b...)
+		}
+	}
+	f.Write(b)
+}
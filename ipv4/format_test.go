@@ -0,0 +1,60 @@
+package ipv4
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter(t *testing.T) {
+	addr := _a("203.0.113.17")
+	prefix := _p("203.0.113.0/24")
+	r := _r(_a("203.0.113.10"), _a("203.0.113.15"))
+
+	tests := []struct {
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{"%s", addr, "203.0.113.17"},
+		{"%v", addr, "203.0.113.17"},
+		{"%q", addr, `"203.0.113.17"`},
+		{"%x", addr, "cb007111"},
+		{"%#x", addr, "0xcb007111"},
+		{"%X", addr, "CB007111"},
+		{"%#X", addr, "0XCB007111"},
+		{"%x", _a("0.0.0.1"), "00000001"},
+		{"%b", _a("128.0.0.1"), "10000000000000000000000000000001"},
+		{"%#b", _a("0.0.0.1"), "0b00000000000000000000000000000001"},
+		{"%d", addr, "3405803793"},
+		{"%16s", addr, "    203.0.113.17"},
+		{"%-16s|", addr, "203.0.113.17    |"},
+		{"%12x", addr, "    cb007111"},
+		{"%z", addr, "%!z(ipv4.Address=203.0.113.17)"},
+		{"%#v", addr, "ipv4.Address{ui:0xcb007111}"},
+		{"%v", []Address{addr, addr}, "[203.0.113.17 203.0.113.17]"},
+
+		{"%s", prefix, "203.0.113.0/24"},
+		{"%x", prefix, "cb007100/24"},
+		{"%18s", prefix, "    203.0.113.0/24"},
+		{"%#v", prefix, "ipv4.Prefix{addr:ipv4.Address{ui:0xcb007100}, length:0x18}"},
+
+		{"%s", r, "[203.0.113.10,203.0.113.15]"},
+		{"%x", r, "[cb00710a,cb00710f]"},
+		{"%#v", r, "ipv4.Range{first:ipv4.Address{ui:0xcb00710a}, last:ipv4.Address{ui:0xcb00710f}}"},
+
+		{"%s", _m(24), "255.255.255.0"},
+		{"%b", _m(24), "11111111111111111111111100000000"},
+
+		{"%s", prefix.Set(), "[203.0.113.0/24]"},
+		{"%x", Set{}.Union(_a("10.0.0.1")).Union(_a("10.0.0.3")), "[0a000001/32, 0a000003/32]"},
+		{"%z", prefix.Set(), "%!z(ipv4.Set=[203.0.113.0/24])"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, tt.value))
+		})
+	}
+}
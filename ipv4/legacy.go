@@ -0,0 +1,229 @@
+package ipv4
+
+import (
+	"strings"
+)
+
+// LegacyForm describes the non-standard notations found by the legacy
+// parsers. The flags can be combined. The zero value means that the input was
+// in standard dotted-quad notation.
+type LegacyForm uint8
+
+const (
+	// LegacyShort means that an address had fewer than four parts and the
+	// last part filled all of the remaining bytes as with inet_aton (e.g.
+	// "10.1" is 10.0.0.1 and "167772161" is 10.0.0.1)
+	LegacyShort LegacyForm = 1 << iota
+	// LegacyOctal means that at least one part had a leading zero and was read
+	// as octal (e.g. "012.1.1.1" is 10.1.1.1). This is ambiguous because many
+	// other parsers read it as decimal.
+	LegacyOctal
+	// LegacyHex means that at least one part was hexadecimal with a "0x"
+	// prefix (e.g. "0x0a.0.0.1" is 10.0.0.1)
+	LegacyHex
+	// LegacyAbbreviatedPrefix means that the address of a prefix had fewer
+	// than four parts and the missing ones were taken to be zero (e.g.
+	// "172.16/12" is 172.16.0.0/12)
+	LegacyAbbreviatedPrefix
+)
+
+// String returns the names of the flags separated by "|" or "standard" if
+// there are none
+func (me LegacyForm) String() string {
+	if me == 0 {
+		return "standard"
+	}
+	var names []string
+	for _, flag := range []struct {
+		form LegacyForm
+		name string
+	}{
+		{LegacyShort, "short"},
+		{LegacyOctal, "octal"},
+		{LegacyHex, "hex"},
+		{LegacyAbbreviatedPrefix, "abbreviated-prefix"},
+	} {
+		if me&flag.form != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// IsAmbiguous returns true if the input could reasonably be read as a
+// different address by other software. This is the case when octal was used.
+func (me LegacyForm) IsAmbiguous() bool {
+	return me&LegacyOctal != 0
+}
+
+// parseLegacyPart parses a decimal, octal or hexadecimal number in s starting
+// at position i. It stops at the first character which is not a digit.
+func parseLegacyPart(s string, i int) (val uint32, end int, form LegacyForm, pos int, msg string) {
+	start := i
+	base := uint32(10)
+	switch {
+	case i+1 < len(s) && s[i] == '0' && (s[i+1] == 'x' || s[i+1] == 'X'):
+		base = 16
+		form = LegacyHex
+		i += 2
+	case i+1 < len(s) && s[i] == '0' && '0' <= s[i+1] && s[i+1] <= '9':
+		base = 8
+		form = LegacyOctal
+		i++
+	}
+
+	digitsStart := i
+	for ; i < len(s); i++ {
+		var digit uint32
+		c := s[i]
+		switch {
+		case '0' <= c && c <= '9':
+			digit = uint32(c - '0')
+		case 'a' <= c && c <= 'f':
+			digit = uint32(c-'a') + 10
+		case 'A' <= c && c <= 'F':
+			digit = uint32(c-'A') + 10
+		default:
+			digit = 0xff
+		}
+		if digit >= base {
+			if base == 8 && digit < 10 {
+				return 0, 0, 0, i, "invalid octal digit"
+			}
+			break
+		}
+		if val > (^uint32(0)-digit)/base {
+			return 0, 0, 0, start, "number is greater than 32 bits"
+		}
+		val = val*base + digit
+	}
+	if i == digitsStart {
+		if base == 16 {
+			return 0, 0, 0, i, "expected a hexadecimal number"
+		}
+		return 0, 0, 0, i, "expected a number"
+	}
+	return val, i, form, 0, ""
+}
+
+// legacyParts holds the numbers parsed by parseLegacyParts and where each
+// of them starts in the input
+type legacyParts struct {
+	vals   [4]uint32
+	starts [4]int
+	n      int
+}
+
+// parseLegacyParts parses one to four dot-separated parts which make up all
+// of s
+func parseLegacyParts(s string) (parts legacyParts, form LegacyForm, pos int, msg string) {
+	i := 0
+	for {
+		if parts.n == len(parts.vals) {
+			return legacyParts{}, 0, i - 1, "too many parts"
+		}
+		val, end, partForm, pos, msg := parseLegacyPart(s, i)
+		if msg != "" {
+			return legacyParts{}, 0, pos, msg
+		}
+		parts.vals[parts.n], parts.starts[parts.n] = val, i
+		parts.n++
+		form |= partForm
+		i = end
+		if i == len(s) {
+			return parts, form, 0, ""
+		}
+		if s[i] != '.' {
+			return legacyParts{}, 0, i, "unexpected character"
+		}
+		i++
+	}
+}
+
+// octets returns the first count parts as the high bytes of an address. Each
+// of them must be a single byte.
+func (me legacyParts) octets(count int) (ui uint32, pos int, msg string) {
+	for i := 0; i < count; i++ {
+		if me.vals[i] > 0xff {
+			return 0, me.starts[i], "part is greater than 255"
+		}
+		ui |= me.vals[i] << (24 - 8*i)
+	}
+	return ui, 0, ""
+}
+
+// parseLegacyAddress parses s with the semantics of inet_aton
+func parseLegacyAddress(s string) (addr Address, form LegacyForm, pos int, msg string) {
+	parts, form, pos, msg := parseLegacyParts(s)
+	if msg != "" {
+		return Address{}, 0, pos, msg
+	}
+
+	// All but the last part are single bytes. The last one fills the rest.
+	ui, pos, msg := parts.octets(parts.n - 1)
+	if msg != "" {
+		return Address{}, 0, pos, msg
+	}
+	last := parts.vals[parts.n-1]
+	if bits := 8 * (5 - parts.n); bits < 32 && last >= 1<<bits {
+		return Address{}, 0, parts.starts[parts.n-1], "last part is too large"
+	}
+	if parts.n < 4 {
+		form |= LegacyShort
+	}
+	return Address{ui | last}, form, 0, ""
+}
+
+// AddressFromStringLegacy parses an address with the semantics of inet_aton
+// as found in many old configuration files. Each of the one to four parts can
+// be decimal, octal with a leading zero, or hexadecimal with a "0x" prefix.
+// All but the last part are single bytes and the last part fills the
+// remaining bytes (e.g. "10.1" is 10.0.0.1). The form returned describes any
+// legacy notation that was found so that callers can warn about it (see
+// LegacyForm.IsAmbiguous).
+//
+// Input in standard dotted-quad notation gives the same result as
+// AddressFromString except that octets with leading zeros are accepted and
+// read as octal. Errors that occur while parsing are of type *ParseError.
+func AddressFromStringLegacy(address string) (Address, LegacyForm, error) {
+	addr, form, pos, msg := parseLegacyAddress(address)
+	if msg != "" {
+		return Address{}, 0, &ParseError{Input: address, Pos: pos, Msg: msg}
+	}
+	return addr, form, nil
+}
+
+// PrefixFromStringLegacy parses a prefix in CIDR notation as found in many old
+// configuration files. Each part of the address can be decimal, octal or
+// hexadecimal as for AddressFromStringLegacy but each must be a single byte.
+// Unlike inet_aton, missing parts are taken to be zero octets at the end
+// (e.g. "10/8" is 10.0.0.0/8 and "172.16/12" is 172.16.0.0/12). A leading zero
+// in the length is ignored. Host bits are allowed. Errors that occur while
+// parsing are of type *ParseError.
+func PrefixFromStringLegacy(prefix string) (Prefix, LegacyForm, error) {
+	fail := func(pos int, msg string) (Prefix, LegacyForm, error) {
+		return Prefix{}, 0, &ParseError{Input: prefix, Pos: pos, Msg: msg}
+	}
+
+	slash := strings.IndexByte(prefix, '/')
+	if slash < 0 {
+		return fail(len(prefix), "expected '/'")
+	}
+	parts, form, pos, msg := parseLegacyParts(prefix[:slash])
+	if msg != "" {
+		return fail(pos, msg)
+	}
+	ui, pos, msg := parts.octets(parts.n)
+	if msg != "" {
+		return fail(pos, msg)
+	}
+	if parts.n < 4 {
+		form |= LegacyAbbreviatedPrefix
+	}
+
+	length, pos, msg := parseLength(prefix, slash+1, false)
+	if msg != "" {
+		return fail(pos, msg)
+	}
+	return Prefix{Address{ui}, length}, form, nil
+}
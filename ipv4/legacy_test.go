@@ -0,0 +1,123 @@
+package ipv4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressFromStringLegacy(t *testing.T) {
+	tests := []struct {
+		input   string
+		address Address
+		form    LegacyForm
+	}{
+		{"203.0.113.17", _a("203.0.113.17"), 0},
+		{"0.0.0.0", _a("0.0.0.0"), 0},
+		{"255.255.255.255", _a("255.255.255.255"), 0},
+		{"012.1.1.1", _a("10.1.1.1"), LegacyOctal},
+		{"010.010.010.010", _a("8.8.8.8"), LegacyOctal},
+		{"00.0.0.1", _a("0.0.0.1"), LegacyOctal},
+		{"0x0a.0.0.1", _a("10.0.0.1"), LegacyHex},
+		{"0XA.0Xff.0x0.0xFF", _a("10.255.0.255"), LegacyHex},
+		{"10.1", _a("10.0.0.1"), LegacyShort},
+		{"10.1.2", _a("10.1.0.2"), LegacyShort},
+		{"10.1.258", _a("10.1.1.2"), LegacyShort},
+		{"10.65535", _a("10.0.255.255"), LegacyShort},
+		{"10.16777215", _a("10.255.255.255"), LegacyShort},
+		{"167772161", _a("10.0.0.1"), LegacyShort},
+		{"4294967295", _a("255.255.255.255"), LegacyShort},
+		{"0xa000001", _a("10.0.0.1"), LegacyShort | LegacyHex},
+		{"01200000001", _a("10.0.0.1"), LegacyShort | LegacyOctal},
+		{"0x7f.1", _a("127.0.0.1"), LegacyShort | LegacyHex},
+		{"012.0x1.1", _a("10.1.0.1"), LegacyShort | LegacyOctal | LegacyHex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			addr, form, err := AddressFromStringLegacy(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addr)
+			assert.Equal(t, tt.form, form)
+		})
+	}
+}
+
+func TestAddressFromStringLegacyErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"", 0},
+		{"1.2.3.4.5", 7},
+		{"1.2.3.256", 6},
+		{"256.1.1.1", 0},
+		{"1.2.65536", 4},
+		{"1.16777216", 2},
+		{"4294967296", 0},
+		{"0x100000000", 0},
+		{"08.1.1.1", 1},
+		{"1..1", 2},
+		{"1.2.3.", 6},
+		{"0x.1", 2},
+		{"1.2.3.4 ", 7},
+		{"1.2.3.4/24", 7},
+		{"0xg", 2},
+		{"::1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, _, err := AddressFromStringLegacy(tt.input)
+			assert.IsType(t, &ParseError{}, err)
+			if err, ok := err.(*ParseError); ok {
+				assert.Equal(t, tt.pos, err.Pos, err.Error())
+			}
+		})
+	}
+}
+
+func TestPrefixFromStringLegacy(t *testing.T) {
+	tests := []struct {
+		input  string
+		prefix Prefix
+		form   LegacyForm
+	}{
+		{"10.0.0.0/8", _p("10.0.0.0/8"), 0},
+		{"203.0.113.17/24", _p("203.0.113.17/24"), 0},
+		{"10/8", _p("10.0.0.0/8"), LegacyAbbreviatedPrefix},
+		{"172.16/12", _p("172.16.0.0/12"), LegacyAbbreviatedPrefix},
+		{"192.168.1/24", _p("192.168.1.0/24"), LegacyAbbreviatedPrefix},
+		{"0/0", _p("0.0.0.0/0"), LegacyAbbreviatedPrefix},
+		{"012.0.0.0/8", _p("10.0.0.0/8"), LegacyOctal},
+		{"0xac.0x10/12", _p("172.16.0.0/12"), LegacyAbbreviatedPrefix | LegacyHex},
+		{"10.0.0.0/08", _p("10.0.0.0/8"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			prefix, form, err := PrefixFromStringLegacy(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.prefix, prefix)
+			assert.Equal(t, tt.form, form)
+		})
+	}
+
+	for _, input := range []string{"10", "10/33", "256/8", "10.1.2.3.4/8", "167772160/8", "10/", "/8", "10/8x"} {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := PrefixFromStringLegacy(input)
+			assert.IsType(t, &ParseError{}, err)
+		})
+	}
+}
+
+func TestLegacyFormString(t *testing.T) {
+	assert.Equal(t, "standard", LegacyForm(0).String())
+	assert.Equal(t, "octal", LegacyOctal.String())
+	assert.Equal(t, "short|hex", (LegacyShort | LegacyHex).String())
+	assert.Equal(t, "octal|abbreviated-prefix", (LegacyAbbreviatedPrefix | LegacyOctal).String())
+
+	assert.False(t, LegacyForm(0).IsAmbiguous())
+	assert.False(t, (LegacyShort | LegacyHex | LegacyAbbreviatedPrefix).IsAmbiguous())
+	assert.True(t, (LegacyShort | LegacyOctal).IsAmbiguous())
+}
@@ -1,6 +1,7 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/bits"
 	"net"
@@ -61,6 +62,21 @@ func MaskFromNetIPMask(mask net.IPMask) (Mask, error) {
 	return m, nil
 }
 
+// MaskFromString returns the Mask represented by `mask` in dotted-quad
+// notation (e.g. 255.255.255.0). If it cannot be parsed or isn't a valid mask,
+// then error is non-nil and the Mask returned must be ignored.
+func MaskFromString(mask string) (Mask, error) {
+	address, err := AddressFromString(mask)
+	if err != nil {
+		return Mask{}, err
+	}
+	m := Mask{address.ui}
+	if !m.valid() {
+		return Mask{}, fmt.Errorf("failed to create a valid mask from string: %s", mask)
+	}
+	return m, nil
+}
+
 // Length returns the number of leading 1s in the mask
 func (me Mask) Length() int {
 	return bits.LeadingZeros32(^me.ui)
@@ -81,6 +97,34 @@ func (me Mask) Uint32() uint32 {
 	return me.ui
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Mask) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using MaskFromString
+func (me *Mask) UnmarshalText(text []byte) error {
+	mask, err := MaskFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = mask
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The mask is encoded as a JSON
+// string.
+func (me Mask) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the mask
+// unchanged.
+func (me *Mask) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
 func (me Mask) valid() bool {
 	return me.Length() == bits.OnesCount32(me.ui)
 }
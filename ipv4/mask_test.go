@@ -1,6 +1,7 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"net"
 	"testing"
 
@@ -148,3 +149,28 @@ func TestMaskAsMapKey(t *testing.T) {
 
 	assert.True(t, m[_m(27)])
 }
+
+func TestMaskFromString(t *testing.T) {
+	m, err := MaskFromString("255.255.255.0")
+	assert.Nil(t, err)
+	assert.Equal(t, _m(24), m)
+
+	_, err = MaskFromString("255.0.255.0")
+	assert.NotNil(t, err)
+
+	_, err = MaskFromString("bogus")
+	assert.NotNil(t, err)
+}
+
+func TestMaskMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(_m(20))
+	assert.Nil(t, err)
+	assert.Equal(t, `"255.255.240.0"`, string(data))
+
+	var m Mask
+	assert.Nil(t, json.Unmarshal(data, &m))
+	assert.Equal(t, _m(20), m)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"255.255.0.255"`), &m))
+	assert.Equal(t, _m(20), m)
+}
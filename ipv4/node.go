@@ -8,6 +8,7 @@ import (
 type trieNode struct {
 	Prefix   Prefix
 	Data     interface{}
+	numAddrs uint64
 	size     uint32
 	h        uint16
 	isActive bool
@@ -110,8 +111,10 @@ func (me *trieNode) mutate(mutator func(*trieNode)) *trieNode {
 
 	me.size = uint32(numNodes)
 	me.h = uint16(height)
+	me.numAddrs = uint64(me.children[0].NumAddresses() + me.children[1].NumAddresses())
 	if me.isActive {
 		me.size++
+		me.numAddrs = uint64(me.Prefix.NumAddresses())
 	}
 	return me
 }
@@ -240,18 +243,13 @@ func (me *trieNode) Match(searchKey Prefix) *trieNode {
 	return me
 }
 
-// NumAddresses returns the number of addresses that could match this node Note
-// that this may have to search all nodes recursively to find the answer. The
-// implementation can be changed to store the size in each node at the cost of
-// adding about 8 more bytes to the size of each node.
+// NumAddresses returns the number of addresses that could match this node. It
+// is stored in each node by mutate so it takes constant time.
 func (me *trieNode) NumAddresses() int64 {
 	if me == nil {
 		return 0
 	}
-	if me.isActive {
-		return int64(^me.Prefix.Mask().Uint32()) + 1
-	}
-	return me.children[0].NumAddresses() + me.children[1].NumAddresses()
+	return int64(me.numAddrs)
 }
 
 // NumNodes returns the number of entries in the trie
@@ -296,6 +294,13 @@ func (me *trieNode) isValidLen(minLen uint32) bool {
 	if me.h != 1+uint16(uint16(intMax(left.height(), right.height()))) {
 		return false
 	}
+	numAddrs := left.NumAddresses() + right.NumAddresses()
+	if me.isActive {
+		numAddrs = me.Prefix.NumAddresses()
+	}
+	if me.NumAddresses() != numAddrs {
+		return false
+	}
 	if me.Prefix.length < minLen {
 		return false
 	}
@@ -53,8 +53,8 @@ func TestStructSizes(t *testing.T) {
 	)
 	assert.Equal(t,
 		intMin(
-			48,
-			keySize+6*nodeAlign,
+			56,
+			keySize+8*nodeAlign,
 		),
 		nodeSize,
 	)
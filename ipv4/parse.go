@@ -0,0 +1,98 @@
+package ipv4
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is returned when a string cannot be parsed as an address or
+// prefix. It records where in the input the problem was found.
+type ParseError struct {
+	// Input is the complete string that was being parsed
+	Input string
+	// Pos is the byte offset in Input where the problem was found
+	Pos int
+	// Msg describes the problem
+	Msg string
+}
+
+// Error implements error
+func (me *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %q: %s at position %d", me.Input, me.Msg, me.Pos)
+}
+
+// parseAddress parses s which must be exactly an address in dotted-quad
+// notation. Octets with leading zeros are always rejected because other
+// parsers have historically interpreted them as octal. It does not allocate.
+// On failure, msg describes the problem found at position pos.
+func parseAddress(s string) (addr Address, pos int, msg string) {
+	var ui uint32
+	i := 0
+	for octet := 0; octet < 4; octet++ {
+		if octet > 0 {
+			if i == len(s) || s[i] != '.' {
+				return Address{}, i, "expected '.'"
+			}
+			i++
+		}
+		start := i
+		var val uint32
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			if i > start && s[start] == '0' {
+				return Address{}, start, "octet has a leading zero"
+			}
+			val = val*10 + uint32(s[i]-'0')
+			if val > 0xff {
+				return Address{}, start, "octet is greater than 255"
+			}
+		}
+		if i == start {
+			return Address{}, i, "expected a decimal octet"
+		}
+		ui = ui<<8 | val
+	}
+	if i != len(s) {
+		return Address{}, i, "unexpected character"
+	}
+	return Address{ui}, 0, ""
+}
+
+// parseLength parses the prefix length in s starting at position i through
+// the end of s. If strict, a leading zero is rejected.
+func parseLength(s string, i int, strict bool) (length uint32, pos int, msg string) {
+	start := i
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if strict && i > start && s[start] == '0' {
+			return 0, start, "prefix length has a leading zero"
+		}
+		length = length*10 + uint32(s[i]-'0')
+		if length > uint32(addressSize) {
+			return 0, start, "prefix length is greater than 32"
+		}
+	}
+	if i == start {
+		return 0, i, "expected a decimal prefix length"
+	}
+	if i != len(s) {
+		return 0, i, "unexpected character"
+	}
+	return length, 0, ""
+}
+
+// parsePrefix parses s which must be exactly a prefix in dotted-quad CIDR
+// notation. Host bits are allowed in the address. It does not allocate.
+func parsePrefix(s string, strict bool) (prefix Prefix, pos int, msg string) {
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 {
+		return Prefix{}, len(s), "expected '/'"
+	}
+	addr, pos, msg := parseAddress(s[:slash])
+	if msg != "" {
+		return Prefix{}, pos, msg
+	}
+	length, pos, msg := parseLength(s, slash+1, strict)
+	if msg != "" {
+		return Prefix{}, pos, msg
+	}
+	return Prefix{addr, length}, 0, ""
+}
@@ -0,0 +1,120 @@
+package ipv4
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		expected    Address
+		lenient     bool // only the lenient parser accepts it
+		pos         int
+		isErr       bool
+	}{
+		{description: "zero", input: "0.0.0.0", expected: AddressFromUint32(0)},
+		{description: "max", input: "255.255.255.255", expected: AddressFromUint32(0xffffffff)},
+		{description: "typical", input: "10.224.24.1", expected: AddressFromUint32(0x0ae01801)},
+		{description: "mapped", input: "::ffff:10.224.24.1", expected: AddressFromUint32(0x0ae01801), lenient: true},
+		{description: "empty", input: "", isErr: true, pos: 0},
+		{description: "leading zero", input: "10.224.024.1", isErr: true, pos: 7},
+		{description: "out of range", input: "10.224.256.1", isErr: true, pos: 7},
+		{description: "long octet", input: "10.224.2560000000000.1", isErr: true, pos: 7},
+		{description: "too few octets", input: "10.224.24", isErr: true, pos: 9},
+		{description: "too many octets", input: "10.224.24.1.5", isErr: true, pos: 11},
+		{description: "empty octet", input: "10..24.1", isErr: true, pos: 3},
+		{description: "trailing dot", input: "10.224.24.1.", isErr: true, pos: 11},
+		{description: "sign", input: "+10.224.24.1", isErr: true, pos: 0},
+		{description: "space", input: "10.224.24.1 ", isErr: true, pos: 11},
+		{description: "ipv6", input: "2001:db8::1", isErr: true, pos: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			addr, err := AddressFromString(tt.input)
+			strictAddr, strictErr := AddressFromStringStrict(tt.input)
+			if tt.isErr {
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, err, strictErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, addr)
+			if tt.lenient {
+				assert.NotNil(t, strictErr)
+			} else {
+				assert.Nil(t, strictErr)
+				assert.Equal(t, tt.expected, strictAddr)
+				assert.Equal(t, tt.input, strictAddr.String())
+			}
+		})
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		expected    Prefix
+		lenient     bool // only the lenient parser accepts it
+		pos         int
+		isErr       bool
+	}{
+		{description: "default", input: "0.0.0.0/0", expected: unsafePrefixFromUint32(0, 0)},
+		{description: "host", input: "10.224.24.1/32", expected: unsafePrefixFromUint32(0x0ae01801, 32)},
+		{description: "host bits", input: "10.224.24.1/22", expected: unsafePrefixFromUint32(0x0ae01801, 22)},
+		{description: "leading zero length", input: "10.224.24.0/024", expected: unsafePrefixFromUint32(0x0ae01800, 24), lenient: true},
+		{description: "no length", input: "10.224.24.1", isErr: true, pos: 11},
+		{description: "empty length", input: "10.224.24.1/", isErr: true, pos: 12},
+		{description: "length too long", input: "10.224.24.1/33", isErr: true, pos: 12},
+		{description: "length garbage", input: "10.224.24.1/2x", isErr: true, pos: 13},
+		{description: "bad address", input: "10.224.24.01/24", isErr: true, pos: 10},
+		{description: "mapped", input: "::ffff:10.224.24.1/120", isErr: true, pos: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			prefix, err := PrefixFromString(tt.input)
+			strictPrefix, strictErr := PrefixFromStringStrict(tt.input)
+			if tt.isErr {
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, err, strictErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, prefix)
+			if tt.lenient {
+				assert.NotNil(t, strictErr)
+			} else {
+				assert.Nil(t, strictErr)
+				assert.Equal(t, tt.expected, strictPrefix)
+				assert.Equal(t, tt.input, strictPrefix.String())
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := AddressFromString("10.224.256.1")
+	assert.Equal(t, `failed to parse "10.224.256.1": octet is greater than 255 at position 7`, err.Error())
+}
+
+func TestParseAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = AddressFromString("10.224.24.1")
+		_, _ = AddressFromStringStrict("10.224.24.1")
+		_, _ = PrefixFromString("10.224.24.0/24")
+		_, _ = PrefixFromStringStrict("10.224.24.0/24")
+	})
+	assert.Equal(t, float64(0), allocs)
+}
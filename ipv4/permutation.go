@@ -0,0 +1,169 @@
+package ipv4
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand"
+)
+
+// PermutationIterator visits every address in a set exactly once in a
+// pseudo-random order using constant state. It is the cyclic group
+// permutation used by network scanners like zmap: the index of each address
+// in the set is taken from the multiplicative group of integers modulo the
+// smallest prime greater than the number of addresses. Get one from
+// Set.Permutation. The zero value produces nothing.
+type PermutationIterator struct {
+	set      Set
+	count    uint64 // the number of addresses in the set
+	prime    uint64 // the smallest prime greater than count
+	first    uint64 // the first element of the cycle in this shard
+	step     uint64 // multiplies one element of this shard to get the next
+	current  uint64 // the next element of the cycle
+	position uint64 // the number of elements of this shard already consumed
+	length   uint64 // the number of elements of the cycle in this shard
+}
+
+// Permutation returns an iterator over the addresses in this set in a
+// pseudo-random order determined by the seed. The same set and seed always
+// produce the same order.
+//
+// The work can be split between a number of shards, numbered from 0. Each
+// shard visits a disjoint part of the permutation and, together, they visit
+// every address exactly once. Use 0 and 1 to visit every address with one
+// iterator. It is an error if the shard isn't less than the number of shards.
+func (me Set) Permutation(seed int64, shard, shards uint64) (PermutationIterator, error) {
+	if shard >= shards {
+		return PermutationIterator{}, fmt.Errorf("shard %d is out of range for %d shards", shard, shards)
+	}
+	count := uint64(me.NumAddresses())
+	if count == 0 {
+		return PermutationIterator{}, nil
+	}
+
+	// The cycle of the group is every integer from 1 to prime - 1. Each shard
+	// takes every nth element of the cycle, starting with the shard number.
+	prime := nextPrime(count)
+	random := rand.New(rand.NewSource(seed))
+	generator := primitiveRoot(prime, random)
+	start := 1 + uint64(random.Int63n(int64(prime-1)))
+	first := mulMod(start, powMod(generator, shard, prime), prime)
+
+	var length uint64
+	if shard < prime-1 {
+		length = (prime - 1 - shard + shards - 1) / shards
+	}
+	return PermutationIterator{
+		set:     me,
+		count:   count,
+		prime:   prime,
+		first:   first,
+		step:    powMod(generator, shards, prime),
+		current: first,
+		length:  length,
+	}, nil
+}
+
+// Next returns the next address. When there are none left, ok is false and
+// the address must be ignored.
+func (me *PermutationIterator) Next() (addr Address, ok bool) {
+	for me.position < me.length {
+		element := me.current
+		me.current = mulMod(me.current, me.step, me.prime)
+		me.position++
+
+		// Elements of the cycle past the end of the set are skipped. Since the
+		// prime is the smallest one past the count, there are few of them.
+		if index := element - 1; index < me.count {
+			return me.set.trie.addressAt(index), true
+		}
+	}
+	return Address{}, false
+}
+
+// Checkpoint returns the progress of the iterator so that iteration can be
+// picked up later, with Resume, from where it left off
+func (me *PermutationIterator) Checkpoint() uint64 {
+	return me.position
+}
+
+// Resume moves the iterator to a checkpoint returned by Checkpoint. It must be
+// called on an iterator created from the same set, seed and shard as the one
+// that made the checkpoint. It is an error if the checkpoint is past the end
+// of the iterator.
+func (me *PermutationIterator) Resume(checkpoint uint64) error {
+	if checkpoint > me.length {
+		return fmt.Errorf("checkpoint %d is past the end of the permutation", checkpoint)
+	}
+	if me.length != 0 {
+		me.current = mulMod(me.first, powMod(me.step, checkpoint, me.prime), me.prime)
+	}
+	me.position = checkpoint
+	return nil
+}
+
+// mulMod returns a * b mod m without overflowing
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// powMod returns base ^ exp mod m by repeated squaring
+func powMod(base, exp, m uint64) uint64 {
+	result := 1 % m
+	base %= m
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return result
+}
+
+// nextPrime returns the smallest prime greater than n
+func nextPrime(n uint64) uint64 {
+	for candidate := n + 1; ; candidate++ {
+		// ProbablyPrime is exact for inputs less than 2^64
+		if new(big.Int).SetUint64(candidate).ProbablyPrime(0) {
+			return candidate
+		}
+	}
+}
+
+// primitiveRoot returns a randomly chosen generator of the multiplicative
+// group of integers modulo the given prime. A candidate is a generator if
+// raising it to (prime - 1) / q isn't 1 for every prime factor q of prime - 1.
+func primitiveRoot(prime uint64, random *rand.Rand) uint64 {
+	if prime < 3 {
+		return 1
+	}
+
+	var factors []uint64
+	n := prime - 1
+	for q := uint64(2); q*q <= n; q++ {
+		if n%q == 0 {
+			factors = append(factors, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+
+	for {
+		candidate := 2 + uint64(random.Int63n(int64(prime-2)))
+		generator := true
+		for _, q := range factors {
+			if powMod(candidate, (prime-1)/q, prime) == 1 {
+				generator = false
+				break
+			}
+		}
+		if generator {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package ipv4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectPermutation(it *PermutationIterator) (addrs []Address) {
+	for {
+		addr, ok := it.Next()
+		if !ok {
+			return
+		}
+		addrs = append(addrs, addr)
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/22", "10.0.7.3/32", "192.168.0.0/25")
+
+	it, err := set.Permutation(29, 0, 1)
+	require.Nil(t, err)
+	addrs := collectPermutation(&it)
+	assert.Len(t, addrs, int(set.NumAddresses()))
+
+	// Every address is visited exactly once
+	visited := NewSet_()
+	for _, addr := range addrs {
+		assert.True(t, set.Contains(addr))
+		assert.False(t, visited.Contains(addr))
+		visited.Insert(addr)
+	}
+	assert.True(t, set.Equal(visited.Set()))
+
+	// The order is shuffled
+	ordered := 0
+	for i := 1; i < len(addrs); i++ {
+		if addrs[i-1].ui+1 == addrs[i].ui {
+			ordered++
+		}
+	}
+	assert.Less(t, ordered, len(addrs)/10)
+}
+
+func TestPermutationSeed(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/24")
+
+	permutation := func(seed int64) []Address {
+		it, err := set.Permutation(seed, 0, 1)
+		require.Nil(t, err)
+		return collectPermutation(&it)
+	}
+	assert.Equal(t, permutation(29), permutation(29))
+	assert.NotEqual(t, permutation(29), permutation(30))
+}
+
+func TestPermutationShards(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/22", "10.0.7.3/32", "192.168.0.0/25")
+
+	for _, shards := range []uint64{1, 2, 3, 7, 16} {
+		visited := NewSet_()
+		total := 0
+		for shard := uint64(0); shard < shards; shard++ {
+			it, err := set.Permutation(29, shard, shards)
+			require.Nil(t, err)
+			for _, addr := range collectPermutation(&it) {
+				assert.False(t, visited.Contains(addr))
+				visited.Insert(addr)
+				total++
+			}
+		}
+		assert.Equal(t, int(set.NumAddresses()), total)
+		assert.True(t, set.Equal(visited.Set()))
+	}
+}
+
+func TestPermutationCheckpoint(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/22", "192.168.0.0/25")
+
+	it, err := set.Permutation(29, 1, 3)
+	require.Nil(t, err)
+	expected := collectPermutation(&it)
+
+	it, err = set.Permutation(29, 1, 3)
+	require.Nil(t, err)
+	var addrs []Address
+	for i := 0; i < 100; i++ {
+		addr, ok := it.Next()
+		require.True(t, ok)
+		addrs = append(addrs, addr)
+	}
+	checkpoint := it.Checkpoint()
+
+	resumed, err := set.Permutation(29, 1, 3)
+	require.Nil(t, err)
+	assert.Nil(t, resumed.Resume(checkpoint))
+	addrs = append(addrs, collectPermutation(&resumed)...)
+	assert.Equal(t, expected, addrs)
+
+	assert.NotNil(t, resumed.Resume(resumed.Checkpoint()+1))
+}
+
+func TestPermutationErrors(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/24")
+
+	_, err := set.Permutation(29, 0, 0)
+	assert.NotNil(t, err)
+	_, err = set.Permutation(29, 3, 3)
+	assert.NotNil(t, err)
+}
+
+func TestPermutationSmall(t *testing.T) {
+	it, err := Set{}.Permutation(29, 0, 1)
+	require.Nil(t, err)
+	assert.Empty(t, collectPermutation(&it))
+
+	it = PermutationIterator{}
+	assert.Nil(t, it.Resume(0))
+	assert.Empty(t, collectPermutation(&it))
+
+	it, err = setFromPrefixes("10.0.0.1/32").Permutation(29, 0, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []Address{_a("10.0.0.1")}, collectPermutation(&it))
+
+	// There are more shards than addresses
+	it, err = setFromPrefixes("10.0.0.1/32").Permutation(29, 5, 8)
+	require.Nil(t, err)
+	assert.Empty(t, collectPermutation(&it))
+}
+
+func TestPermutationEverything(t *testing.T) {
+	set := setFromPrefixes("0.0.0.0/0")
+
+	it, err := set.Permutation(29, 0, 1)
+	require.Nil(t, err)
+	seen := map[Address]bool{}
+	for i := 0; i < 1000; i++ {
+		addr, ok := it.Next()
+		require.True(t, ok)
+		assert.False(t, seen[addr])
+		seen[addr] = true
+	}
+}
@@ -1,9 +1,11 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"net"
+	"net/netip"
 )
 
 // Prefix represents an IP prefix which is formally an Address plus a Mask. It
@@ -49,6 +51,22 @@ func PrefixFromNetIPNet(net *net.IPNet) (Prefix, error) {
 	}, nil
 }
 
+// PrefixFromNetipPrefix converts the given netip.Prefix to a Prefix. It does not
+// allocate. Its address must be IPv4 as described for AddressFromNetipAddr.
+func PrefixFromNetipPrefix(prefix netip.Prefix) (Prefix, error) {
+	if !prefix.IsValid() {
+		return Prefix{}, fmt.Errorf("failed to convert invalid netip.Prefix")
+	}
+	addr, err := AddressFromNetipAddr(prefix.Addr())
+	if err != nil {
+		return Prefix{}, err
+	}
+	return Prefix{
+		addr:   addr,
+		length: uint32(prefix.Bits()),
+	}, nil
+}
+
 // PrefixFromAddressMask combines the address and mask into a prefix
 func PrefixFromAddressMask(address Address, mask Mask) Prefix {
 	return Prefix{
@@ -57,28 +75,31 @@ func PrefixFromAddressMask(address Address, mask Mask) Prefix {
 	}
 }
 
-// parseNet returns a single *net.IPNet that unifies the IP address and
-// the mask. It leaves out the network address which net.ParseCIDR returns.
-// This may be considered an abuse of the IPNet construct as it is documented
-// that IP is supposed to be the "network number". However, the public IPNet
-// interface does not dissallow it and this usage has been spotted in the wild.
-func parseNet(prefix string) (*net.IPNet, error) {
-	ip, ipNet, err := net.ParseCIDR(prefix)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse prefix: %s", prefix)
-	}
-	return &net.IPNet{IP: ip, Mask: ipNet.Mask}, nil
-}
-
 // PrefixFromString returns the net represented by `addr` in dotted-quad CIDR
 // notation. If the prefix cannot be parsed, then error is non-nil and the
 // prefix returned must be ignored.
+//
+// It does not allocate unless it fails. Host bits are allowed in the address
+// and are kept in the result. Errors that occur while parsing are of type
+// *ParseError.
 func PrefixFromString(prefix string) (Prefix, error) {
-	ipNet, err := parseNet(prefix)
-	if err != nil {
-		return Prefix{}, err
+	p, pos, msg := parsePrefix(prefix, false)
+	if msg != "" {
+		return Prefix{}, &ParseError{Input: prefix, Pos: pos, Msg: msg}
 	}
-	return PrefixFromNetIPNet(ipNet)
+	return p, nil
+}
+
+// PrefixFromStringStrict is like PrefixFromString but accepts only the
+// canonical form of a prefix, which is what String returns. In addition to the
+// rules for AddressFromStringStrict, the length must not have leading zeros.
+// Host bits are still allowed.
+func PrefixFromStringStrict(prefix string) (Prefix, error) {
+	p, pos, msg := parsePrefix(prefix, true)
+	if msg != "" {
+		return Prefix{}, &ParseError{Input: prefix, Pos: pos, Msg: msg}
+	}
+	return p, nil
 }
 
 // Address returns the address part of the Prefix, including host bits
@@ -91,6 +112,13 @@ func (me Prefix) Prefix() Prefix {
 	return me
 }
 
+// ToNetipPrefix returns the netip.Prefix representation of this prefix. Like
+// Prefix, it keeps any bits set in the host part of the address. It does not
+// allocate.
+func (me Prefix) ToNetipPrefix() netip.Prefix {
+	return netip.PrefixFrom(me.addr.ToNetipAddr(), me.Length())
+}
+
 // ToNetIPNet returns a *net.IPNet representation of this prefix
 func (me Prefix) ToNetIPNet() *net.IPNet {
 	return &net.IPNet{
@@ -198,6 +226,34 @@ func (me Prefix) String() string {
 	return fmt.Sprintf("%s/%d", me.addr.String(), me.Length())
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Prefix) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using PrefixFromString
+func (me *Prefix) UnmarshalText(text []byte) error {
+	prefix, err := PrefixFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = prefix
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The prefix is encoded as a JSON
+// string.
+func (me Prefix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the prefix
+// unchanged.
+func (me *Prefix) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
 // Uint32 returns the address and mask as uint32s
 func (me Prefix) Uint32() (address, mask uint32) {
 	address = me.addr.Uint32()
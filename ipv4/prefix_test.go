@@ -1,8 +1,10 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -25,14 +27,6 @@ func unsafePrefixFromUint32(ip uint32, length int) Prefix {
 	return PrefixFromAddressMask(Address{ip}, mask)
 }
 
-func unsafeParseNet(prefix string) *net.IPNet {
-	ipNet, err := parseNet(prefix)
-	if err != nil {
-		panic("only use this in happy cases")
-	}
-	return ipNet
-}
-
 func TestPrefixComparable(t *testing.T) {
 	tests := []struct {
 		description string
@@ -125,19 +119,19 @@ func TestPrefixFromNetIPNet(t *testing.T) {
 		},
 		{
 			description: "ipv4",
-			net:         unsafeParseNet("10.224.24.1/22"),
+			net:         &net.IPNet{IP: net.ParseIP("10.224.24.1"), Mask: net.CIDRMask(22, 32)},
 			expected:    unsafePrefixFromUint32(0x0ae01801, 22),
 		},
 		{
 			description: "ipv6",
-			net:         unsafeParseNet("2001::/56"),
+			net:         &net.IPNet{IP: net.ParseIP("2001::"), Mask: net.CIDRMask(56, 128)},
 			isErr:       true,
 		},
 		{
 			description: "mixed up IPv4/6 IPNet",
 			net: &net.IPNet{
-				IP:   unsafeParseNet("2001::/56").IP,
-				Mask: unsafeParseNet("10.0.0.0/16").Mask,
+				IP:   net.ParseIP("2001::"),
+				Mask: net.CIDRMask(16, 32),
 			},
 			isErr: true,
 		},
@@ -577,3 +571,62 @@ func TestPrefixNumPrefixes(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefixMarshalText(t *testing.T) {
+	text, err := _p("10.224.24.1/24").MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.224.24.1/24", string(text))
+
+	var p Prefix
+	assert.Nil(t, p.UnmarshalText(text))
+	assert.Equal(t, _p("10.224.24.1/24"), p)
+
+	assert.NotNil(t, p.UnmarshalText([]byte("10.224.24.1/33")))
+	assert.Equal(t, _p("10.224.24.1/24"), p)
+}
+
+func TestPrefixMarshalJSON(t *testing.T) {
+	prefixes := map[string]Prefix{
+		"a": _p("10.0.0.0/8"),
+		"b": _p("192.168.1.17/24"),
+	}
+	data, err := json.Marshal(prefixes)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"10.0.0.0/8","b":"192.168.1.17/24"}`, string(data))
+
+	var decoded map[string]Prefix
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, prefixes, decoded)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":"10.0.0.0"}`), &decoded))
+}
+
+func TestPrefixAsJSONMapKey(t *testing.T) {
+	m := map[Prefix]int{
+		_p("10.0.0.0/8"): 1,
+	}
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"10.0.0.0/8":1}`, string(data))
+
+	var decoded map[Prefix]int
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, m, decoded)
+}
+
+func TestPrefixFromNetipPrefix(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		_, err := PrefixFromNetipPrefix(netip.Prefix{})
+		assert.NotNil(t, err)
+	})
+	t.Run("wrong family", func(t *testing.T) {
+		_, err := PrefixFromNetipPrefix(netip.MustParsePrefix("2001:db8::/32"))
+		assert.NotNil(t, err)
+	})
+	t.Run("host bits", func(t *testing.T) {
+		prefix, err := PrefixFromNetipPrefix(netip.MustParsePrefix("10.224.24.1/22"))
+		assert.Nil(t, err)
+		assert.Equal(t, _p("10.224.24.1/22"), prefix)
+		assert.Equal(t, netip.MustParsePrefix("10.224.24.1/22"), prefix.ToNetipPrefix())
+	})
+}
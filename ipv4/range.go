@@ -1,7 +1,11 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
 )
 
 // Range represents a range of addresses that don't have to be aligned to
@@ -40,6 +44,81 @@ func (me Range) NumAddresses() int64 {
 	return int64(c)
 }
 
+// RangeFromString returns the Range represented by `r` which is two addresses
+// separated by a dash (e.g. "10.0.0.1-10.0.0.254"). The last address may be
+// shortened to its last one, two or three octets which then replace those of
+// the first address (e.g. "10.0.0.1-254" or "10.0.0.1-1.254"). The format
+// produced by String() (e.g. "[10.0.0.1,10.0.0.254]") is also accepted. If it
+// cannot be parsed or first comes after last, then error is non-nil and the
+// Range returned must be ignored.
+func RangeFromString(r string) (Range, error) {
+	if strings.HasPrefix(r, "[") {
+		return parseRange(r)
+	}
+	firstStr, lastStr, found := strings.Cut(r, "-")
+	if !found {
+		return Range{}, fmt.Errorf("failed to parse range (expected '-'): %s", r)
+	}
+	first, err := AddressFromString(strings.TrimSpace(firstStr))
+	if err != nil {
+		return Range{}, err
+	}
+	last, err := parseRangeLast(first, strings.TrimSpace(lastStr))
+	if err != nil {
+		return Range{}, err
+	}
+	result, empty := RangeFromAddresses(first, last)
+	if empty {
+		return Range{}, fmt.Errorf("failed to parse range with first address after last: %s", r)
+	}
+	return result, nil
+}
+
+// parseRangeLast parses the last address of a range which may be shortened to
+// its last one to three octets. Those replace the same octets in first.
+func parseRangeLast(first Address, last string) (Address, error) {
+	parts := strings.SplitN(last, ".", 4)
+	if len(parts) == 4 {
+		return AddressFromString(last)
+	}
+	shift := 8 * len(parts)
+	ui := first.ui >> shift << shift
+	for i, part := range parts {
+		octet, err := strconv.ParseUint(part, 10, 8)
+		if err != nil || len(part) > 1 && part[0] == '0' {
+			return Address{}, fmt.Errorf("failed to parse the last address of range: %s", last)
+		}
+		ui |= uint32(octet) << (8 * (len(parts) - 1 - i))
+	}
+	return Address{ui}, nil
+}
+
+// parseRange returns the Range represented by `r` in the format produced by
+// String() (e.g. "[first,last]"). If it cannot be parsed or first comes after
+// last, then error is non-nil and the Range returned must be ignored.
+func parseRange(r string) (Range, error) {
+	if !strings.HasPrefix(r, "[") || !strings.HasSuffix(r, "]") {
+		return Range{}, fmt.Errorf("failed to parse range: %s", r)
+	}
+	parts := strings.Split(r[1:len(r)-1], ",")
+	if len(parts) != 2 {
+		return Range{}, fmt.Errorf("failed to parse range: %s", r)
+	}
+	first, err := AddressFromString(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Range{}, err
+	}
+	last, err := AddressFromString(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Range{}, err
+	}
+	result, empty := RangeFromAddresses(first, last)
+	if empty {
+		return Range{}, fmt.Errorf("failed to parse range with first address after last: %s", r)
+	}
+	return result, nil
+}
+
 // First returns the first address in the range
 func (me Range) First() Address {
 	return me.first
@@ -50,15 +129,103 @@ func (me Range) Last() Address {
 	return me.last
 }
 
+// String returns the range in the format "[first,last]"
 func (me Range) String() string {
 	return fmt.Sprintf("[%s,%s]", me.first, me.last)
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Range) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the format
+// produced by String().
+func (me *Range) UnmarshalText(text []byte) error {
+	r, err := parseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*me = r
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The range is encoded as a JSON
+// string.
+func (me Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the range
+// unchanged.
+func (me *Range) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
 // Contains returns true iff this range entirely contains the given other range
 func (me Range) Contains(other SetI) bool {
 	return me.Set().Contains(other)
 }
 
+// Overlaps returns true if this range and the other have at least one address
+// in common
+func (me Range) Overlaps(other Range) bool {
+	return !me.last.lessThan(other.first) && !other.last.lessThan(me.first)
+}
+
+// WalkPrefixes calls `callback` for each prefix in the smallest list of
+// prefixes that covers the range exactly, in lexigraphical order. It computes
+// them directly without building a Set. It stops iteration immediately if
+// callback returns false.
+//
+// It returns false if iteration was stopped due to a callback return false or
+// true if it iterated all items.
+func (me Range) WalkPrefixes(callback func(Prefix) bool) bool {
+	first, last := uint64(me.first.ui), uint64(me.last.ui)
+	for first <= last {
+		// The prefix must start at an address aligned to its size and can't
+		// go past the end of the range
+		size := intMin(
+			bits.TrailingZeros64(first|1<<addressSize),
+			63-bits.LeadingZeros64(last-first+1),
+		)
+		if !callback(Prefix{Address{uint32(first)}, uint32(addressSize - size)}) {
+			return false
+		}
+		first += 1 << size
+	}
+	return true
+}
+
+// Prefixes returns the smallest list of prefixes that covers the range
+// exactly, in lexigraphical order. See WalkPrefixes.
+func (me Range) Prefixes() []Prefix {
+	prefixes := []Prefix{}
+	me.WalkPrefixes(func(prefix Prefix) bool {
+		prefixes = append(prefixes, prefix)
+		return true
+	})
+	return prefixes
+}
+
+// WalkAddresses calls `callback` for each address in the range in
+// lexographical order. It stops iteration immediately if callback returns
+// false.
+//
+// It returns false if iteration was stopped due to a callback return false or
+// true if it iterated all items.
+func (me Range) WalkAddresses(callback func(Address) bool) bool {
+	for addr := me.first; ; addr.ui++ {
+		if !callback(addr) {
+			return false
+		}
+		if addr == me.last {
+			return true
+		}
+	}
+}
+
 // Minus returns a slice of ranges resulting from subtracting the given range
 // The slice will contain from 0 to 2 new ranges depending on how they overlap
 func (me Range) Minus(other Range) []Range {
@@ -107,11 +274,13 @@ func (me Range) Set() Set {
 // prev returns the address just before the range (or maxint) if the range
 // starts at the beginning of the IP space due to overflow)
 func (me Range) prev() Address {
-	return Address{me.first.ui - 1}
+	prev, _ := me.first.Prev()
+	return prev
 }
 
 // next returns the next address after the range (or 0 if the range goes to the
 // end of the IP space due to overflow)
 func (me Range) next() Address {
-	return Address{me.last.ui + 1}
+	next, _ := me.last.Next()
+	return next
 }
@@ -1,6 +1,8 @@
 package ipv4
 
 import (
+	"encoding/json"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -428,3 +430,233 @@ func TestRangeNumPrefixes(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeMarshalText(t *testing.T) {
+	tests := []struct {
+		description string
+		text        string
+		r           Range
+		isErr       bool
+	}{
+		{
+			description: "simple",
+			text:        "[10.0.0.1,10.0.0.254]",
+			r:           _r(_a("10.0.0.1"), _a("10.0.0.254")),
+		}, {
+			description: "spaces",
+			text:        "[10.0.0.1, 10.0.0.254]",
+			r:           _r(_a("10.0.0.1"), _a("10.0.0.254")),
+		}, {
+			description: "backwards",
+			text:        "[10.0.0.254,10.0.0.1]",
+			isErr:       true,
+		}, {
+			description: "no brackets",
+			text:        "10.0.0.1,10.0.0.254",
+			isErr:       true,
+		}, {
+			description: "too many",
+			text:        "[10.0.0.1,10.0.0.2,10.0.0.3]",
+			isErr:       true,
+		}, {
+			description: "bad address",
+			text:        "[10.0.0.1,10.0.0.256]",
+			isErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var r Range
+			err := r.UnmarshalText([]byte(tt.text))
+			if tt.isErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.r, r)
+
+			text, err := r.MarshalText()
+			assert.Nil(t, err)
+			assert.Equal(t, r.String(), string(text))
+		})
+	}
+}
+
+func TestRangeMarshalJSON(t *testing.T) {
+	r := _r(_a("10.0.0.1"), _a("10.0.0.254"))
+	data, err := json.Marshal(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `"[10.0.0.1,10.0.0.254]"`, string(data))
+
+	var decoded Range
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, r, decoded)
+}
+
+func TestRangeFromString(t *testing.T) {
+	tests := []struct {
+		input string
+		r     Range
+	}{
+		{"10.0.0.1-10.0.0.254", _r(_a("10.0.0.1"), _a("10.0.0.254"))},
+		{"10.0.0.1 - 10.0.0.254", _r(_a("10.0.0.1"), _a("10.0.0.254"))},
+		{"10.0.0.1-254", _r(_a("10.0.0.1"), _a("10.0.0.254"))},
+		{"10.0.0.1-1.254", _r(_a("10.0.0.1"), _a("10.0.1.254"))},
+		{"10.0.0.1-1.0.0", _r(_a("10.0.0.1"), _a("10.1.0.0"))},
+		{"10.0.0.1-10.0.0.1", _r(_a("10.0.0.1"), _a("10.0.0.1"))},
+		{"0.0.0.0-255.255.255.255", _r(_a("0.0.0.0"), _a("255.255.255.255"))},
+		{"[10.0.0.1,10.0.0.254]", _r(_a("10.0.0.1"), _a("10.0.0.254"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := RangeFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.r, r)
+		})
+	}
+
+	for _, input := range []string{
+		"",
+		"10.0.0.1",
+		"10.0.0.1-",
+		"10.0.0.1-256",
+		"10.0.0.1-01",
+		"10.0.0.1-0x1",
+		"10.0.0.1--1",
+		"10.0.0.2-1",
+		"10.0.0.1-10.0.0.0",
+		"10.0.0-10.0.0.1",
+		"10.0.0.1-10.0.0.1.1",
+	} {
+		_, err := RangeFromString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	r := _r(_a("10.0.0.10"), _a("10.0.0.20"))
+	assert.True(t, r.Overlaps(r))
+	assert.True(t, r.Overlaps(_r(_a("10.0.0.0"), _a("10.0.0.10"))))
+	assert.True(t, r.Overlaps(_r(_a("10.0.0.20"), _a("10.0.0.30"))))
+	assert.True(t, r.Overlaps(_r(_a("10.0.0.15"), _a("10.0.0.15"))))
+	assert.True(t, r.Overlaps(_r(_a("0.0.0.0"), _a("255.255.255.255"))))
+	assert.False(t, r.Overlaps(_r(_a("10.0.0.0"), _a("10.0.0.9"))))
+	assert.False(t, r.Overlaps(_r(_a("10.0.0.21"), _a("10.0.0.30"))))
+}
+
+func TestRangePrefixes(t *testing.T) {
+	tests := []struct {
+		r        Range
+		prefixes []Prefix
+	}{
+		{_r(_a("10.0.0.0"), _a("10.0.0.0")), []Prefix{_p("10.0.0.0/32")}},
+		{_r(_a("10.0.0.0"), _a("10.0.0.255")), []Prefix{_p("10.0.0.0/24")}},
+		{_r(_a("0.0.0.0"), _a("255.255.255.255")), []Prefix{_p("0.0.0.0/0")}},
+		{_r(_a("255.255.255.255"), _a("255.255.255.255")), []Prefix{_p("255.255.255.255/32")}},
+		{_r(_a("10.0.0.1"), _a("10.0.0.254")), []Prefix{
+			_p("10.0.0.1/32"),
+			_p("10.0.0.2/31"),
+			_p("10.0.0.4/30"),
+			_p("10.0.0.8/29"),
+			_p("10.0.0.16/28"),
+			_p("10.0.0.32/27"),
+			_p("10.0.0.64/26"),
+			_p("10.0.0.128/26"),
+			_p("10.0.0.192/27"),
+			_p("10.0.0.224/28"),
+			_p("10.0.0.240/29"),
+			_p("10.0.0.248/30"),
+			_p("10.0.0.252/31"),
+			_p("10.0.0.254/32"),
+		}},
+		{_r(_a("128.0.0.0"), _a("255.255.255.254")), []Prefix{
+			_p("128.0.0.0/2"),
+			_p("192.0.0.0/3"),
+			_p("224.0.0.0/4"),
+			_p("240.0.0.0/5"),
+			_p("248.0.0.0/6"),
+			_p("252.0.0.0/7"),
+			_p("254.0.0.0/8"),
+			_p("255.0.0.0/9"),
+			_p("255.128.0.0/10"),
+			_p("255.192.0.0/11"),
+			_p("255.224.0.0/12"),
+			_p("255.240.0.0/13"),
+			_p("255.248.0.0/14"),
+			_p("255.252.0.0/15"),
+			_p("255.254.0.0/16"),
+			_p("255.255.0.0/17"),
+			_p("255.255.128.0/18"),
+			_p("255.255.192.0/19"),
+			_p("255.255.224.0/20"),
+			_p("255.255.240.0/21"),
+			_p("255.255.248.0/22"),
+			_p("255.255.252.0/23"),
+			_p("255.255.254.0/24"),
+			_p("255.255.255.0/25"),
+			_p("255.255.255.128/26"),
+			_p("255.255.255.192/27"),
+			_p("255.255.255.224/28"),
+			_p("255.255.255.240/29"),
+			_p("255.255.255.248/30"),
+			_p("255.255.255.252/31"),
+			_p("255.255.255.254/32"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.r.String(), func(t *testing.T) {
+			assert.Equal(t, tt.prefixes, tt.r.Prefixes())
+		})
+	}
+}
+
+func TestRangeWalkPrefixesMatchesSet(t *testing.T) {
+	rand.Seed(29)
+	for i := 0; i < 1000; i++ {
+		a, b := Address{rand.Uint32()}, Address{rand.Uint32()}
+		r := _r(minAddress(a, b), maxAddress(a, b))
+
+		var fromSet []Prefix
+		r.Set().WalkPrefixes(func(p Prefix) bool {
+			fromSet = append(fromSet, p)
+			return true
+		})
+		assert.Equal(t, fromSet, r.Prefixes())
+	}
+}
+
+func TestRangeWalkPrefixesStop(t *testing.T) {
+	r := _r(_a("10.0.0.1"), _a("10.0.0.254"))
+	count := 0
+	assert.False(t, r.WalkPrefixes(func(Prefix) bool {
+		count++
+		return count < 3
+	}))
+	assert.Equal(t, 3, count)
+}
+
+func TestRangeWalkAddresses(t *testing.T) {
+	var addrs []Address
+	assert.True(t, _r(_a("10.0.0.254"), _a("10.0.1.1")).WalkAddresses(func(addr Address) bool {
+		addrs = append(addrs, addr)
+		return true
+	}))
+	assert.Equal(t, []Address{_a("10.0.0.254"), _a("10.0.0.255"), _a("10.0.1.0"), _a("10.0.1.1")}, addrs)
+
+	addrs = nil
+	assert.True(t, _r(_a("255.255.255.254"), _a("255.255.255.255")).WalkAddresses(func(addr Address) bool {
+		addrs = append(addrs, addr)
+		return true
+	}))
+	assert.Equal(t, []Address{_a("255.255.255.254"), _a("255.255.255.255")}, addrs)
+
+	count := 0
+	assert.False(t, _r(_a("0.0.0.0"), _a("255.255.255.255")).WalkAddresses(func(Address) bool {
+		count++
+		return count < 5
+	}))
+	assert.Equal(t, 5, count)
+}
@@ -0,0 +1,75 @@
+package ipv4
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// addressAt returns the address at the given index counting from the lowest
+// address in the set. Each node stores the number of addresses under it so
+// it descends straight to the answer. The index must be less than the number
+// of addresses in the set.
+func (me *setNode) addressAt(index uint64) Address {
+	n := me
+	for !n.isActive {
+		left := n.Left()
+		if index < left.numAddrs {
+			n = left
+		} else {
+			index -= left.numAddrs
+			n = n.Right()
+		}
+	}
+	return Address{n.Prefix.Network().addr.ui + uint32(index)}
+}
+
+// indexOf returns the number of addresses in the set that are lower than the
+// given address. If the address isn't in the set, found is false.
+func (me *setNode) indexOf(addr Address) (index uint64, found bool) {
+	n := me
+	for n != nil {
+		matches, _, _, child := contains(n.Prefix, addr.Prefix())
+		if !matches {
+			return 0, false
+		}
+		if n.isActive {
+			return index + uint64(addr.ui-n.Prefix.Network().addr.ui), true
+		}
+		if child == 1 {
+			index += n.Left().numAddrs
+		}
+		n = (*setNode)(n.children[child])
+	}
+	return 0, false
+}
+
+// AddressAt returns the address at the given index in the set where the
+// lowest address is at index 0. It is the inverse of IndexOf. It takes time
+// proportional to the height of the trie. It is an error if the index is
+// negative or not less than NumAddresses().
+func (me Set) AddressAt(index int64) (Address, error) {
+	if index < 0 || me.NumAddresses() <= index {
+		return Address{}, fmt.Errorf("index %d is out of range for a set of %d addresses", index, me.NumAddresses())
+	}
+	return me.trie.addressAt(uint64(index)), nil
+}
+
+// IndexOf returns the index of the given address in the set where the lowest
+// address is at index 0. It is the inverse of AddressAt. It takes time
+// proportional to the height of the trie. If the address is not in the set,
+// found is false and the index must be ignored.
+func (me Set) IndexOf(addr Address) (index int64, found bool) {
+	i, found := me.trie.indexOf(addr)
+	return int64(i), found
+}
+
+// RandomAddress returns an address chosen uniformly at random from the set
+// using the given source. It takes time proportional to the height of the
+// trie. If the set is empty, ok is false and the address must be ignored.
+func (me Set) RandomAddress(source rand.Source) (addr Address, ok bool) {
+	if me.IsEmpty() {
+		return Address{}, false
+	}
+	index := rand.New(source).Int63n(me.NumAddresses())
+	return me.trie.addressAt(uint64(index)), true
+}
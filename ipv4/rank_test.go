@@ -0,0 +1,111 @@
+package ipv4
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAddressAt(t *testing.T) {
+	set := setFromPrefixes("10.0.0.0/31", "10.0.0.4/30", "192.168.0.0/16")
+
+	tests := []struct {
+		index    int64
+		expected Address
+	}{
+		{0, _a("10.0.0.0")},
+		{1, _a("10.0.0.1")},
+		{2, _a("10.0.0.4")},
+		{5, _a("10.0.0.7")},
+		{6, _a("192.168.0.0")},
+		{65541, _a("192.168.255.255")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected.String(), func(t *testing.T) {
+			addr, err := set.AddressAt(tt.index)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, addr)
+
+			index, found := set.IndexOf(tt.expected)
+			assert.True(t, found)
+			assert.Equal(t, tt.index, index)
+		})
+	}
+
+	_, err := set.AddressAt(65542)
+	assert.NotNil(t, err)
+	_, err = set.AddressAt(-1)
+	assert.NotNil(t, err)
+	_, err = Set{}.AddressAt(0)
+	assert.NotNil(t, err)
+
+	_, found := set.IndexOf(_a("10.0.0.2"))
+	assert.False(t, found)
+	_, found = set.IndexOf(_a("172.16.0.1"))
+	assert.False(t, found)
+	_, found = Set{}.IndexOf(_a("10.0.0.0"))
+	assert.False(t, found)
+}
+
+func TestSetAddressAtEverything(t *testing.T) {
+	set := setFromPrefixes("0.0.0.0/0")
+
+	addr, err := set.AddressAt(1<<32 - 1)
+	assert.Nil(t, err)
+	assert.Equal(t, _a("255.255.255.255"), addr)
+
+	index, found := set.IndexOf(_a("255.255.255.255"))
+	assert.True(t, found)
+	assert.Equal(t, int64(1<<32-1), index)
+}
+
+func TestSetAddressAtRandom(t *testing.T) {
+	rand.Seed(29)
+	for i := 0; i < 100; i++ {
+		s := NewSet_()
+		for j := 0; j < 1+rand.Intn(8); j++ {
+			s.Insert(Prefix{Address{0x0a000000 | rand.Uint32()&0xffff}, uint32(24 + rand.Intn(9))})
+		}
+		set := s.Set()
+
+		// Compare to counting addresses in order
+		var index int64
+		set.WalkAddresses(func(expected Address) bool {
+			addr, err := set.AddressAt(index)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, addr)
+
+			i, found := set.IndexOf(expected)
+			assert.True(t, found)
+			assert.Equal(t, index, i)
+
+			index++
+			return true
+		})
+		assert.Equal(t, set.NumAddresses(), index)
+	}
+}
+
+func TestSetRandomAddress(t *testing.T) {
+	source := rand.NewSource(29)
+
+	_, ok := Set{}.RandomAddress(source)
+	assert.False(t, ok)
+
+	set := setFromPrefixes("10.0.0.0/31", "10.0.0.4/30")
+	counts := map[Address]int{}
+	for i := 0; i < 6000; i++ {
+		addr, ok := set.RandomAddress(source)
+		assert.True(t, ok)
+		assert.True(t, set.Contains(addr))
+		counts[addr]++
+	}
+
+	// Each of the six addresses should be drawn about 1000 times
+	assert.Len(t, counts, 6)
+	for addr, count := range counts {
+		assert.InDelta(t, 1000, count, 150, addr.String())
+	}
+}
@@ -0,0 +1,214 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv4
+
+// SpecialPurpose is an entry in the IANA IPv4 Special-Purpose Address
+// Registry (RFC 6890). The boolean attributes have the meanings given in RFC
+// 6890 section 2.2.2. Where the registry says an attribute is "N/A", it is
+// false here.
+type SpecialPurpose struct {
+	// Prefix is the address block
+	Prefix Prefix
+	// Name is the name of the block as it appears in the registry
+	Name string
+	// RFC is the document that defines the block (e.g. "RFC 1918")
+	RFC string
+	// Source is true if an address from the block is valid as a source address
+	Source bool
+	// Destination is true if an address from the block is valid as a
+	// destination address
+	Destination bool
+	// Forwardable is true if a router may forward a packet with an address
+	// from the block as its destination beyond a single link
+	Forwardable bool
+	// GloballyReachable is true if a packet with an address from the block
+	// as its destination can be forwarded beyond a single administrative
+	// domain
+	GloballyReachable bool
+	// ReservedByProtocol is true if the block is reserved by a protocol
+	// specification rather than being allocated for a particular use
+	ReservedByProtocol bool
+}
+
+// specialPurposeEntries is the content of the registry. The columns follow
+// the order of the registry: source, destination, forwardable, globally
+// reachable, and reserved-by-protocol.
+var specialPurposeEntries = []SpecialPurpose{
+	{mustParsePrefix("0.0.0.0/8"), "\"This network\"", "RFC 791", true, false, false, false, true},
+	{mustParsePrefix("0.0.0.0/32"), "\"This host on this network\"", "RFC 1122", true, false, false, false, true},
+	{mustParsePrefix("10.0.0.0/8"), "Private-Use", "RFC 1918", true, true, true, false, false},
+	{mustParsePrefix("100.64.0.0/10"), "Shared Address Space", "RFC 6598", true, true, true, false, false},
+	{mustParsePrefix("127.0.0.0/8"), "Loopback", "RFC 1122", false, false, false, false, true},
+	{mustParsePrefix("169.254.0.0/16"), "Link Local", "RFC 3927", true, true, false, false, true},
+	{mustParsePrefix("172.16.0.0/12"), "Private-Use", "RFC 1918", true, true, true, false, false},
+	{mustParsePrefix("192.0.0.0/24"), "IETF Protocol Assignments", "RFC 6890", false, false, false, false, false},
+	{mustParsePrefix("192.0.0.0/29"), "IPv4 Service Continuity Prefix", "RFC 7335", true, true, true, false, false},
+	{mustParsePrefix("192.0.0.8/32"), "IPv4 dummy address", "RFC 7600", true, false, false, false, false},
+	{mustParsePrefix("192.0.0.9/32"), "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false},
+	{mustParsePrefix("192.0.0.10/32"), "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false},
+	{mustParsePrefix("192.0.0.170/32"), "NAT64/DNS64 Discovery", "RFC 8880", false, false, false, false, true},
+	{mustParsePrefix("192.0.0.171/32"), "NAT64/DNS64 Discovery", "RFC 8880", false, false, false, false, true},
+	{mustParsePrefix("192.0.2.0/24"), "Documentation (TEST-NET-1)", "RFC 5737", false, false, false, false, false},
+	{mustParsePrefix("192.31.196.0/24"), "AS112-v4", "RFC 7535", true, true, true, true, false},
+	{mustParsePrefix("192.52.193.0/24"), "AMT", "RFC 7450", true, true, true, true, false},
+	{mustParsePrefix("192.168.0.0/16"), "Private-Use", "RFC 1918", true, true, true, false, false},
+	{mustParsePrefix("192.175.48.0/24"), "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false},
+	{mustParsePrefix("198.18.0.0/15"), "Benchmarking", "RFC 2544", true, true, true, false, false},
+	{mustParsePrefix("198.51.100.0/24"), "Documentation (TEST-NET-2)", "RFC 5737", false, false, false, false, false},
+	{mustParsePrefix("203.0.113.0/24"), "Documentation (TEST-NET-3)", "RFC 5737", false, false, false, false, false},
+	{mustParsePrefix("240.0.0.0/4"), "Reserved", "RFC 1112", false, false, false, false, true},
+	{mustParsePrefix("255.255.255.255/32"), "Limited Broadcast", "RFC 919", false, true, false, false, true},
+}
+
+var (
+	specialPurposeRegistry = NewTable_[SpecialPurpose]().Table().Build(func(t Table_[SpecialPurpose]) bool {
+		for _, entry := range specialPurposeEntries {
+			t.Insert(entry.Prefix, entry)
+		}
+		return true
+	})
+
+	loopbackSet           = setFromStrings("127.0.0.0/8")
+	privateSet            = setFromStrings("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16")
+	sharedSet             = setFromStrings("100.64.0.0/10")
+	multicastSet          = setFromStrings("224.0.0.0/4")
+	linkLocalUnicastSet   = setFromStrings("169.254.0.0/16")
+	linkLocalMulticastSet = setFromStrings("224.0.0.0/24")
+	documentationSet      = setFromStrings("192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24")
+	benchmarkingSet       = setFromStrings("198.18.0.0/15")
+	reservedSet           = setFromStrings("240.0.0.0/4")
+)
+
+// SpecialPurposeRegistry returns a table with an entry for each block in the
+// IANA IPv4 Special-Purpose Address Registry. Some blocks are nested inside
+// others (e.g. 192.0.0.9/32 is in 192.0.0.0/24) so use LongestMatch to find
+// the most specific entry for an address or prefix.
+func SpecialPurposeRegistry() Table[SpecialPurpose] {
+	return specialPurposeRegistry
+}
+
+// SpecialPurpose returns the most specific entry in the special-purpose
+// registry containing this address. If there is none, found is false. It is
+// equivalent to calling LongestMatch on the registry but doesn't allocate.
+func (me Address) SpecialPurpose() (entry SpecialPurpose, found bool) {
+	node := specialPurposeRegistry.t.trie.Match(me.Prefix())
+	if node == nil {
+		return SpecialPurpose{}, false
+	}
+	entry, found = node.Data.(SpecialPurpose)
+	return
+}
+
+// IsUnspecified returns true if this is 0.0.0.0
+func (me Address) IsUnspecified() bool {
+	return me == Address{}
+}
+
+// IsLoopback returns true if this is a loopback address (127.0.0.0/8)
+func (me Address) IsLoopback() bool {
+	return loopbackSet.containsAddress(me)
+}
+
+// IsPrivate returns true if this is a private-use address defined in RFC 1918
+// (10.0.0.0/8, 172.16.0.0/12 and 192.168.0.0/16). It does not include the
+// shared address space used for carrier-grade NAT (see IsShared).
+func (me Address) IsPrivate() bool {
+	return privateSet.containsAddress(me)
+}
+
+// IsShared returns true if this is in the shared address space reserved for
+// carrier-grade NAT (100.64.0.0/10) defined in RFC 6598
+func (me Address) IsShared() bool {
+	return sharedSet.containsAddress(me)
+}
+
+// IsMulticast returns true if this is a multicast address (224.0.0.0/4)
+func (me Address) IsMulticast() bool {
+	return multicastSet.containsAddress(me)
+}
+
+// IsLinkLocalUnicast returns true if this is a link-local unicast address
+// (169.254.0.0/16)
+func (me Address) IsLinkLocalUnicast() bool {
+	return linkLocalUnicastSet.containsAddress(me)
+}
+
+// IsLinkLocalMulticast returns true if this is a link-local multicast address
+// (224.0.0.0/24)
+func (me Address) IsLinkLocalMulticast() bool {
+	return linkLocalMulticastSet.containsAddress(me)
+}
+
+// IsLinkLocal returns true if this is either a link-local unicast or a
+// link-local multicast address
+func (me Address) IsLinkLocal() bool {
+	return me.IsLinkLocalUnicast() || me.IsLinkLocalMulticast()
+}
+
+// IsDocumentation returns true if this is in one of the blocks reserved for
+// documentation by RFC 5737 (TEST-NET-1, TEST-NET-2 and TEST-NET-3)
+func (me Address) IsDocumentation() bool {
+	return documentationSet.containsAddress(me)
+}
+
+// IsBenchmarking returns true if this is in the block reserved for benchmarking
+// by RFC 2544 (198.18.0.0/15)
+func (me Address) IsBenchmarking() bool {
+	return benchmarkingSet.containsAddress(me)
+}
+
+// IsReserved returns true if this is in the block reserved for future use
+// (240.0.0.0/4). This includes the limited broadcast address.
+func (me Address) IsReserved() bool {
+	return reservedSet.containsAddress(me)
+}
+
+// IsBroadcast returns true if this is the limited broadcast address
+// (255.255.255.255)
+func (me Address) IsBroadcast() bool {
+	return me.ui == 0xffffffff
+}
+
+// IsGlobalUnicast returns true if this is a global unicast address. This
+// follows the definition used by net.IP: it is any address that is not
+// unspecified, loopback, multicast, link-local unicast or limited broadcast.
+// Notably, private addresses are global unicast addresses. See
+// IsGloballyReachable to exclude those too.
+func (me Address) IsGlobalUnicast() bool {
+	return !me.IsUnspecified() &&
+		!me.IsLoopback() &&
+		!me.IsMulticast() &&
+		!me.IsLinkLocalUnicast() &&
+		!me.IsBroadcast()
+}
+
+// IsGloballyReachable returns true if this address is globally reachable
+// according to the special-purpose registry. Addresses that aren't in any
+// special-purpose block are globally reachable.
+func (me Address) IsGloballyReachable() bool {
+	if entry, found := me.SpecialPurpose(); found {
+		return entry.GloballyReachable
+	}
+	return true
+}
+
+// mustParsePrefix parses a prefix that is known to be valid. It is used to
+// initialize package variables.
+func mustParsePrefix(prefix string) Prefix {
+	p, err := PrefixFromString(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// setFromStrings returns a set of prefixes that are known to be valid. It is
+// used to initialize package variables.
+func setFromStrings(prefixes ...string) Set {
+	s := NewSet_()
+	for _, prefix := range prefixes {
+		s.Insert(mustParsePrefix(prefix))
+	}
+	return s.Set()
+}
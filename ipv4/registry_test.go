@@ -0,0 +1,121 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv4
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecialPurposeRegistry(t *testing.T) {
+	registry := SpecialPurposeRegistry()
+	assert.Equal(t, int64(len(specialPurposeEntries)), registry.NumEntries())
+
+	registry.Walk(func(p Prefix, entry SpecialPurpose) bool {
+		assert.Equal(t, p, entry.Prefix)
+		assert.Equal(t, p.Network(), p)
+		return true
+	})
+
+	entry, found, prefix := registry.LongestMatch(_a("192.0.0.9"))
+	assert.True(t, found)
+	assert.Equal(t, _p("192.0.0.9/32"), prefix)
+	assert.Equal(t, "Port Control Protocol Anycast", entry.Name)
+	assert.True(t, entry.GloballyReachable)
+
+	entry, found, _ = registry.LongestMatch(_p("192.0.0.128/25"))
+	assert.True(t, found)
+	assert.Equal(t, "IETF Protocol Assignments", entry.Name)
+	assert.False(t, entry.GloballyReachable)
+
+	_, found, _ = registry.LongestMatch(_a("8.8.8.8"))
+	assert.False(t, found)
+}
+
+func TestAddressSpecialPurpose(t *testing.T) {
+	tests := []struct {
+		address           Address
+		name              string
+		forwardable       bool
+		globallyReachable bool
+	}{
+		{_a("0.0.0.0"), "\"This host on this network\"", false, false},
+		{_a("0.1.2.3"), "\"This network\"", false, false},
+		{_a("10.224.24.1"), "Private-Use", true, false},
+		{_a("100.64.0.1"), "Shared Address Space", true, false},
+		{_a("127.0.0.1"), "Loopback", false, false},
+		{_a("169.254.169.254"), "Link Local", false, false},
+		{_a("192.0.0.10"), "Traversal Using Relays around NAT Anycast", true, true},
+		{_a("198.19.255.255"), "Benchmarking", true, false},
+		{_a("203.0.113.17"), "Documentation (TEST-NET-3)", false, false},
+		{_a("255.255.255.255"), "Limited Broadcast", false, false},
+		{_a("254.0.0.1"), "Reserved", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			entry, found := tt.address.SpecialPurpose()
+			assert.True(t, found)
+			assert.Equal(t, tt.name, entry.Name)
+			assert.Equal(t, tt.forwardable, entry.Forwardable)
+			assert.Equal(t, tt.globallyReachable, entry.GloballyReachable)
+			assert.Equal(t, tt.globallyReachable, tt.address.IsGloballyReachable())
+		})
+	}
+
+	_, found := _a("8.8.8.8").SpecialPurpose()
+	assert.False(t, found)
+	assert.True(t, _a("8.8.8.8").IsGloballyReachable())
+}
+
+func TestAddressPredicates(t *testing.T) {
+	addresses := []string{
+		"0.0.0.0", "0.0.0.1", "1.1.1.1", "8.8.8.8", "9.255.255.255", "10.0.0.0",
+		"10.255.255.255", "11.0.0.0", "100.64.0.1", "126.255.255.255",
+		"127.0.0.0", "127.255.255.255", "128.0.0.0", "169.253.255.255",
+		"169.254.0.0", "169.254.255.255", "169.255.0.0", "172.15.255.255",
+		"172.16.0.0", "172.31.255.255", "172.32.0.0", "192.0.2.1",
+		"192.167.255.255", "192.168.0.0", "192.168.255.255", "192.169.0.0",
+		"198.51.100.1", "203.0.113.255", "223.255.255.255", "224.0.0.0",
+		"224.0.0.255", "224.0.1.0", "239.255.255.255", "240.0.0.0",
+		"255.255.255.254", "255.255.255.255",
+	}
+
+	for _, str := range addresses {
+		t.Run(str, func(t *testing.T) {
+			addr := _a(str)
+			ip := net.ParseIP(str)
+			assert.Equal(t, ip.IsUnspecified(), addr.IsUnspecified())
+			assert.Equal(t, ip.IsLoopback(), addr.IsLoopback())
+			assert.Equal(t, ip.IsPrivate(), addr.IsPrivate())
+			assert.Equal(t, ip.IsMulticast(), addr.IsMulticast())
+			assert.Equal(t, ip.IsLinkLocalUnicast(), addr.IsLinkLocalUnicast())
+			assert.Equal(t, ip.IsLinkLocalMulticast(), addr.IsLinkLocalMulticast())
+			assert.Equal(t, ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast(), addr.IsLinkLocal())
+			assert.Equal(t, ip.IsGlobalUnicast(), addr.IsGlobalUnicast())
+		})
+	}
+
+	assert.True(t, _a("100.127.255.255").IsShared())
+	assert.False(t, _a("100.128.0.0").IsShared())
+	assert.True(t, _a("192.0.2.255").IsDocumentation())
+	assert.False(t, _a("192.0.3.0").IsDocumentation())
+	assert.True(t, _a("198.18.0.0").IsBenchmarking())
+	assert.False(t, _a("198.20.0.0").IsBenchmarking())
+	assert.True(t, _a("255.255.255.255").IsReserved())
+	assert.True(t, _a("255.255.255.255").IsBroadcast())
+	assert.False(t, _a("255.255.255.254").IsBroadcast())
+}
+
+func TestAddressPredicatesAllocs(t *testing.T) {
+	addr := _a("192.168.1.1")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = addr.IsPrivate()
+		_ = addr.IsGlobalUnicast()
+		_ = addr.IsGloballyReachable()
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,101 @@
+package ipv4
+
+// SetRelation describes how two sets of addresses relate to each other. See
+// Relation.
+type SetRelation int
+
+const (
+	// RelationDisjoint means that the sets have no addresses in common
+	RelationDisjoint SetRelation = iota
+	// RelationOverlapping means that the sets have some addresses in common
+	// but each also has addresses that the other doesn't
+	RelationOverlapping
+	// RelationSubset means that every address in the first set is in the
+	// second but not the other way around
+	RelationSubset
+	// RelationSuperset means that every address in the second set is in the
+	// first but not the other way around
+	RelationSuperset
+	// RelationEqual means that the sets have exactly the same addresses
+	RelationEqual
+)
+
+// String returns the name of the relation (e.g. "subset")
+func (me SetRelation) String() string {
+	switch me {
+	case RelationDisjoint:
+		return "disjoint"
+	case RelationOverlapping:
+		return "overlapping"
+	case RelationSubset:
+		return "subset"
+	case RelationSuperset:
+		return "superset"
+	case RelationEqual:
+		return "equal"
+	}
+	return "unknown"
+}
+
+// Relation classifies how the set a relates to the set b. An empty set is a
+// subset of any non-empty set and equal to another empty set. Like Overlaps,
+// it doesn't allocate unless it is passed a Range.
+func Relation(a, b SetI) SetRelation {
+	var aNode, bNode setNode
+	aTrie, bTrie := trieOf(a, &aNode), trieOf(b, &bNode)
+
+	subset, superset := aTrie.IsSubsetOf(bTrie), bTrie.IsSubsetOf(aTrie)
+	switch {
+	case subset && superset:
+		return RelationEqual
+	case subset:
+		return RelationSubset
+	case superset:
+		return RelationSuperset
+	case aTrie.Overlaps(bTrie):
+		return RelationOverlapping
+	}
+	return RelationDisjoint
+}
+
+// Overlaps returns true if this set and the other have at least one address
+// in common. It walks both tries at once and stops as soon as it finds one
+// so it is much cheaper than checking whether the Intersection is empty. It
+// doesn't allocate unless it is passed a Range. Note that converting a Prefix
+// or Address to a SetI may allocate so do that outside of hot loops.
+func (me Set) Overlaps(other SetI) bool {
+	var node setNode
+	return me.trie.Overlaps(trieOf(other, &node))
+}
+
+// IsDisjoint returns true if this set and the other have no addresses in
+// common. It is the opposite of Overlaps.
+func (me Set) IsDisjoint(other SetI) bool {
+	return !me.Overlaps(other)
+}
+
+// IsSubsetOf returns true if every address in this set is also in the other.
+// It is the same as other.Contains(me). It doesn't allocate unless it is
+// passed a Range.
+func (me Set) IsSubsetOf(other SetI) bool {
+	var node setNode
+	return me.trie.IsSubsetOf(trieOf(other, &node))
+}
+
+// trieOf returns the trie behind the given SetI. A Prefix or Address is
+// written into the given node instead of allocating one.
+func trieOf(set SetI, node *setNode) *setNode {
+	switch set := set.(type) {
+	case nil:
+		return nil
+	case Set:
+		return set.trie
+	case Prefix:
+		*node = setNode{Prefix: set, isActive: true, numAddrs: uint64(set.NumAddresses()), size: 1, h: 1}
+		return node
+	case Address:
+		*node = setNode{Prefix: set.Prefix(), isActive: true, numAddrs: uint64(set.Prefix().NumAddresses()), size: 1, h: 1}
+		return node
+	}
+	return set.Set().trie
+}
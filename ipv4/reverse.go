@@ -0,0 +1,191 @@
+package ipv4
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// reverseDomain is the domain under which reverse DNS names for IPv4 live
+const reverseDomain = "in-addr.arpa."
+
+// ReverseZone is a DNS zone in in-addr.arpa which holds the PTR records for a
+// block of addresses
+type ReverseZone struct {
+	// Prefix is the block of addresses whose PTR records are in the zone
+	Prefix Prefix
+	// Name is the fully qualified name of the zone (e.g.
+	// "113.0.203.in-addr.arpa.")
+	Name string
+}
+
+// IsClassless returns true if this is an RFC 2317 zone for a prefix longer
+// than /24 and shorter than /32. The PTR records in such a zone are reached
+// through CNAME records in the parent /24 zone.
+func (me ReverseZone) IsClassless() bool {
+	return 24 < me.Prefix.length && me.Prefix.length < 32
+}
+
+// RecordName returns the owner name of the PTR record for the given address
+// in this zone. For a classless zone, this is the target of the CNAME in the
+// parent /24 zone (e.g. "17.0/26.113.0.203.in-addr.arpa."). Otherwise, it is
+// the same as addr.ReverseName().
+func (me ReverseZone) RecordName(addr Address) string {
+	if me.IsClassless() {
+		_, _, _, d := addr.toBytes()
+		return fmt.Sprintf("%d.%s", d, me.Name)
+	}
+	return addr.ReverseName()
+}
+
+// ReverseName returns the fully qualified name of the PTR record for this
+// address (e.g. "17.113.0.203.in-addr.arpa.")
+func (me Address) ReverseName() string {
+	return Prefix{me, 32}.reverseZoneName()
+}
+
+// reverseZoneName returns the name of the zone for this prefix. The length
+// must be a multiple of 8.
+func (me Prefix) reverseZoneName() string {
+	var b strings.Builder
+	for i := int(me.length)/8 - 1; i >= 0; i-- {
+		b.WriteString(strconv.Itoa(int(me.addr.ui >> (24 - 8*i) & 0xff)))
+		b.WriteByte('.')
+	}
+	b.WriteString(reverseDomain)
+	return b.String()
+}
+
+// ReverseZones returns the reverse zones needed to delegate the addresses in
+// this prefix. A prefix whose length is not a multiple of 8 is split into the
+// zones at the next octet boundary (e.g. a /22 needs four /24 zones). A prefix
+// longer than /24 and shorter than /32 gets a single RFC 2317 classless zone
+// named after its first address and length (e.g.
+// "0/26.113.0.203.in-addr.arpa."). Host bits are ignored.
+func (me Prefix) ReverseZones() []ReverseZone {
+	network := me.Network()
+	if me.IsClasslessReverse() {
+		parent := Prefix{network.addr, 24}
+		_, _, _, d := network.addr.toBytes()
+		return []ReverseZone{{
+			network,
+			fmt.Sprintf("%d/%d.%s", d, me.length, parent.reverseZoneName()),
+		}}
+	}
+
+	length := (me.length + 7) / 8 * 8
+	zones := make([]ReverseZone, 0, 1<<(length-me.length))
+	for i := uint32(0); i < 1<<(length-me.length); i++ {
+		zone := Prefix{Address{network.addr.ui | i<<(32-length)}, length}
+		zones = append(zones, ReverseZone{zone, zone.reverseZoneName()})
+	}
+	return zones
+}
+
+// IsClasslessReverse returns true if this prefix needs an RFC 2317 classless
+// reverse zone because it is longer than /24 and shorter than /32
+func (me Prefix) IsClasslessReverse() bool {
+	return ReverseZone{Prefix: me}.IsClassless()
+}
+
+// AddressFromReverseName parses the name of a PTR record in in-addr.arpa (e.g.
+// "17.113.0.203.in-addr.arpa."). The trailing dot is optional and case is
+// ignored. A name in an RFC 2317 classless zone (e.g.
+// "17.0/26.113.0.203.in-addr.arpa.") is accepted too.
+func AddressFromReverseName(name string) (Address, error) {
+	prefix, err := PrefixFromReverseName(name)
+	if err != nil {
+		return Address{}, err
+	}
+	if prefix.length != 32 {
+		return Address{}, fmt.Errorf("reverse name %q does not contain a complete address", name)
+	}
+	return prefix.addr, nil
+}
+
+// PrefixFromReverseName parses the name of a reverse zone in in-addr.arpa
+// (e.g. "113.0.203.in-addr.arpa." is 203.0.113.0/24) including RFC 2317
+// classless zones written as first address and length or as first and last
+// address (e.g. "0/26.113.0.203.in-addr.arpa." or
+// "0-63.113.0.203.in-addr.arpa." are 203.0.113.0/26). The name of a single
+// address gives a /32. The trailing dot is optional and case is ignored.
+func PrefixFromReverseName(name string) (Prefix, error) {
+	fail := func(reason string) (Prefix, error) {
+		return Prefix{}, fmt.Errorf("failed to parse reverse name %q: %s", name, reason)
+	}
+
+	s := strings.ToLower(strings.TrimSuffix(name, "."))
+	domain := strings.TrimSuffix(reverseDomain, ".")
+	var labels []string
+	switch {
+	case s == domain:
+	case strings.HasSuffix(s, "."+domain):
+		labels = strings.Split(strings.TrimSuffix(s, "."+domain), ".")
+	default:
+		return fail("not in " + reverseDomain)
+	}
+
+	var prefix Prefix
+	classless := false
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := labels[i]
+		if prefix.length == 32 {
+			return fail("too many labels")
+		}
+		if prefix.length == 24 && !classless && strings.ContainsAny(label, "/-") {
+			block, ok := parseClasslessLabel(label)
+			if !ok {
+				return fail(fmt.Sprintf("invalid classless label %q", label))
+			}
+			prefix = Prefix{Address{prefix.addr.ui | block.addr.ui}, block.length}
+			classless = true
+			continue
+		}
+		octet, err := strconv.ParseUint(label, 10, 8)
+		if err != nil || label != strconv.FormatUint(octet, 10) {
+			return fail(fmt.Sprintf("invalid octet %q", label))
+		}
+		if classless {
+			host := Address{prefix.addr.ui&0xffffff00 | uint32(octet)}
+			if (Prefix{host, prefix.length}).Network() != prefix {
+				return fail(fmt.Sprintf("%q is not in the classless zone", label))
+			}
+			prefix = Prefix{host, 32}
+			continue
+		}
+		prefix = Prefix{Address{prefix.addr.ui | uint32(octet)<<(24-prefix.length)}, prefix.length + 8}
+	}
+	return prefix, nil
+}
+
+// parseClasslessLabel parses an RFC 2317 label such as "0/26" or "0-63" and
+// returns the block it represents within the last octet
+func parseClasslessLabel(label string) (block Prefix, ok bool) {
+	if first, length, found := strings.Cut(label, "/"); found {
+		f, err := strconv.ParseUint(first, 10, 8)
+		if err != nil {
+			return Prefix{}, false
+		}
+		l, err := strconv.ParseUint(length, 10, 8)
+		if err != nil || l <= 24 || l > 32 {
+			return Prefix{}, false
+		}
+		block = Prefix{Address{uint32(f)}, uint32(l)}
+		return block, block.Network() == block
+	}
+	first, last, _ := strings.Cut(label, "-")
+	f, err := strconv.ParseUint(first, 10, 8)
+	if err != nil {
+		return Prefix{}, false
+	}
+	l, err := strconv.ParseUint(last, 10, 8)
+	if err != nil || l < f {
+		return Prefix{}, false
+	}
+	size := uint32(l-f) + 1
+	if size > 128 || bits.OnesCount32(size) != 1 || uint32(f)%size != 0 {
+		return Prefix{}, false
+	}
+	return Prefix{Address{uint32(f)}, uint32(32 - bits.TrailingZeros32(size))}, true
+}
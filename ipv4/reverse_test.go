@@ -0,0 +1,176 @@
+package ipv4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		address Address
+		name    string
+	}{
+		{_a("203.0.113.17"), "17.113.0.203.in-addr.arpa."},
+		{_a("0.0.0.0"), "0.0.0.0.in-addr.arpa."},
+		{_a("255.255.255.255"), "255.255.255.255.in-addr.arpa."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.address.ReverseName())
+
+			addr, err := AddressFromReverseName(tt.name)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addr)
+		})
+	}
+}
+
+func TestAddressFromReverseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		valid   bool
+	}{
+		{"17.113.0.203.in-addr.arpa", _a("203.0.113.17"), true},
+		{"17.113.0.203.IN-ADDR.ARPA.", _a("203.0.113.17"), true},
+		{"17.0/26.113.0.203.in-addr.arpa.", _a("203.0.113.17"), true},
+		{"17.0-63.113.0.203.in-addr.arpa.", _a("203.0.113.17"), true},
+		{"113.0.203.in-addr.arpa.", Address{}, false},
+		{"0/26.113.0.203.in-addr.arpa.", Address{}, false},
+		{"65.0/26.113.0.203.in-addr.arpa.", Address{}, false},
+		{"1.17.113.0.203.in-addr.arpa.", Address{}, false},
+		{"017.113.0.203.in-addr.arpa.", Address{}, false},
+		{"256.113.0.203.in-addr.arpa.", Address{}, false},
+		{"17.113.0.203.ip6.arpa.", Address{}, false},
+		{"17.113.0.203xin-addr.arpa.", Address{}, false},
+		{"17..0.203.in-addr.arpa.", Address{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := AddressFromReverseName(tt.name)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.address, addr)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestPrefixFromReverseName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix Prefix
+		valid  bool
+	}{
+		{"in-addr.arpa.", _p("0.0.0.0/0"), true},
+		{"10.in-addr.arpa.", _p("10.0.0.0/8"), true},
+		{"113.0.203.in-addr.arpa.", _p("203.0.113.0/24"), true},
+		{"0/26.113.0.203.in-addr.arpa.", _p("203.0.113.0/26"), true},
+		{"64/26.113.0.203.in-addr.arpa.", _p("203.0.113.64/26"), true},
+		{"128/25.113.0.203.in-addr.arpa.", _p("203.0.113.128/25"), true},
+		{"0-63.113.0.203.in-addr.arpa.", _p("203.0.113.0/26"), true},
+		{"16-31.113.0.203.in-addr.arpa.", _p("203.0.113.16/28"), true},
+		{"17-17.113.0.203.in-addr.arpa.", _p("203.0.113.17/32"), true},
+		{"17/32.113.0.203.in-addr.arpa.", _p("203.0.113.17/32"), true},
+		{"17.113.0.203.in-addr.arpa.", _p("203.0.113.17/32"), true},
+		{"1/26.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"0/24.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"0/33.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"0-62.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"1-64.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"0-255.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"63-0.113.0.203.in-addr.arpa.", Prefix{}, false},
+		{"0/26.0.203.in-addr.arpa.", Prefix{}, false},
+		{"arpa.", Prefix{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, err := PrefixFromReverseName(tt.name)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.prefix, prefix)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestReverseZones(t *testing.T) {
+	tests := []struct {
+		prefix Prefix
+		names  []string
+	}{
+		{_p("0.0.0.0/0"), []string{"in-addr.arpa."}},
+		{_p("10.0.0.0/8"), []string{"10.in-addr.arpa."}},
+		{_p("203.0.113.0/24"), []string{"113.0.203.in-addr.arpa."}},
+		{_p("203.0.113.17/24"), []string{"113.0.203.in-addr.arpa."}},
+		{_p("203.0.112.0/22"), []string{
+			"112.0.203.in-addr.arpa.",
+			"113.0.203.in-addr.arpa.",
+			"114.0.203.in-addr.arpa.",
+			"115.0.203.in-addr.arpa.",
+		}},
+		{_p("128.0.0.0/7"), []string{"128.in-addr.arpa.", "129.in-addr.arpa."}},
+		{_p("203.0.113.0/26"), []string{"0/26.113.0.203.in-addr.arpa."}},
+		{_p("203.0.113.128/25"), []string{"128/25.113.0.203.in-addr.arpa."}},
+		{_p("203.0.113.17/31"), []string{"16/31.113.0.203.in-addr.arpa."}},
+		{_p("203.0.113.17/32"), []string{"17.113.0.203.in-addr.arpa."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			zones := tt.prefix.ReverseZones()
+			var names []string
+			set := NewSet_()
+			for _, zone := range zones {
+				names = append(names, zone.Name)
+				set.Insert(zone.Prefix)
+
+				prefix, err := PrefixFromReverseName(zone.Name)
+				assert.Nil(t, err)
+				assert.Equal(t, zone.Prefix, prefix)
+			}
+			assert.Equal(t, tt.names, names)
+			assert.True(t, tt.prefix.Network().Set().Equal(set.Set()))
+		})
+	}
+
+	t.Run("sizes", func(t *testing.T) {
+		for length := 0; length <= 32; length++ {
+			mask, _ := MaskFromLength(length)
+			zones := PrefixFromAddressMask(_a("203.0.113.17"), mask).ReverseZones()
+			expected := 1 << ((8 - length%8) % 8)
+			if length > 24 {
+				expected = 1
+			}
+			assert.Len(t, zones, expected, "/%d", length)
+		}
+	})
+}
+
+func TestReverseZoneRecordName(t *testing.T) {
+	zone := _p("203.0.113.0/26").ReverseZones()[0]
+	assert.True(t, zone.IsClassless())
+	assert.True(t, zone.Prefix.IsClasslessReverse())
+	assert.Equal(t, "17.0/26.113.0.203.in-addr.arpa.", zone.RecordName(_a("203.0.113.17")))
+
+	addr, err := AddressFromReverseName(zone.RecordName(_a("203.0.113.17")))
+	assert.Nil(t, err)
+	assert.Equal(t, _a("203.0.113.17"), addr)
+
+	zone = _p("203.0.113.0/24").ReverseZones()[0]
+	assert.False(t, zone.IsClassless())
+	assert.False(t, zone.Prefix.IsClasslessReverse())
+	assert.Equal(t, "17.113.0.203.in-addr.arpa.", zone.RecordName(_a("203.0.113.17")))
+
+	zone = _p("203.0.113.17/32").ReverseZones()[0]
+	assert.False(t, zone.IsClassless())
+	assert.Equal(t, "17.113.0.203.in-addr.arpa.", zone.RecordName(_a("203.0.113.17")))
+}
@@ -1,6 +1,7 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"math"
 	"math/rand"
 	"strconv"
@@ -1161,3 +1162,410 @@ func countPrefixes(s Set) int {
 	})
 	return numPrefixes
 }
+
+func TestSetFromString(t *testing.T) {
+	tests := []struct {
+		description string
+		str         string
+		set         Set
+		isErr       bool
+	}{
+		{
+			description: "empty",
+			str:         "[]",
+			set:         Set{},
+		}, {
+			description: "one",
+			str:         "[10.0.0.0/8]",
+			set:         _p("10.0.0.0/8").Set(),
+		}, {
+			description: "unordered and overlapping",
+			str:         "[ 10.0.1.0/24,10.0.0.0/24 , 10.0.0.128/25 ]",
+			set:         _p("10.0.0.0/23").Set(),
+		}, {
+			description: "no brackets",
+			str:         "10.0.0.0/8",
+			isErr:       true,
+		}, {
+			description: "bad prefix",
+			str:         "[10.0.0.0/8, 10.0.0.0/33]",
+			isErr:       true,
+		}, {
+			description: "trailing comma",
+			str:         "[10.0.0.0/8,]",
+			isErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			set, err := SetFromString(tt.str)
+			if tt.isErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, tt.set.Equal(set))
+		})
+	}
+}
+
+func TestSetStringRoundTrip(t *testing.T) {
+	s := Set{}.Build(func(s_ Set_) bool {
+		s_.Insert(_p("10.0.0.0/8"))
+		s_.Insert(_p("192.168.0.0/16"))
+		s_.Remove(_p("10.224.24.0/24"))
+		return true
+	})
+
+	var decoded Set
+	text, err := s.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, s.String(), string(text))
+	assert.Nil(t, decoded.UnmarshalText(text))
+	assert.True(t, s.Equal(decoded))
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	s := Set{}.Build(func(s_ Set_) bool {
+		s_.Insert(_p("10.0.0.0/8"))
+		s_.Insert(_p("192.168.0.0/16"))
+		return true
+	})
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, `["10.0.0.0/8","192.168.0.0/16"]`, string(data))
+
+	data, err = json.Marshal(Set{})
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	var decoded Set
+	assert.Nil(t, json.Unmarshal([]byte(`["192.168.0.0/17","10.0.0.0/8","192.168.128.0/17"]`), &decoded))
+	assert.True(t, s.Equal(decoded))
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &decoded))
+	assert.True(t, s.Equal(decoded))
+
+	assert.Nil(t, json.Unmarshal([]byte(`[]`), &decoded))
+	assert.True(t, decoded.IsEmpty())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`["10.0.0.0"]`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`"10.0.0.0/8"`), &decoded))
+}
+
+func TestSetSymmetricDifferenceNil(t *testing.T) {
+	assert.Equal(t, int64(0), Set_{}.SymmetricDifference(nil).NumAddresses())
+	assert.Equal(t, int64(0), Set{}.SymmetricDifference(nil).NumAddresses())
+}
+
+func TestSetComplementNil(t *testing.T) {
+	assert.Equal(t, int64(1)<<32, Set_{}.Complement().NumAddresses())
+	assert.Equal(t, int64(1)<<32, Set{}.Complement().NumAddresses())
+	assert.Equal(t, int64(0), Set_{}.ComplementWithin(nil).NumAddresses())
+	assert.Equal(t, int64(0), Set{}.ComplementWithin(nil).NumAddresses())
+}
+
+func TestSetSymmetricDifference(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        Set
+		expected    Set
+	}{
+		{
+			description: "disjoint",
+			a:           setFromPrefixes("10.0.0.0/24"),
+			b:           setFromPrefixes("10.0.2.0/24"),
+			expected:    setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+		}, {
+			description: "same",
+			a:           setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+			b:           setFromPrefixes("10.0.0.0/25", "10.0.0.128/25", "10.0.2.0/24"),
+			expected:    Set{},
+		}, {
+			description: "contained",
+			a:           setFromPrefixes("10.0.0.0/8"),
+			b:           setFromPrefixes("10.0.0.0/9"),
+			expected:    setFromPrefixes("10.128.0.0/9"),
+		}, {
+			description: "overlapping",
+			a:           setFromPrefixes("10.0.0.0/23"),
+			b:           setFromPrefixes("10.0.1.0/24", "10.0.2.0/24"),
+			expected:    setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+		}, {
+			description: "everything",
+			a:           setFromPrefixes("0.0.0.0/0"),
+			b:           setFromPrefixes("128.0.0.0/1"),
+			expected:    setFromPrefixes("0.0.0.0/1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := tt.a.SymmetricDifference(tt.b)
+			assert.True(t, result.isValid())
+			assert.True(t, tt.expected.Equal(result), result.String())
+			assert.True(t, tt.expected.Equal(tt.b.SymmetricDifference(tt.a)))
+		})
+	}
+}
+
+func TestSetComplement(t *testing.T) {
+	tests := []struct {
+		description string
+		set         Set
+		expected    Set
+	}{
+		{
+			description: "empty",
+			set:         Set{},
+			expected:    setFromPrefixes("0.0.0.0/0"),
+		}, {
+			description: "everything",
+			set:         setFromPrefixes("0.0.0.0/0"),
+			expected:    Set{},
+		}, {
+			description: "half",
+			set:         setFromPrefixes("0.0.0.0/1"),
+			expected:    setFromPrefixes("128.0.0.0/1"),
+		}, {
+			description: "bogons",
+			set:         setFromPrefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"),
+			expected: setFromPrefixes(
+				"0.0.0.0/5", "8.0.0.0/7", "11.0.0.0/8", "12.0.0.0/6", "16.0.0.0/4",
+				"32.0.0.0/3", "64.0.0.0/2", "128.0.0.0/3", "160.0.0.0/5",
+				"168.0.0.0/6", "172.0.0.0/12", "172.32.0.0/11", "172.64.0.0/10",
+				"172.128.0.0/9", "173.0.0.0/8", "174.0.0.0/7", "176.0.0.0/4",
+				"192.0.0.0/9", "192.128.0.0/11", "192.160.0.0/13", "192.169.0.0/16",
+				"192.170.0.0/15", "192.172.0.0/14", "192.176.0.0/12", "192.192.0.0/10",
+				"193.0.0.0/8", "194.0.0.0/7", "196.0.0.0/6", "200.0.0.0/5",
+				"208.0.0.0/4", "224.0.0.0/3",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := tt.set.Complement()
+			assert.True(t, result.isValid())
+			assert.True(t, tt.expected.Equal(result), result.String())
+			assert.True(t, tt.set.Equal(result.Complement()))
+		})
+	}
+}
+
+func TestSetComplementWithin(t *testing.T) {
+	universe := setFromPrefixes("10.0.0.0/16", "192.168.0.0/24")
+	set := setFromPrefixes("10.0.0.0/17", "192.168.0.0/25", "172.16.0.0/12")
+
+	result := set.ComplementWithin(universe)
+	assert.True(t, result.isValid())
+	assert.True(t, setFromPrefixes("10.0.128.0/17", "192.168.0.128/25").Equal(result), result.String())
+}
+
+func TestSetSymmetricDifferenceRandom(t *testing.T) {
+	rand.Seed(29)
+	randomSet := func() Set {
+		s := NewSet_()
+		for i := 0; i < 1+rand.Intn(8); i++ {
+			s.Insert(Prefix{Address{0x0a000000 | rand.Uint32()&0xffff}, uint32(18 + rand.Intn(15))})
+		}
+		return s.Set()
+	}
+	universe := setFromPrefixes("10.0.0.0/16")
+	for i := 0; i < 1000; i++ {
+		a, b := randomSet(), randomSet()
+
+		// Compare to composing the basic operations
+		expected := a.Difference(b).Union(b.Difference(a))
+		result := a.SymmetricDifference(b)
+		assert.True(t, result.isValid())
+		assert.True(t, expected.Equal(result))
+
+		complement := a.Complement()
+		assert.True(t, complement.isValid())
+		assert.True(t, complement.Intersection(a).IsEmpty())
+		assert.Equal(t, int64(1)<<32, complement.NumAddresses()+a.NumAddresses())
+		assert.True(t, universe.Difference(a).Equal(a.ComplementWithin(universe)))
+	}
+}
+
+func TestSetToggle(t *testing.T) {
+	s := NewSet_()
+	s.Insert(_p("10.0.0.0/23"))
+	s.Toggle(setFromPrefixes("10.0.1.0/24", "10.0.2.0/24"))
+	assert.True(t, setFromPrefixes("10.0.0.0/24", "10.0.2.0/24").Equal(s.Set()))
+
+	s.Toggle(s.Set())
+	assert.True(t, s.IsEmpty())
+
+	s.Toggle(nil)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSetInvert(t *testing.T) {
+	s := NewSet_()
+	s.Invert()
+	assert.Equal(t, int64(1)<<32, s.NumAddresses())
+
+	s.Remove(_p("10.0.0.0/8"))
+	s.Invert()
+	assert.True(t, setFromPrefixes("10.0.0.0/8").Equal(s.Set()))
+
+	s.InvertWithin(_p("10.0.0.0/7"))
+	assert.True(t, setFromPrefixes("11.0.0.0/8").Equal(s.Set()))
+
+	s.InvertWithin(nil)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSetDiff(t *testing.T) {
+	tests := []struct {
+		description    string
+		a, b           Set
+		added, removed []Prefix
+	}{
+		{
+			description: "empty",
+		}, {
+			description: "same",
+			a:           setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+			b:           setFromPrefixes("10.0.0.0/25", "10.0.0.128/25", "10.0.2.0/24"),
+		}, {
+			description: "all added",
+			b:           setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+			added:       []Prefix{_p("10.0.0.0/24"), _p("10.0.2.0/24")},
+		}, {
+			description: "all removed",
+			a:           setFromPrefixes("10.0.0.0/24", "10.0.2.0/24"),
+			removed:     []Prefix{_p("10.0.0.0/24"), _p("10.0.2.0/24")},
+		}, {
+			description: "disjoint",
+			a:           setFromPrefixes("10.0.2.0/24"),
+			b:           setFromPrefixes("10.0.0.0/24"),
+			added:       []Prefix{_p("10.0.0.0/24")},
+			removed:     []Prefix{_p("10.0.2.0/24")},
+		}, {
+			description: "split",
+			a:           setFromPrefixes("10.0.0.0/22"),
+			b:           setFromPrefixes("10.0.1.0/24", "10.0.2.0/23"),
+			removed:     []Prefix{_p("10.0.0.0/24")},
+		}, {
+			description: "merged",
+			a:           setFromPrefixes("10.0.1.0/24", "10.0.2.0/23"),
+			b:           setFromPrefixes("10.0.0.0/22"),
+			added:       []Prefix{_p("10.0.0.0/24")},
+		}, {
+			description: "both",
+			a:           setFromPrefixes("10.0.0.0/23", "192.168.0.0/24"),
+			b:           setFromPrefixes("10.0.1.0/24", "10.0.2.0/24", "192.168.0.0/23"),
+			added:       []Prefix{_p("10.0.2.0/24"), _p("192.168.1.0/24")},
+			removed:     []Prefix{_p("10.0.0.0/24")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var added, removed []Prefix
+			assert.True(t, tt.a.Diff(tt.b,
+				func(p Prefix) bool {
+					added = append(added, p)
+					return true
+				},
+				func(p Prefix) bool {
+					removed = append(removed, p)
+					return true
+				},
+			))
+			assert.Equal(t, tt.added, added)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestSetDiffNil(t *testing.T) {
+	a := setFromPrefixes("10.0.0.0/24")
+	b := setFromPrefixes("10.0.2.0/24")
+	assert.True(t, a.Diff(b, nil, nil))
+
+	var removed []Prefix
+	assert.True(t, a.Diff(b, nil, func(p Prefix) bool {
+		removed = append(removed, p)
+		return true
+	}))
+	assert.Equal(t, []Prefix{_p("10.0.0.0/24")}, removed)
+}
+
+func TestSetDiffStop(t *testing.T) {
+	a := setFromPrefixes("10.0.0.0/24", "10.0.2.0/24")
+	count := 0
+	assert.False(t, Set{}.Diff(a,
+		func(p Prefix) bool {
+			count++
+			return false
+		}, nil,
+	))
+	assert.Equal(t, 1, count)
+}
+
+func TestSetDiffShared(t *testing.T) {
+	s := NewSet_()
+	for i := 0; i < 1000; i++ {
+		s.Insert(Prefix{Address{0x0a000000 | uint32(i)<<8}, 24})
+	}
+	a := s.Set()
+	b := a.Build(func(s Set_) bool {
+		s.Remove(_p("10.0.7.0/24"))
+		s.Insert(_p("192.168.0.0/16"))
+		return true
+	})
+
+	var added, removed []Prefix
+	a.Diff(b,
+		func(p Prefix) bool {
+			added = append(added, p)
+			return true
+		},
+		func(p Prefix) bool {
+			removed = append(removed, p)
+			return true
+		},
+	)
+	assert.Equal(t, []Prefix{_p("192.168.0.0/16")}, added)
+	assert.Equal(t, []Prefix{_p("10.0.7.0/24")}, removed)
+}
+
+func TestSetDiffRandom(t *testing.T) {
+	rand.Seed(29)
+	randomSet := func() Set {
+		s := NewSet_()
+		for i := 0; i < 1+rand.Intn(8); i++ {
+			s.Insert(Prefix{Address{0x0a000000 | rand.Uint32()&0xffff}, uint32(18 + rand.Intn(15))})
+		}
+		return s.Set()
+	}
+	collect := func(s Set) (prefixes []Prefix) {
+		s.WalkPrefixes(func(p Prefix) bool {
+			prefixes = append(prefixes, p)
+			return true
+		})
+		return
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := randomSet(), randomSet()
+
+		var added, removed []Prefix
+		a.Diff(b,
+			func(p Prefix) bool {
+				added = append(added, p)
+				return true
+			},
+			func(p Prefix) bool {
+				removed = append(removed, p)
+				return true
+			},
+		)
+		assert.Equal(t, collect(b.Difference(a)), added)
+		assert.Equal(t, collect(a.Difference(b)), removed)
+	}
+}
@@ -0,0 +1,213 @@
+package ipv4
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// The binary snapshot format is versioned and laid out as follows. All
+// multi-byte integers are big-endian except for the entry count which is an
+// unsigned varint (as in encoding/binary).
+//
+//	magic    4 bytes   "ADRS"
+//	version  1 byte    snapshotVersion
+//	family   1 byte    4 for IPv4
+//	kind     1 byte    snapshotKindSet or snapshotKindTable
+//	count    uvarint   number of entries that follow
+//	entries  count x   length (1 byte), the first ceil(length/8) bytes of the
+//	                   network address, and then the value (tables only)
+//
+// Entries are written in the trie's walk order (lexigraphical) which allows
+// the reader to build the trie bottom-up in one pass rather than inserting
+// each entry individually. Only the network part of each prefix is stored; any
+// host bits in a table's keys are not preserved.
+const (
+	snapshotMagic   = "ADRS"
+	snapshotVersion = 1
+	snapshotFamily  = 4
+
+	snapshotKindSet   = 's'
+	snapshotKindTable = 't'
+)
+
+// snapshotReader is what is needed to read a snapshot. Values are decoded
+// directly from it so it must not read ahead of what is consumed.
+type snapshotReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// encodeTrie writes the header and then all of the active nodes in the trie
+// to w. If encodeValue is not nil, it is called to write the value after each
+// prefix.
+func encodeTrie(w io.Writer, kind byte, trie *trieNode, encodeValue func(io.Writer, interface{}) error) error {
+	bw := bufio.NewWriter(w)
+
+	var header [7 + binary.MaxVarintLen64]byte
+	copy(header[:], snapshotMagic)
+	header[4], header[5], header[6] = snapshotVersion, snapshotFamily, kind
+	n := 7 + binary.PutUvarint(header[7:], uint64(trie.NumNodes()))
+	if _, err := bw.Write(header[:n]); err != nil {
+		return err
+	}
+
+	var err error
+	trie.Walk(func(p Prefix, data interface{}) bool {
+		a, b, c, d := p.Network().addr.toBytes()
+		entry := [5]byte{byte(p.length), a, b, c, d}
+		if _, err = bw.Write(entry[:1+(p.length+7)/8]); err != nil {
+			return false
+		}
+		if encodeValue != nil {
+			if err = encodeValue(bw, data); err != nil {
+				err = fmt.Errorf("failed to encode value for %s: %w", p, err)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// decodeTrie reads a snapshot written by encodeTrie from r and builds the trie
+// from it. If decodeValue is not nil, it is called to read the value after
+// each prefix. If flatten is true, the result is flattened as a set.
+func decodeTrie(r io.Reader, kind byte, decodeValue func(io.Reader) (interface{}, error), flatten bool) (*trieNode, error) {
+	br, ok := r.(snapshotReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	var header [7]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	switch {
+	case string(header[:4]) != snapshotMagic:
+		return nil, fmt.Errorf("not an addrs snapshot")
+	case header[4] != snapshotVersion:
+		return nil, fmt.Errorf("unsupported snapshot version: %d", header[4])
+	case header[5] != snapshotFamily:
+		return nil, fmt.Errorf("snapshot is not IPv4 (family %d)", header[5])
+	case header[6] != kind:
+		return nil, fmt.Errorf("snapshot kind %q doesn't match expected kind %q", header[6], kind)
+	}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot entry count: %w", err)
+	}
+
+	// Don't trust the count too much when allocating up front
+	capacity := count
+	if capacity > 1<<16 {
+		capacity = 1 << 16
+	}
+	nodes := make([]*trieNode, 0, capacity)
+
+	for i := uint64(0); i < count; i++ {
+		var entry [5]byte
+		if _, err := io.ReadFull(br, entry[:1]); err != nil {
+			return nil, fmt.Errorf("failed to read snapshot entry %d: %w", i, err)
+		}
+		length := uint32(entry[0])
+		if length > 32 {
+			return nil, fmt.Errorf("invalid prefix length in snapshot entry %d: %d", i, length)
+		}
+		if _, err := io.ReadFull(br, entry[1:1+(length+7)/8]); err != nil {
+			return nil, fmt.Errorf("failed to read snapshot entry %d: %w", i, err)
+		}
+		prefix := Prefix{
+			AddressFromBytes(entry[1], entry[2], entry[3], entry[4]),
+			length,
+		}.Network()
+
+		if len(nodes) != 0 && !nodes[len(nodes)-1].Prefix.lessThan(prefix) {
+			return nil, fmt.Errorf("snapshot entry %d (%s) is out of order", i, prefix)
+		}
+
+		node := &trieNode{Prefix: prefix}
+		if decodeValue != nil {
+			if node.Data, err = decodeValue(br); err != nil {
+				return nil, fmt.Errorf("failed to decode value for %s: %w", prefix, err)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return buildTrie(nodes, flatten), nil
+}
+
+// buildTrie builds a trie bottom-up from nodes which must be sorted in walk
+// order with no duplicates. The nodes are modified and become active nodes in
+// the result. It takes time proportional to the number of nodes times the
+// height of the resulting trie.
+func buildTrie(nodes []*trieNode, flatten bool) *trieNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var root *trieNode
+	var bit uint32
+	var rest []*trieNode
+
+	first, last := nodes[0], nodes[len(nodes)-1]
+	result, _, common, _ := compare(first.Prefix, last.Prefix)
+	switch result {
+	case compareSame, compareContains:
+		// The first node contains all of the others
+		root = first
+		root.isActive = true
+		bit = first.Prefix.length
+		rest = nodes[1:]
+	default:
+		// Join disjoint nodes under an inactive node
+		root = &trieNode{
+			Prefix: Prefix{
+				addr: Address{
+					ui: first.Prefix.addr.ui & ^(uint32(0xffffffff) >> common), // zero out bits not in common
+				},
+				length: common,
+			},
+		}
+		bit = common
+		rest = nodes
+	}
+
+	// Since they're sorted, all of the nodes that go to the left come first
+	pivot := sort.Search(len(rest), func(i int) bool {
+		return rest[i].Prefix.addr.ui&(uint32(0x80000000)>>bit) != 0
+	})
+	left := buildTrie(rest[:pivot], flatten)
+	right := buildTrie(rest[pivot:], flatten)
+
+	return root.mutate(func(n *trieNode) {
+		n.children = [2]*trieNode{left, right}
+		if flatten {
+			n.flatten()
+		}
+	})
+}
+
+// Encode writes the set to w using a compact, versioned binary format which
+// can be read back with SetFromReader. It is much faster to write and read
+// than the JSON or text formats for large sets.
+func (me Set) Encode(w io.Writer) error {
+	return encodeTrie(w, snapshotKindSet, (*trieNode)(me.trie), nil)
+}
+
+// SetFromReader reads a set written by Set.Encode from r. If r does not
+// implement io.ByteReader, it is wrapped in a bufio.Reader and so may be read
+// beyond the end of the set.
+func SetFromReader(r io.Reader) (Set, error) {
+	trie, err := decodeTrie(r, snapshotKindSet, nil, true)
+	if err != nil {
+		return Set{}, err
+	}
+	return Set{(*setNode)(trie)}, nil
+}
@@ -0,0 +1,153 @@
+package ipv4
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEncodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		description string
+		set         Set
+	}{
+		{
+			description: "empty",
+			set:         Set{},
+		}, {
+			description: "everything",
+			set:         _p("0.0.0.0/0").Set(),
+		}, {
+			description: "one address",
+			set:         _a("203.0.113.17").Set(),
+		}, {
+			description: "holes",
+			set: Set{}.Build(func(s_ Set_) bool {
+				s_.Insert(_p("10.0.0.0/8"))
+				s_.Remove(_p("10.224.24.0/24"))
+				s_.Remove(_a("10.0.0.1"))
+				s_.Insert(_p("192.168.0.0/16"))
+				return true
+			}),
+		}, {
+			description: "random",
+			set: Set{}.Build(func(s_ Set_) bool {
+				r := rand.New(rand.NewSource(0))
+				for i := 0; i < 10000; i++ {
+					s_.Insert(Prefix{Address{r.Uint32()}, uint32(16 + r.Intn(17))}.Network())
+				}
+				return true
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.Nil(t, tt.set.Encode(&buf))
+
+			s, err := SetFromReader(&buf)
+			assert.Nil(t, err)
+			assert.True(t, s.isValid())
+			assert.True(t, tt.set.Equal(s))
+			assert.Equal(t, tt.set.NumAddresses(), s.NumAddresses())
+			assert.Equal(t, 0, buf.Len())
+		})
+	}
+}
+
+func TestSetEncodeCompact(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, _p("10.0.0.0/8").Set().Union(_p("192.168.0.0/16")).Encode(&buf))
+	assert.Equal(t, []byte{
+		'A', 'D', 'R', 'S', 1, 4, 's', 2,
+		8, 10,
+		16, 192, 168,
+	}, buf.Bytes())
+}
+
+func TestSetFromReaderFlattens(t *testing.T) {
+	// Two adjacent halves and a nested prefix are written by hand
+	data := []byte{
+		'A', 'D', 'R', 'S', 1, 4, 's', 3,
+		25, 10, 0, 0, 0,
+		25, 10, 0, 0, 128,
+		26, 10, 0, 0, 192,
+	}
+	s, err := SetFromReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, s.isValid())
+	assert.True(t, _p("10.0.0.0/24").Set().Equal(s))
+}
+
+func TestSetFromReaderErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		data        []byte
+	}{
+		{
+			description: "empty",
+			data:        []byte{},
+		}, {
+			description: "bad magic",
+			data:        []byte{'A', 'D', 'R', 'X', 1, 4, 's', 0},
+		}, {
+			description: "bad version",
+			data:        []byte{'A', 'D', 'R', 'S', 2, 4, 's', 0},
+		}, {
+			description: "ipv6",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 6, 's', 0},
+		}, {
+			description: "table",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 't', 0},
+		}, {
+			description: "no count",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's'},
+		}, {
+			description: "truncated",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's', 2, 8, 10, 16, 192},
+		}, {
+			description: "bad length",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's', 1, 33, 10, 0, 0, 0, 0},
+		}, {
+			description: "out of order",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's', 2, 16, 192, 168, 8, 10},
+		}, {
+			description: "duplicate",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's', 2, 8, 10, 8, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			_, err := SetFromReader(bytes.NewReader(tt.data))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestBuildTrie(t *testing.T) {
+	prefixes := []Prefix{
+		_p("10.0.0.0/8"),
+		_p("10.0.0.0/16"),
+		_p("10.0.0.0/24"),
+		_p("10.1.0.0/16"),
+		_p("10.224.24.0/24"),
+		_p("192.168.0.0/16"),
+		_p("192.168.1.1/32"),
+	}
+
+	var expected *trieNode
+	var nodes []*trieNode
+	for i, p := range prefixes {
+		expected, _ = expected.Insert(p, i)
+		nodes = append(nodes, &trieNode{Prefix: p, Data: i})
+	}
+
+	trie := buildTrie(nodes, false)
+	assert.True(t, trie.isValid())
+	assert.True(t, expected.Equal(trie, defaultComparator))
+	assert.Equal(t, expected.height(), trie.height())
+}
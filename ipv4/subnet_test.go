@@ -0,0 +1,182 @@
+package ipv4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixSubnets(t *testing.T) {
+	tests := []struct {
+		prefix  Prefix
+		length  uint32
+		subnets []Prefix
+	}{
+		{_p("10.0.0.0/24"), 24, []Prefix{_p("10.0.0.0/24")}},
+		{_p("10.0.0.0/24"), 26, []Prefix{
+			_p("10.0.0.0/26"),
+			_p("10.0.0.64/26"),
+			_p("10.0.0.128/26"),
+			_p("10.0.0.192/26"),
+		}},
+		{_p("10.0.0.17/24"), 25, []Prefix{_p("10.0.0.0/25"), _p("10.0.0.128/25")}},
+		{_p("255.255.255.252/30"), 32, []Prefix{
+			_p("255.255.255.252/32"),
+			_p("255.255.255.253/32"),
+			_p("255.255.255.254/32"),
+			_p("255.255.255.255/32"),
+		}},
+		{_p("0.0.0.0/0"), 0, []Prefix{_p("0.0.0.0/0")}},
+		{_p("0.0.0.0/0"), 2, []Prefix{
+			_p("0.0.0.0/2"),
+			_p("64.0.0.0/2"),
+			_p("128.0.0.0/2"),
+			_p("192.0.0.0/2"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			iterator, err := tt.prefix.Subnets(tt.length)
+			assert.Nil(t, err)
+			var subnets []Prefix
+			for subnet, ok := iterator.Next(); ok; subnet, ok = iterator.Next() {
+				subnets = append(subnets, subnet)
+			}
+			assert.Equal(t, tt.subnets, subnets)
+
+			count, _ := tt.prefix.NumPrefixes(tt.length)
+			assert.Equal(t, count, uint64(len(subnets)))
+
+			_, ok := iterator.Next()
+			assert.False(t, ok)
+		})
+	}
+
+	_, err := _p("10.0.0.0/24").Subnets(23)
+	assert.NotNil(t, err)
+	_, err = _p("10.0.0.0/24").Subnets(33)
+	assert.NotNil(t, err)
+
+	var zero SubnetIterator
+	_, ok := zero.Next()
+	assert.False(t, ok)
+}
+
+func TestPrefixSubnetsLazy(t *testing.T) {
+	iterator, err := _p("0.0.0.0/0").Subnets(32)
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		subnet, ok := iterator.Next()
+		assert.True(t, ok)
+		assert.Equal(t, Prefix{Address{uint32(i)}, 32}, subnet)
+	}
+}
+
+func TestPrefixSubnet(t *testing.T) {
+	p := _p("10.0.0.17/24")
+
+	subnet, err := p.Subnet(26, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.0.0.0/26"), subnet)
+
+	subnet, err = p.Subnet(26, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.0.0.192/26"), subnet)
+
+	subnet, err = p.Subnet(32, 255)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.0.0.255/32"), subnet)
+
+	subnet, err = _p("0.0.0.0/0").Subnet(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("0.0.0.0/0"), subnet)
+
+	_, err = p.Subnet(26, 4)
+	assert.NotNil(t, err)
+	_, err = p.Subnet(23, 0)
+	assert.NotNil(t, err)
+	_, err = p.Subnet(33, 0)
+	assert.NotNil(t, err)
+}
+
+func TestPrefixSupernet(t *testing.T) {
+	supernet, err := _p("10.1.2.3/32").Supernet(16)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.1.0.0/16"), supernet)
+
+	supernet, err = _p("10.1.2.0/24").Supernet(24)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.1.2.0/24"), supernet)
+
+	supernet, err = _p("10.1.2.0/24").Supernet(0)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("0.0.0.0/0"), supernet)
+
+	_, err = _p("10.1.2.0/24").Supernet(25)
+	assert.NotNil(t, err)
+}
+
+func TestPrefixParentSibling(t *testing.T) {
+	tests := []struct {
+		prefix, parent, sibling Prefix
+	}{
+		{_p("10.0.0.0/25"), _p("10.0.0.0/24"), _p("10.0.0.128/25")},
+		{_p("10.0.0.128/25"), _p("10.0.0.0/24"), _p("10.0.0.0/25")},
+		{_p("10.0.0.130/25"), _p("10.0.0.0/24"), _p("10.0.0.0/25")},
+		{_p("10.0.0.1/32"), _p("10.0.0.0/31"), _p("10.0.0.0/32")},
+		{_p("128.0.0.0/1"), _p("0.0.0.0/0"), _p("0.0.0.0/1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			parent, ok := tt.prefix.Parent()
+			assert.True(t, ok)
+			assert.Equal(t, tt.parent, parent)
+
+			sibling, ok := tt.prefix.Sibling()
+			assert.True(t, ok)
+			assert.Equal(t, tt.sibling, sibling)
+
+			a, b := parent.Halves()
+			assert.ElementsMatch(t, []Prefix{a, b}, []Prefix{tt.prefix.Network(), sibling})
+		})
+	}
+
+	_, ok := _p("0.0.0.0/0").Parent()
+	assert.False(t, ok)
+	_, ok = _p("0.0.0.0/0").Sibling()
+	assert.False(t, ok)
+}
+
+func TestPrefixIsAligned(t *testing.T) {
+	assert.True(t, _p("10.0.0.0/24").IsAligned())
+	assert.True(t, _p("10.0.0.17/32").IsAligned())
+	assert.True(t, _p("0.0.0.0/0").IsAligned())
+	assert.False(t, _p("10.0.0.17/24").IsAligned())
+	assert.False(t, _p("10.0.0.1/31").IsAligned())
+}
+
+func TestPrefixIndex(t *testing.T) {
+	parent := _p("10.0.0.0/24")
+	for index := uint64(0); index < 4; index++ {
+		subnet, err := parent.Subnet(26, index)
+		assert.Nil(t, err)
+		i, err := subnet.Index(parent)
+		assert.Nil(t, err)
+		assert.Equal(t, index, i)
+	}
+
+	i, err := _p("10.0.0.200/26").Index(_p("10.0.0.17/24"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), i)
+
+	i, err = _p("255.255.255.255/32").Index(_p("0.0.0.0/0"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0xffffffff), i)
+
+	_, err = _p("10.0.1.0/26").Index(parent)
+	assert.NotNil(t, err)
+	_, err = _p("10.0.0.0/23").Index(parent)
+	assert.NotNil(t, err)
+}
@@ -4,6 +4,7 @@
 package ipv4
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
@@ -961,3 +962,91 @@ func TestTableVariousComparators(t *testing.T) {
 		})
 	}
 }
+
+func TestTableMarshalJSON(t *testing.T) {
+	table := Table[int]{}.Build(func(t_ Table_[int]) bool {
+		t_.Insert(_p("10.0.0.0/8"), 1)
+		t_.Insert(_p("10.0.0.0/24"), 2)
+		t_.Insert(_a("10.0.0.1"), 3)
+		t_.Insert(_p("192.168.0.0/16"), 4)
+		return true
+	})
+
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"10.0.0.0/8":1,"10.0.0.0/24":2,"10.0.0.1/32":3,"192.168.0.0/16":4}`, string(data))
+
+	var decoded Table[int]
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, int64(4), decoded.NumEntries())
+	assert.True(t, table.Diff(decoded, nil, func(Prefix, int) bool { return false }, func(Prefix, int) bool { return false }, nil))
+
+	data, err = json.Marshal(Table[int]{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestTableUnmarshalJSON(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		var table Table[string]
+		err := json.Unmarshal([]byte(`[{"prefix":"10.0.0.0/8","value":"a"},{"prefix":"10.0.0.1/32","value":"b"}]`), &table)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), table.NumEntries())
+		value, found, _ := table.LongestMatch(_a("10.0.0.1"))
+		assert.True(t, found)
+		assert.Equal(t, "b", value)
+	})
+	t.Run("struct values", func(t *testing.T) {
+		type route struct {
+			NextHop Address `json:"next_hop"`
+			Metric  int     `json:"metric"`
+		}
+		var table Table[route]
+		err := json.Unmarshal([]byte(`{"0.0.0.0/0":{"next_hop":"10.0.0.1","metric":10}}`), &table)
+		assert.Nil(t, err)
+		value, found := table.Get(Prefix{})
+		assert.True(t, found)
+		assert.Equal(t, route{_a("10.0.0.1"), 10}, value)
+	})
+	t.Run("null", func(t *testing.T) {
+		table := Table[int]{}.Build(func(t_ Table_[int]) bool {
+			t_.Insert(_p("10.0.0.0/8"), 1)
+			return true
+		})
+		assert.Nil(t, json.Unmarshal([]byte(`null`), &table))
+		assert.Equal(t, int64(1), table.NumEntries())
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`[{"prefix":"10.0.0.0/8","value":1},{"prefix":"10.0.0.0/8","value":2}]`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("missing prefix", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`[{"value":1}]`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("bad prefix", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`{"10.0.0.0/33":1}`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("bad value", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`{"10.0.0.0/8":"one"}`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("not a table", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`"10.0.0.0/8"`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("custom comparator", func(t *testing.T) {
+		table := NewTableCustomCompare_(func(a, b []int) bool {
+			return len(a) == len(b)
+		}).Table()
+		err := json.Unmarshal([]byte(`{"10.0.0.0/24":[1],"10.0.1.0/24":[2]}`), &table)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), table.Aggregate().NumEntries())
+	})
+}
@@ -0,0 +1,69 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ValueCodec encodes and decodes the values stored in a Table for its binary
+// snapshot format. See Table.Encode and TableFromReader.
+type ValueCodec[T any] interface {
+	// EncodeValue writes the binary representation of value to w
+	EncodeValue(w io.Writer, value T) error
+	// DecodeValue reads exactly one value, as written by EncodeValue, from r
+	DecodeValue(r io.Reader) (T, error)
+}
+
+// BinaryCodec is a ValueCodec for fixed-size values (e.g. integers, or arrays
+// and structs of them) using encoding/binary in big-endian byte order.
+type BinaryCodec[T any] struct{}
+
+// EncodeValue implements ValueCodec
+func (BinaryCodec[T]) EncodeValue(w io.Writer, value T) error {
+	return binary.Write(w, binary.BigEndian, value)
+}
+
+// DecodeValue implements ValueCodec
+func (BinaryCodec[T]) DecodeValue(r io.Reader) (value T, err error) {
+	err = binary.Read(r, binary.BigEndian, &value)
+	return
+}
+
+// Encode writes the table to w using a compact, versioned binary format which
+// can be read back with TableFromReader. Each value is written using the given
+// codec. It is much faster to write and read than JSON for large tables.
+//
+// Only the network part of each prefix is stored. Any host bits in the keys
+// are not preserved.
+func (me Table[T]) Encode(w io.Writer, codec ValueCodec[T]) error {
+	return encodeTrie(w, snapshotKindTable, me.t.trie, func(w io.Writer, data interface{}) error {
+		t, _ := data.(T)
+		return codec.EncodeValue(w, t)
+	})
+}
+
+// TableFromReader reads a table written by Table.Encode from r using the given
+// codec to read each value. The trie is built directly from the entries in
+// one pass rather than inserting them one at a time. If r does not implement
+// io.ByteReader, it is wrapped in a bufio.Reader and so may be read beyond the
+// end of the table.
+//
+// The resulting table compares values with == like a table returned by
+// NewTable_.
+func TableFromReader[T any](r io.Reader, codec ValueCodec[T]) (Table[T], error) {
+	trie, err := decodeTrie(r, snapshotKindTable, func(r io.Reader) (interface{}, error) {
+		return codec.DecodeValue(r)
+	}, false)
+	if err != nil {
+		return Table[T]{}, err
+	}
+	return Table[T]{
+		tableX{
+			trie,
+			defaultComparator,
+		},
+	}, nil
+}
@@ -0,0 +1,211 @@
+package ipv4
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Inverse returns the wildcard mask (also called a host mask) for this mask
+// which has the bits inverted. It is written like an address (e.g. the inverse
+// of 255.255.255.0 is 0.0.0.255) as in Cisco ACLs.
+func (me Mask) Inverse() Address {
+	return Address{^me.ui}
+}
+
+// MaskFromWildcard returns the mask whose inverse is the given wildcard mask
+// (e.g. 0.0.0.255 gives 255.255.255.0). It is an error if the wildcard isn't
+// contiguous; use WildcardMatch for those.
+func MaskFromWildcard(wildcard Address) (Mask, error) {
+	m := Mask{^wildcard.ui}
+	if !m.valid() {
+		return Mask{}, fmt.Errorf("failed to create a valid mask from non-contiguous wildcard: %s", wildcard)
+	}
+	return m, nil
+}
+
+// splitAddressPair splits s into two whitespace-separated addresses
+func splitAddressPair(s string) (a, b Address, err error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Address{}, Address{}, fmt.Errorf("failed to parse %q: expected an address and a mask separated by whitespace", s)
+	}
+	if a, err = AddressFromStringStrict(fields[0]); err != nil {
+		return Address{}, Address{}, err
+	}
+	if b, err = AddressFromStringStrict(fields[1]); err != nil {
+		return Address{}, Address{}, err
+	}
+	return a, b, nil
+}
+
+// PrefixFromNetmaskString parses a prefix written as an address and a netmask
+// separated by whitespace as in router configurations (e.g. "10.0.0.0
+// 255.0.0.0"). Host bits are allowed in the address.
+func PrefixFromNetmaskString(prefix string) (Prefix, error) {
+	addr, netmask, err := splitAddressPair(prefix)
+	if err != nil {
+		return Prefix{}, err
+	}
+	mask, err := MaskFromUint32(netmask.ui)
+	if err != nil {
+		return Prefix{}, err
+	}
+	return PrefixFromAddressMask(addr, mask), nil
+}
+
+// PrefixFromWildcardString parses a prefix written as an address and a
+// wildcard mask separated by whitespace as in Cisco ACLs (e.g. "10.0.0.0
+// 0.255.255.255"). Host bits are allowed in the address. It is an error if the
+// wildcard isn't contiguous; use WildcardMatchFromString for those.
+func PrefixFromWildcardString(prefix string) (Prefix, error) {
+	addr, wildcard, err := splitAddressPair(prefix)
+	if err != nil {
+		return Prefix{}, err
+	}
+	mask, err := MaskFromWildcard(wildcard)
+	if err != nil {
+		return Prefix{}, err
+	}
+	return PrefixFromAddressMask(addr, mask), nil
+}
+
+// NetmaskString returns the prefix written as the address and the netmask
+// separated by a space (e.g. "10.0.0.0 255.0.0.0")
+func (me Prefix) NetmaskString() string {
+	return me.addr.String() + " " + me.Mask().String()
+}
+
+// WildcardString returns the prefix written as the address and the wildcard
+// mask separated by a space (e.g. "10.0.0.0 0.255.255.255")
+func (me Prefix) WildcardString() string {
+	return me.addr.String() + " " + me.Mask().Inverse().String()
+}
+
+// WildcardMatch matches addresses using an address and a wildcard mask as in
+// Cisco ACLs. Bits set in the wildcard are ignored. The others must be equal
+// to the corresponding bits of the address. Unlike the mask of a Prefix, the
+// wildcard doesn't have to be contiguous (e.g. "10.0.0.1 0.0.255.0" matches
+// 10.0.x.1 for any x).
+//
+// The zero value matches only 0.0.0.0.
+type WildcardMatch struct {
+	addr     Address
+	wildcard uint32
+}
+
+var _ SetI = WildcardMatch{}
+
+// WildcardMatchFromAddresses returns a WildcardMatch from the address and
+// wildcard mask. Bits in the address which are ignored are cleared.
+func WildcardMatchFromAddresses(addr, wildcard Address) WildcardMatch {
+	return WildcardMatch{Address{addr.ui &^ wildcard.ui}, wildcard.ui}
+}
+
+// WildcardMatchFromString parses an address and wildcard mask separated by
+// whitespace (e.g. "10.0.0.1 0.0.255.0"). As in Cisco ACLs, "host 10.0.0.1" is
+// accepted for a single address and "any" for all addresses.
+func WildcardMatchFromString(match string) (WildcardMatch, error) {
+	fields := strings.Fields(match)
+	switch {
+	case len(fields) == 1 && fields[0] == "any":
+		return WildcardMatch{wildcard: maxUint32}, nil
+	case len(fields) == 2 && fields[0] == "host":
+		addr, err := AddressFromStringStrict(fields[1])
+		if err != nil {
+			return WildcardMatch{}, err
+		}
+		return WildcardMatch{addr, 0}, nil
+	}
+	addr, wildcard, err := splitAddressPair(match)
+	if err != nil {
+		return WildcardMatch{}, err
+	}
+	return WildcardMatchFromAddresses(addr, wildcard), nil
+}
+
+// Address returns the address with the ignored bits cleared
+func (me WildcardMatch) Address() Address {
+	return me.addr
+}
+
+// Wildcard returns the wildcard mask
+func (me WildcardMatch) Wildcard() Address {
+	return Address{me.wildcard}
+}
+
+// String returns the address and wildcard mask separated by a space (e.g.
+// "10.0.0.1 0.0.255.0")
+func (me WildcardMatch) String() string {
+	return me.addr.String() + " " + me.Wildcard().String()
+}
+
+// Matches returns true if the address matches
+func (me WildcardMatch) Matches(addr Address) bool {
+	return addr.ui&^me.wildcard == me.addr.ui
+}
+
+// NumAddresses returns the number of addresses that match
+func (me WildcardMatch) NumAddresses() int64 {
+	return int64(1) << bits.OnesCount32(me.wildcard)
+}
+
+// Prefix returns the equivalent prefix if the wildcard is contiguous.
+// Otherwise, ok is false and the result must be ignored.
+func (me WildcardMatch) Prefix() (prefix Prefix, ok bool) {
+	mask, err := MaskFromWildcard(me.Wildcard())
+	if err != nil {
+		return Prefix{}, false
+	}
+	return PrefixFromAddressMask(me.addr, mask), true
+}
+
+// NumPrefixes returns the number of prefixes walked by WalkPrefixes. Each of
+// the ignored bits that isn't part of the contiguous run at the end of the
+// wildcard doubles it.
+func (me WildcardMatch) NumPrefixes() int64 {
+	trailing := bits.TrailingZeros32(^me.wildcard)
+	if trailing == 32 {
+		return 1
+	}
+	return int64(1) << bits.OnesCount32(me.wildcard>>trailing)
+}
+
+// WalkPrefixes calls the callback with each of the largest prefixes that
+// together make up the matching addresses in order. See NumPrefixes for how
+// many there are. It returns false if iteration was stopped by the callback
+// returning false.
+func (me WildcardMatch) WalkPrefixes(callback func(Prefix) bool) bool {
+	trailing := uint32(bits.TrailingZeros32(^me.wildcard))
+	if trailing == 32 {
+		return callback(Prefix{me.addr, 0})
+	}
+	length := 32 - trailing
+	scattered := me.wildcard &^ (1<<trailing - 1)
+
+	// Count through the combinations of the scattered bits in order. Each
+	// step sets all of the other bits so that adding one carries into the
+	// next scattered bit.
+	ui := me.addr.ui
+	for {
+		if !callback(Prefix{Address{ui}, length}) {
+			return false
+		}
+		next := ((ui | ^scattered) + 1) & scattered
+		if next == 0 {
+			return true
+		}
+		ui = me.addr.ui | next
+	}
+}
+
+// Set returns the set of matching addresses. It has NumPrefixes prefixes
+// which can be very large for a wildcard with many scattered bits.
+func (me WildcardMatch) Set() Set {
+	s := NewSet_()
+	me.WalkPrefixes(func(prefix Prefix) bool {
+		s.Insert(prefix)
+		return true
+	})
+	return s.Set()
+}
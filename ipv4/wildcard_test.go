@@ -0,0 +1,216 @@
+package ipv4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskInverse(t *testing.T) {
+	assert.Equal(t, _a("255.255.255.255"), _m(0).Inverse())
+	assert.Equal(t, _a("0.255.255.255"), _m(8).Inverse())
+	assert.Equal(t, _a("0.0.0.31"), _m(27).Inverse())
+	assert.Equal(t, _a("0.0.0.0"), _m(32).Inverse())
+}
+
+func TestMaskFromWildcard(t *testing.T) {
+	for length := 0; length <= 32; length++ {
+		m, err := MaskFromWildcard(_m(length).Inverse())
+		assert.Nil(t, err)
+		assert.Equal(t, _m(length), m)
+	}
+
+	_, err := MaskFromWildcard(_a("0.0.255.0"))
+	assert.NotNil(t, err)
+	_, err = MaskFromWildcard(_a("255.0.0.0"))
+	assert.NotNil(t, err)
+}
+
+func TestPrefixFromNetmaskString(t *testing.T) {
+	p, err := PrefixFromNetmaskString("10.0.0.0 255.0.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.0.0.0/8"), p)
+
+	p, err = PrefixFromNetmaskString("  192.0.2.17\t255.255.255.224 ")
+	assert.Nil(t, err)
+	assert.Equal(t, _p("192.0.2.17/27"), p)
+
+	for _, input := range []string{
+		"",
+		"10.0.0.0",
+		"10.0.0.0/8",
+		"10.0.0.0 255.0.0.0 0",
+		"10.0.0.0 255.0.255.0",
+		"10.0.0.0 0.255.255.255",
+		"10.0.0 255.0.0.0",
+	} {
+		_, err := PrefixFromNetmaskString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestPrefixFromWildcardString(t *testing.T) {
+	p, err := PrefixFromWildcardString("10.0.0.0 0.255.255.255")
+	assert.Nil(t, err)
+	assert.Equal(t, _p("10.0.0.0/8"), p)
+
+	p, err = PrefixFromWildcardString("192.0.2.1 0.0.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, _p("192.0.2.1/32"), p)
+
+	for _, input := range []string{
+		"10.0.0.0",
+		"10.0.0.0 255.0.0.0",
+		"10.0.0.1 0.0.255.0",
+	} {
+		_, err := PrefixFromWildcardString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestPrefixNetmaskWildcardString(t *testing.T) {
+	p := _p("10.0.0.0/8")
+	assert.Equal(t, "10.0.0.0 255.0.0.0", p.NetmaskString())
+	assert.Equal(t, "10.0.0.0 0.255.255.255", p.WildcardString())
+
+	p = _p("192.0.2.17/27")
+	assert.Equal(t, "192.0.2.17 255.255.255.224", p.NetmaskString())
+	assert.Equal(t, "192.0.2.17 0.0.0.31", p.WildcardString())
+
+	for _, s := range []string{"0.0.0.0/0", "203.0.113.0/24", "203.0.113.5/32"} {
+		p := _p(s)
+		fromNetmask, err := PrefixFromNetmaskString(p.NetmaskString())
+		assert.Nil(t, err)
+		assert.Equal(t, p, fromNetmask)
+		fromWildcard, err := PrefixFromWildcardString(p.WildcardString())
+		assert.Nil(t, err)
+		assert.Equal(t, p, fromWildcard)
+	}
+}
+
+func TestWildcardMatchFromString(t *testing.T) {
+	tests := []struct {
+		input    string
+		address  Address
+		wildcard Address
+		str      string
+	}{
+		{"10.0.0.1 0.0.255.0", _a("10.0.0.1"), _a("0.0.255.0"), "10.0.0.1 0.0.255.0"},
+		{"10.0.7.1 0.0.255.0", _a("10.0.0.1"), _a("0.0.255.0"), "10.0.0.1 0.0.255.0"},
+		{"10.0.0.0 0.255.255.255", _a("10.0.0.0"), _a("0.255.255.255"), "10.0.0.0 0.255.255.255"},
+		{"host 192.0.2.1", _a("192.0.2.1"), _a("0.0.0.0"), "192.0.2.1 0.0.0.0"},
+		{"any", _a("0.0.0.0"), _a("255.255.255.255"), "0.0.0.0 255.255.255.255"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			match, err := WildcardMatchFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, match.Address())
+			assert.Equal(t, tt.wildcard, match.Wildcard())
+			assert.Equal(t, tt.str, match.String())
+		})
+	}
+
+	for _, input := range []string{"", "host", "any 10.0.0.0", "10.0.0.1", "10.0.0.1 0.0.255"} {
+		_, err := WildcardMatchFromString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestWildcardMatchMatches(t *testing.T) {
+	match := WildcardMatchFromAddresses(_a("10.0.0.1"), _a("0.0.255.0"))
+	assert.True(t, match.Matches(_a("10.0.0.1")))
+	assert.True(t, match.Matches(_a("10.0.17.1")))
+	assert.True(t, match.Matches(_a("10.0.255.1")))
+	assert.False(t, match.Matches(_a("10.0.0.2")))
+	assert.False(t, match.Matches(_a("10.1.0.1")))
+
+	// Even addresses in 192.0.2.0/24
+	match = WildcardMatchFromAddresses(_a("192.0.2.0"), _a("0.0.0.254"))
+	assert.True(t, match.Matches(_a("192.0.2.0")))
+	assert.True(t, match.Matches(_a("192.0.2.254")))
+	assert.False(t, match.Matches(_a("192.0.2.1")))
+	assert.Equal(t, int64(128), match.NumAddresses())
+}
+
+func TestWildcardMatchPrefix(t *testing.T) {
+	p, ok := WildcardMatchFromAddresses(_a("10.1.2.3"), _a("0.0.255.255")).Prefix()
+	assert.True(t, ok)
+	assert.Equal(t, _p("10.1.0.0/16"), p)
+
+	_, ok = WildcardMatchFromAddresses(_a("10.0.0.1"), _a("0.0.255.0")).Prefix()
+	assert.False(t, ok)
+}
+
+func TestWildcardMatchSet(t *testing.T) {
+	tests := []struct {
+		description string
+		match       WildcardMatch
+		prefixes    []Prefix
+	}{
+		{
+			description: "host",
+			match:       WildcardMatchFromAddresses(_a("192.0.2.1"), _a("0.0.0.0")),
+			prefixes:    []Prefix{_p("192.0.2.1/32")},
+		}, {
+			description: "any",
+			match:       WildcardMatchFromAddresses(_a("0.0.0.0"), _a("255.255.255.255")),
+			prefixes:    []Prefix{_p("0.0.0.0/0")},
+		}, {
+			description: "contiguous",
+			match:       WildcardMatchFromAddresses(_a("10.0.0.0"), _a("0.255.255.255")),
+			prefixes:    []Prefix{_p("10.0.0.0/8")},
+		}, {
+			description: "scattered",
+			match:       WildcardMatchFromAddresses(_a("10.0.0.0"), _a("0.0.1.3")),
+			prefixes: []Prefix{
+				_p("10.0.0.0/30"),
+				_p("10.0.1.0/30"),
+			},
+		}, {
+			description: "several scattered bits",
+			match:       WildcardMatchFromAddresses(_a("10.0.0.1"), _a("0.0.3.16")),
+			prefixes: []Prefix{
+				_p("10.0.0.1/32"),
+				_p("10.0.0.17/32"),
+				_p("10.0.1.1/32"),
+				_p("10.0.1.17/32"),
+				_p("10.0.2.1/32"),
+				_p("10.0.2.17/32"),
+				_p("10.0.3.1/32"),
+				_p("10.0.3.17/32"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, int64(len(tt.prefixes)), tt.match.NumPrefixes())
+
+			var prefixes []Prefix
+			tt.match.WalkPrefixes(func(p Prefix) bool {
+				prefixes = append(prefixes, p)
+				return true
+			})
+			assert.Equal(t, tt.prefixes, prefixes)
+
+			set := tt.match.Set()
+			assert.Equal(t, tt.match.NumAddresses(), set.NumAddresses())
+			set.WalkPrefixes(func(p Prefix) bool {
+				assert.True(t, tt.match.Matches(p.Address()))
+				return true
+			})
+		})
+	}
+}
+
+func TestWildcardMatchWalkPrefixesStop(t *testing.T) {
+	match := WildcardMatchFromAddresses(_a("10.0.0.0"), _a("0.0.255.0"))
+	count := 0
+	assert.False(t, match.WalkPrefixes(func(Prefix) bool {
+		count++
+		return count < 3
+	}))
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,209 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv4
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ZoneFileOptions holds the zone-level data written at the top of each
+// generated zone file. Names should be fully qualified (end in a dot).
+type ZoneFileOptions struct {
+	// TTL is the default TTL for records in the zone, in seconds
+	TTL uint32
+	// PrimaryNS is the primary name server (MNAME) in the SOA record
+	PrimaryNS string
+	// Mailbox is the mailbox of the person responsible for the zone (RNAME)
+	// in the SOA record (e.g. "hostmaster.example.com.")
+	Mailbox string
+	// Serial, Refresh, Retry, Expire and Minimum are the numeric fields of
+	// the SOA record. All but Serial are in seconds.
+	Serial, Refresh, Retry, Expire, Minimum uint32
+	// NameServers are written as NS records at the apex of the zone. If
+	// empty, PrimaryNS is used.
+	NameServers []string
+}
+
+// ReverseZonesFromTable returns the reverse zones needed to hold the PTR
+// records for a table which maps prefixes to host names (see
+// WriteReverseZone). Each entry is placed in a zone of the given length which
+// must be a multiple of 8 up to 24, or between 25 and 31 for RFC 2317
+// classless zones. An entry shorter than that is covered by larger zones as
+// returned by its ReverseZones method.
+// Zones that would overlap or be adjacent are merged so the result is the
+// smallest set of non-overlapping zones covering every entry, in address
+// order.
+func ReverseZonesFromTable(table Table[string], length int) ([]ReverseZone, error) {
+	if length < 0 || length > 31 || (length <= 24 && length%8 != 0) {
+		return nil, fmt.Errorf("reverse zone length must be 0, 8, 16, 24 or between 25 and 31: %d", length)
+	}
+
+	s := NewSet_()
+	table.Walk(func(prefix Prefix, _ string) bool {
+		zoneLength := uint32(length)
+		if prefix.length < zoneLength {
+			zoneLength = prefix.length
+		}
+		s.Insert(Prefix{prefix.addr, zoneLength}.Network())
+		return true
+	})
+
+	var zones []ReverseZone
+	s.Set().WalkPrefixes(func(prefix Prefix) bool {
+		zones = append(zones, prefix.ReverseZones()...)
+		return true
+	})
+	return zones, nil
+}
+
+// maxZoneRecords limits the size of a generated zone file because a
+// short prefix needs a $GENERATE directive for each /24 in it
+const maxZoneRecords = 1 << 16
+
+// zoneRecord is a line in a zone file and the first address it applies to
+type zoneRecord struct {
+	first Address
+	line  string
+}
+
+// WriteReverseZone writes a BIND format zone file for the given zone to w.
+// The table maps prefixes to host names. Each host route (/32) is written as a
+// PTR record. Any other prefix is written as $GENERATE directives which
+// create PTR records for the addresses in it that aren't covered by a more
+// specific entry. Its host name is used as the right-hand side of the
+// directive where "$" is replaced by the last octet of the address (see the
+// BIND documentation for modifiers). Since the other octets aren't
+// substituted, a pattern for a prefix shorter than /24 produces the same name
+// in each /24. Entries with an empty host name are skipped. Host names which
+// aren't fully qualified have a dot appended.
+func WriteReverseZone(w io.Writer, zone ReverseZone, table Table[string], options ZoneFileOptions) error {
+	type entry struct {
+		prefix Prefix
+		name   string
+	}
+	var entries []entry
+	table.Walk(func(prefix Prefix, name string) bool {
+		prefix = prefix.Network()
+		if zone.Prefix.Contains(prefix) || prefix.Contains(zone.Prefix) {
+			entries = append(entries, entry{prefix, name})
+		}
+		return true
+	})
+
+	var records []zoneRecord
+	for _, e := range entries {
+		if e.name == "" {
+			continue
+		}
+		if e.prefix.length == 32 {
+			records = append(records, zoneRecord{
+				e.prefix.addr,
+				fmt.Sprintf("%s\tIN\tPTR\t%s", zone.relativeName(zone.RecordName(e.prefix.addr)), fullyQualified(e.name)),
+			})
+			continue
+		}
+
+		specifics := NewSet_()
+		for _, other := range entries {
+			if other.prefix != e.prefix && e.prefix.Contains(other.prefix) {
+				specifics.Insert(other.prefix)
+			}
+		}
+		region := e.prefix.Set().Intersection(zone.Prefix).Difference(specifics)
+		complete := region.WalkRanges(func(r Range) bool {
+			var ok bool
+			records, ok = zone.generateRecords(records, r, fullyQualified(e.name))
+			return ok
+		})
+		if !complete {
+			return fmt.Errorf("zone %s needs more than %d records", zone.Name, maxZoneRecords)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].first.lessThan(records[j].first)
+	})
+
+	var b bytes.Buffer
+	nameServers := options.NameServers
+	if len(nameServers) == 0 {
+		nameServers = []string{options.PrimaryNS}
+	}
+	fmt.Fprintf(&b, "$ORIGIN %s\n", zone.Name)
+	fmt.Fprintf(&b, "$TTL %d\n", options.TTL)
+	fmt.Fprintf(&b, "@\tIN\tSOA\t%s %s (\n", fullyQualified(options.PrimaryNS), fullyQualified(options.Mailbox))
+	fmt.Fprintf(&b, "\t\t%d\t; serial\n", options.Serial)
+	fmt.Fprintf(&b, "\t\t%d\t; refresh\n", options.Refresh)
+	fmt.Fprintf(&b, "\t\t%d\t; retry\n", options.Retry)
+	fmt.Fprintf(&b, "\t\t%d\t; expire\n", options.Expire)
+	fmt.Fprintf(&b, "\t\t%d\t; minimum\n", options.Minimum)
+	fmt.Fprintf(&b, "\t\t)\n")
+	for _, ns := range nameServers {
+		fmt.Fprintf(&b, "@\tIN\tNS\t%s\n", fullyQualified(ns))
+	}
+	for _, record := range records {
+		b.WriteString(record.line)
+		b.WriteByte('\n')
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// generateRecords returns the $GENERATE directives for the addresses in the
+// range which must be inside the zone. There is one for each /24 that the
+// range touches because the iterator only replaces the last octet. A zone for
+// a single address has nothing to iterate over so it gets a PTR record at the
+// apex instead.
+//
+// The directives are appended to records. If that brings the number of
+// records over maxZoneRecords, ok is false.
+func (me ReverseZone) generateRecords(records []zoneRecord, r Range, pattern string) (result []zoneRecord, ok bool) {
+	first := r.First()
+	for {
+		last := Address{first.ui | 0xff}
+		if r.Last().lessThan(last) {
+			last = r.Last()
+		}
+		owner := me.relativeName(me.RecordName(first))
+		if owner == "@" {
+			records = append(records, zoneRecord{first, fmt.Sprintf("@\tIN\tPTR\t%s", pattern)})
+			return records, len(records) <= maxZoneRecords
+		}
+		if i := strings.IndexByte(owner, '.'); i >= 0 {
+			owner = "$" + owner[i:]
+		} else {
+			owner = "$"
+		}
+		records = append(records, zoneRecord{
+			first,
+			fmt.Sprintf("$GENERATE %d-%d %s PTR %s", first.ui&0xff, last.ui&0xff, owner, pattern),
+		})
+		if len(records) > maxZoneRecords {
+			return records, false
+		}
+		if last == r.Last() {
+			return records, true
+		}
+		first = Address{last.ui + 1}
+	}
+}
+
+// relativeName returns the given name relative to the origin of the zone
+func (me ReverseZone) relativeName(name string) string {
+	if name == me.Name {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+me.Name)
+}
+
+// fullyQualified returns the name with a trailing dot added if it is missing
+func fullyQualified(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,167 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv4
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseZonesFromTable(t *testing.T) {
+	table := NewTable_[string]()
+	table.Insert(_a("203.0.113.17"), "www.example.com.")
+	table.Insert(_a("203.0.113.200"), "mail.example.com.")
+	table.Insert(_p("203.0.112.0/24"), "dyn-$.example.com.")
+	table.Insert(_a("198.51.100.1"), "gw.example.com.")
+	table.Insert(_p("10.0.0.0/15"), "pool-$.example.com.")
+	table.Insert(_a("10.1.2.3"), "host.example.com.")
+
+	tests := []struct {
+		length int
+		names  []string
+	}{
+		{24, []string{
+			"0.10.in-addr.arpa.",
+			"1.10.in-addr.arpa.",
+			"100.51.198.in-addr.arpa.",
+			"112.0.203.in-addr.arpa.",
+			"113.0.203.in-addr.arpa.",
+		}},
+		{16, []string{
+			"0.10.in-addr.arpa.",
+			"1.10.in-addr.arpa.",
+			"51.198.in-addr.arpa.",
+			"0.203.in-addr.arpa.",
+		}},
+		{25, []string{
+			"0.10.in-addr.arpa.",
+			"1.10.in-addr.arpa.",
+			"0/25.100.51.198.in-addr.arpa.",
+			"112.0.203.in-addr.arpa.",
+			"113.0.203.in-addr.arpa.",
+		}},
+	}
+
+	for _, tt := range tests {
+		zones, err := ReverseZonesFromTable(table.Table(), tt.length)
+		assert.Nil(t, err)
+		var names []string
+		for _, zone := range zones {
+			names = append(names, zone.Name)
+		}
+		assert.Equal(t, tt.names, names, "/%d", tt.length)
+	}
+
+	for _, length := range []int{-1, 4, 23, 32} {
+		_, err := ReverseZonesFromTable(table.Table(), length)
+		assert.NotNil(t, err, "/%d", length)
+	}
+}
+
+func TestWriteReverseZone(t *testing.T) {
+	table := NewTable_[string]()
+	table.Insert(_a("203.0.113.17"), "www.example.com")
+	table.Insert(_a("203.0.113.20"), "")
+	table.Insert(_p("203.0.113.0/26"), "dyn-$.example.com.")
+	table.Insert(_a("203.0.113.200"), "mail.example.com.")
+	table.Insert(_a("203.0.114.1"), "other.example.com.")
+
+	options := ZoneFileOptions{
+		TTL:         3600,
+		PrimaryNS:   "ns1.example.com.",
+		Mailbox:     "hostmaster.example.com",
+		Serial:      2024010101,
+		Refresh:     7200,
+		Retry:       900,
+		Expire:      1209600,
+		Minimum:     300,
+		NameServers: []string{"ns1.example.com.", "ns2.example.com."},
+	}
+
+	t.Run("octet aligned", func(t *testing.T) {
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("203.0.113.0/24").ReverseZones()[0], table.Table(), options)
+		assert.Nil(t, err)
+		assert.Equal(t, `$ORIGIN 113.0.203.in-addr.arpa.
+$TTL 3600
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2024010101	; serial
+		7200	; refresh
+		900	; retry
+		1209600	; expire
+		300	; minimum
+		)
+@	IN	NS	ns1.example.com.
+@	IN	NS	ns2.example.com.
+$GENERATE 0-16 $ PTR dyn-$.example.com.
+17	IN	PTR	www.example.com.
+$GENERATE 18-19 $ PTR dyn-$.example.com.
+$GENERATE 21-63 $ PTR dyn-$.example.com.
+200	IN	PTR	mail.example.com.
+`, b.String())
+	})
+
+	t.Run("classless", func(t *testing.T) {
+		options := options
+		options.NameServers = nil
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("203.0.113.0/27").ReverseZones()[0], table.Table(), options)
+		assert.Nil(t, err)
+		assert.Equal(t, `$ORIGIN 0/27.113.0.203.in-addr.arpa.
+$TTL 3600
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2024010101	; serial
+		7200	; refresh
+		900	; retry
+		1209600	; expire
+		300	; minimum
+		)
+@	IN	NS	ns1.example.com.
+$GENERATE 0-16 $ PTR dyn-$.example.com.
+17	IN	PTR	www.example.com.
+$GENERATE 18-19 $ PTR dyn-$.example.com.
+$GENERATE 21-31 $ PTR dyn-$.example.com.
+`, b.String())
+	})
+
+	t.Run("larger zone", func(t *testing.T) {
+		table := NewTable_[string]()
+		table.Insert(_p("10.0.0.0/23"), "pool-$.example.com.")
+		table.Insert(_a("10.0.1.1"), "host.example.com.")
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("10.0.0.0/16").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.Nil(t, err)
+		assert.Contains(t, b.String(), `
+$GENERATE 0-255 $.0 PTR pool-$.example.com.
+$GENERATE 0-0 $.1 PTR pool-$.example.com.
+1.1	IN	PTR	host.example.com.
+$GENERATE 2-255 $.1 PTR pool-$.example.com.
+`)
+	})
+
+	t.Run("single address", func(t *testing.T) {
+		table := NewTable_[string]()
+		table.Insert(_p("10.0.0.0/24"), "host.example.")
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("10.0.0.5/32").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.Nil(t, err)
+		assert.Contains(t, b.String(), "\n@\tIN\tPTR\thost.example.\n")
+		assert.NotContains(t, b.String(), "$GENERATE")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		table := NewTable_[string]()
+		table.Insert(_p("0.0.0.0/0"), "pool-$.example.com.")
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("0.0.0.0/0").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, b.Len())
+	})
+}
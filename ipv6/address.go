@@ -1,8 +1,12 @@
 package ipv6
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net"
+	"net/netip"
 )
 
 const (
@@ -12,12 +16,17 @@ const (
 
 // Address represents an IPv6 address
 type Address struct {
-	ui uint128
+	ui Uint128
 }
 
 // AddressFromUint64 returns the Address from its unsigned int representation
 func AddressFromUint64(high, low uint64) Address {
-	return Address{uint128{high, low}}
+	return Address{Uint128{high, low}}
+}
+
+// AddressFromUint128 returns the Address from its Uint128 representation
+func AddressFromUint128(ui Uint128) Address {
+	return Address{ui}
 }
 
 // AddressFromUint16 returns the Address from its eight 16 bit unsigned representation
@@ -30,7 +39,7 @@ func AddressFromUint16(a, b, c, d, e, f, g, h uint16) Address {
 		uint64(f)<<32 |
 		uint64(g)<<16 |
 		uint64(h)
-	return Address{uint128{high, low}}
+	return Address{Uint128{high, low}}
 }
 
 // AddressFromSlice returns the Address from individual bytes, ordered from
@@ -44,21 +53,43 @@ func AddressFromNetIP(ip net.IP) (Address, error) {
 	return fromSlice(ip)
 }
 
+// AddressFromNetipAddr converts a netip.Addr to an Address. It does not
+// allocate. The netip.Addr must be an IPv6 address. This includes IPv4-mapped
+// IPv6 addresses (e.g. ::ffff:203.0.113.17) but not plain IPv4 addresses. Any
+// zone in the netip.Addr is ignored.
+func AddressFromNetipAddr(addr netip.Addr) (Address, error) {
+	if !addr.Is6() {
+		return Address{}, fmt.Errorf("address is not IPv6: %s", addr)
+	}
+	return Address{uint128FromArray(addr.As16())}, nil
+}
+
 // AddressFromString returns the Address represented by `addr` in colon
 // notation. If it cannot be parsed, then error is non-nil and the Address
 // returned must be ignored.
+//
+// It does not allocate unless it fails. The last 32 bits may be written in
+// dotted-quad notation as in ::ffff:203.0.113.17 but a plain IPv4 address is
+// rejected. Errors that occur while parsing are of type *ParseError.
 func AddressFromString(address string) (Address, error) {
-	netIP := net.ParseIP(address)
-	if netIP == nil {
-		return Address{}, fmt.Errorf("failed to parse address: %s", address)
+	addr, pos, msg := parseAddress(address, false)
+	if msg != "" {
+		return Address{}, &ParseError{Input: address, Pos: pos, Msg: msg}
 	}
+	return addr, nil
+}
 
-	netIPv4 := netIP.To4()
-	if netIPv4 != nil {
-		return Address{}, fmt.Errorf("address is not IPv6: %s", address)
+// AddressFromStringStrict is like AddressFromString but accepts only the
+// canonical form of an address described in RFC 5952: lowercase, no leading
+// zeros in any group, and "::" replacing the first longest run of two or more
+// zero groups. Dotted-quad notation must be used for IPv4-mapped addresses
+// (::ffff:0:0/96) and nowhere else.
+func AddressFromStringStrict(address string) (Address, error) {
+	addr, pos, msg := parseAddress(address, true)
+	if msg != "" {
+		return Address{}, &ParseError{Input: address, Pos: pos, Msg: msg}
 	}
-
-	return AddressFromNetIP(netIP)
+	return addr, nil
 }
 
 // minAddress returns the address, a or b, which comes first in lexigraphical order
@@ -77,6 +108,12 @@ func maxAddress(a, b Address) Address {
 	return a
 }
 
+// ToNetipAddr returns the netip.Addr representation of the address. It does
+// not allocate.
+func (me Address) ToNetipAddr() netip.Addr {
+	return netip.AddrFrom16(me.ui.toArray())
+}
+
 // ToNetIP returns a net.IP representation of the address which always has 4 bytes
 func (me Address) ToNetIP() net.IP {
 	return me.ui.toBytes()
@@ -98,9 +135,94 @@ func (me Address) Set() Set {
 	return me.Prefix().Set()
 }
 
-// String returns a string representing the address in IPv6 notation
+// String returns a string representing the address in the canonical IPv6
+// notation from RFC 5952 (see FormatCanonical)
 func (me Address) String() string {
-	return me.ToNetIP().String()
+	return string(me.appendFormat(make([]byte, 0, 39), FormatCanonical))
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Address) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using AddressFromString
+func (me *Address) UnmarshalText(text []byte) error {
+	address, err := AddressFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = address
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The address is encoded as a JSON
+// string.
+func (me Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the address
+// unchanged.
+func (me *Address) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
+// Next returns the address immediately after this one. If this is the last
+// address (ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff), it wraps around to :: and
+// overflow is true.
+func (me Address) Next() (next Address, overflow bool) {
+	return me.Add(1)
+}
+
+// Prev returns the address immediately before this one. If this is the first
+// address (::), it wraps around to ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff and
+// underflow is true.
+func (me Address) Prev() (prev Address, underflow bool) {
+	return me.Sub(1)
+}
+
+// Add returns the address n addresses after this one. If that would go past
+// the end of the address space, the result wraps around and overflow is true.
+func (me Address) Add(n uint64) (result Address, overflow bool) {
+	sum := me.ui.addUint64(n)
+	return Address{sum}, sum.compare(me.ui) < 0
+}
+
+// Sub returns the address n addresses before this one. If that would go past
+// the beginning of the address space, the result wraps around and underflow is
+// true.
+func (me Address) Sub(n uint64) (result Address, underflow bool) {
+	difference := me.ui.subtractUint64(n)
+	return Address{difference}, difference.compare(me.ui) > 0
+}
+
+// Distance returns the number of addresses from this address to other. It is
+// negative if other comes before this address. If the distance doesn't fit in
+// an int64, overflow is true and distance must be ignored.
+func (me Address) Distance(other Address) (distance int64, overflow bool) {
+	negative := other.lessThan(me)
+	var d Uint128
+	if negative {
+		d = me.ui.subtract(other.ui)
+	} else {
+		d = other.ui.subtract(me.ui)
+	}
+	switch {
+	case d.high != 0:
+		return 0, true
+	case negative:
+		if d.low > 1<<63 {
+			return 0, true
+		}
+		return -int64(d.low), false
+	default:
+		if d.low > math.MaxInt64 {
+			return 0, true
+		}
+		return int64(d.low), false
+	}
 }
 
 // NumBits returns the size of an address (always 128)
@@ -110,7 +232,12 @@ func (me Address) NumBits() int {
 
 // Uint64 returns the address as two uint64
 func (me Address) Uint64() (uint64, uint64) {
-	return me.ui.uint64()
+	return me.ui.Uint64()
+}
+
+// Uint128 returns the address as a Uint128
+func (me Address) Uint128() Uint128 {
+	return me.ui
 }
 
 // fromSlice returns the Address from a slice or an error if the slice is the
@@ -125,3 +252,17 @@ func fromSlice(s []byte) (Address, error) {
 	val, err := uint128FromBytes(s)
 	return Address{val}, err
 }
+
+// unmarshalJSONText decodes a JSON string and passes its contents to the given
+// TextUnmarshaler. A JSON null is ignored, as is the convention for
+// json.Unmarshaler.
+func unmarshalJSONText(data []byte, u encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(text))
+}
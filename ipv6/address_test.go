@@ -1,7 +1,10 @@
 package ipv6
 
 import (
+	"encoding/json"
+	"math"
 	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -204,3 +207,233 @@ func TestAddressFromUint16(t *testing.T) {
 	ip := AddressFromUint16(0x2001, 0xdb8, 0x85a3, 0xabcd, 0, 0, 0, 0x1)
 	assert.Equal(t, ip.String(), "2001:db8:85a3:abcd::1")
 }
+
+func TestAddressMarshalText(t *testing.T) {
+	text, err := _a("2001:db8::1").MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "2001:db8::1", string(text))
+
+	var a Address
+	assert.Nil(t, a.UnmarshalText(text))
+	assert.Equal(t, _a("2001:db8::1"), a)
+
+	assert.NotNil(t, a.UnmarshalText([]byte("2001:db8::g")))
+	assert.Equal(t, _a("2001:db8::1"), a)
+}
+
+func TestAddressMarshalJSON(t *testing.T) {
+	type config struct {
+		Gateway Address   `json:"gateway"`
+		Servers []Address `json:"servers"`
+	}
+	c := config{
+		Gateway: _a("2001:db8::1"),
+		Servers: []Address{_a("2001:db8::2"), _a("2001:db8::3")},
+	}
+	data, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"gateway":"2001:db8::1","servers":["2001:db8::2","2001:db8::3"]}`, string(data))
+
+	var decoded config
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, c, decoded)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"gateway":null}`), &decoded))
+	assert.Equal(t, _a("2001:db8::1"), decoded.Gateway)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"gateway":"10.0.0.1"}`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"gateway":1}`), &decoded))
+}
+
+func TestAddressFromNetipAddr(t *testing.T) {
+	tests := []struct {
+		description string
+		addr        netip.Addr
+		expected    Address
+		isErr       bool
+	}{
+		{
+			description: "zero",
+			addr:        netip.Addr{},
+			isErr:       true,
+		},
+		{
+			description: "ipv4",
+			addr:        netip.MustParseAddr("10.224.24.1"),
+			isErr:       true,
+		},
+		{
+			description: "ipv4 mapped",
+			addr:        netip.MustParseAddr("::ffff:203.0.113.17"),
+			expected:    AddressFromUint64(0x0, 0xffffcb007111),
+		},
+		{
+			description: "ipv6",
+			addr:        netip.MustParseAddr("2001:db8:85a3::8a2e:370:7334"),
+			expected:    AddressFromUint64(0x20010db885a30000, 0x8a2e03707334),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			addr, err := AddressFromNetipAddr(tt.addr)
+			if tt.isErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, addr)
+				assert.Equal(t, tt.addr, addr.ToNetipAddr())
+			}
+		})
+	}
+
+	t.Run("zone", func(t *testing.T) {
+		addr, err := AddressFromNetipAddr(netip.MustParseAddr("fe80::1%eth0"))
+		assert.Nil(t, err)
+		assert.Equal(t, _a("fe80::1"), addr)
+	})
+}
+
+func TestAddressNetipAllocs(t *testing.T) {
+	addr := netip.MustParseAddr("2001:db8::1")
+	allocs := testing.AllocsPerRun(100, func() {
+		a, _ := AddressFromNetipAddr(addr)
+		_ = a.ToNetipAddr()
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestAddressNextPrev(t *testing.T) {
+	next, overflow := _a("2001:db8::ffff:ffff:ffff:ffff").Next()
+	assert.Equal(t, _a("2001:db8:0:1::"), next)
+	assert.False(t, overflow)
+
+	next, overflow = _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").Next()
+	assert.Equal(t, _a("::"), next)
+	assert.True(t, overflow)
+
+	prev, underflow := _a("2001:db8:0:1::").Prev()
+	assert.Equal(t, _a("2001:db8::ffff:ffff:ffff:ffff"), prev)
+	assert.False(t, underflow)
+
+	prev, underflow = _a("::").Prev()
+	assert.Equal(t, _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), prev)
+	assert.True(t, underflow)
+}
+
+func TestAddressAddSub(t *testing.T) {
+	tests := []struct {
+		description string
+		a           Address
+		n           uint64
+		sum         Address
+		overflow    bool
+		difference  Address
+		underflow   bool
+	}{
+		{
+			description: "zero",
+			a:           _a("2001:db8::1"),
+			n:           0,
+			sum:         _a("2001:db8::1"),
+			difference:  _a("2001:db8::1"),
+		}, {
+			description: "carry",
+			a:           _a("2001:db8::1"),
+			n:           0xffffffffffffffff,
+			sum:         _a("2001:db8:0:1::"),
+			difference:  _a("2001:db7:ffff:ffff::2"),
+		}, {
+			description: "underflow",
+			a:           _a("::1"),
+			n:           2,
+			sum:         _a("::3"),
+			difference:  _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"),
+			underflow:   true,
+		}, {
+			description: "overflow",
+			a:           _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0"),
+			n:           0x11,
+			sum:         _a("::1"),
+			overflow:    true,
+			difference:  _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffdf"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			sum, overflow := tt.a.Add(tt.n)
+			assert.Equal(t, tt.sum, sum)
+			assert.Equal(t, tt.overflow, overflow)
+
+			difference, underflow := tt.a.Sub(tt.n)
+			assert.Equal(t, tt.difference, difference)
+			assert.Equal(t, tt.underflow, underflow)
+		})
+	}
+}
+
+func TestAddressDistance(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        Address
+		distance    int64
+		overflow    bool
+	}{
+		{
+			description: "same",
+			a:           _a("2001:db8::1"),
+			b:           _a("2001:db8::1"),
+		}, {
+			description: "forward",
+			a:           _a("2001:db8::1"),
+			b:           _a("2001:db8::1:0"),
+			distance:    0xffff,
+		}, {
+			description: "backward",
+			a:           _a("2001:db8::1:0"),
+			b:           _a("2001:db8::1"),
+			distance:    -0xffff,
+		}, {
+			description: "max",
+			a:           _a("2001:db8::"),
+			b:           _a("2001:db8::7fff:ffff:ffff:ffff"),
+			distance:    math.MaxInt64,
+		}, {
+			description: "min",
+			a:           _a("2001:db8::8000:0:0:0"),
+			b:           _a("2001:db8::"),
+			distance:    math.MinInt64,
+		}, {
+			description: "too far forward",
+			a:           _a("2001:db8::"),
+			b:           _a("2001:db8::8000:0:0:0"),
+			overflow:    true,
+		}, {
+			description: "too far backward",
+			a:           _a("2001:db8::8000:0:0:1"),
+			b:           _a("2001:db8::"),
+			overflow:    true,
+		}, {
+			description: "across halves",
+			a:           _a("2001:db8::ffff:ffff:ffff:ffff"),
+			b:           _a("2001:db8:0:1::1"),
+			distance:    2,
+		}, {
+			description: "extremes",
+			a:           _a("::"),
+			b:           _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"),
+			overflow:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			distance, overflow := tt.a.Distance(tt.b)
+			assert.Equal(t, tt.overflow, overflow)
+			if !overflow {
+				assert.Equal(t, tt.distance, distance)
+			}
+		})
+	}
+}
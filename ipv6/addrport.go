@@ -0,0 +1,188 @@
+package ipv6
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// AddrPort is a ZonedAddress plus a port number (e.g. [2001:db8::1]:443 or
+// [fe80::1%eth0]:443). The zone is usually empty. It is comparable and can be
+// used as a map key.
+type AddrPort struct {
+	addr ZonedAddress
+	port uint16
+}
+
+// AddrPortFromAddressPort returns an AddrPort with the given address and port
+// and no zone
+func AddrPortFromAddressPort(addr Address, port uint16) AddrPort {
+	return AddrPort{ZonedAddress{addr: addr}, port}
+}
+
+// AddrPortFromZonedAddressPort returns an AddrPort with the given address,
+// including its zone, and port
+func AddrPortFromZonedAddressPort(addr ZonedAddress, port uint16) AddrPort {
+	return AddrPort{addr, port}
+}
+
+// AddrPortFromString parses an address in colon notation, with an optional
+// zone, enclosed in brackets and followed by a colon and a decimal port (e.g.
+// [2001:db8::1]:443 or [fe80::1%eth0]:443). Errors that occur while parsing
+// are of type *ParseError.
+func AddrPortFromString(addrPort string) (AddrPort, error) {
+	if !strings.HasPrefix(addrPort, "[") {
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: 0, Msg: "expected '['"}
+	}
+	end := strings.IndexByte(addrPort, ']')
+	if end < 0 {
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: len(addrPort), Msg: "expected ']'"}
+	}
+	addr, err := ZonedAddressFromString(addrPort[1:end])
+	if err != nil {
+		parseErr := err.(*ParseError)
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: 1 + parseErr.Pos, Msg: parseErr.Msg}
+	}
+	if end+1 == len(addrPort) || addrPort[end+1] != ':' {
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: end + 1, Msg: "expected ':'"}
+	}
+	port, pos, msg := parsePort(addrPort, end+2)
+	if msg != "" {
+		return AddrPort{}, &ParseError{Input: addrPort, Pos: pos, Msg: msg}
+	}
+	return AddrPort{addr, port}, nil
+}
+
+// AddrPortFromNetTCPAddr converts a *net.TCPAddr, including its zone, to an
+// AddrPort. Its IP must be a 16 byte IPv6 address.
+func AddrPortFromNetTCPAddr(addr *net.TCPAddr) (AddrPort, error) {
+	if addr == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert nil *net.TCPAddr")
+	}
+	return addrPortFromNetIPPort(addr.IP, addr.Zone, addr.Port)
+}
+
+// AddrPortFromNetUDPAddr converts a *net.UDPAddr, including its zone, to an
+// AddrPort. Its IP must be a 16 byte IPv6 address.
+func AddrPortFromNetUDPAddr(addr *net.UDPAddr) (AddrPort, error) {
+	if addr == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert nil *net.UDPAddr")
+	}
+	return addrPortFromNetIPPort(addr.IP, addr.Zone, addr.Port)
+}
+
+// AddrPortFromNetipAddrPort converts a netip.AddrPort, including its zone, to
+// an AddrPort. Its address must be an IPv6 address.
+func AddrPortFromNetipAddrPort(addrPort netip.AddrPort) (AddrPort, error) {
+	addr, err := ZonedAddressFromNetipAddr(addrPort.Addr())
+	if err != nil {
+		return AddrPort{}, err
+	}
+	return AddrPort{addr, addrPort.Port()}, nil
+}
+
+// addrPortFromNetIPPort converts the fields of a *net.TCPAddr or *net.UDPAddr
+func addrPortFromNetIPPort(ip net.IP, zone string, port int) (AddrPort, error) {
+	addr, err := AddressFromNetIP(ip)
+	if err != nil {
+		return AddrPort{}, err
+	}
+	if port < 0 || port > 0xffff {
+		return AddrPort{}, fmt.Errorf("port is out of range: %d", port)
+	}
+	return AddrPort{ZonedAddress{addr, zone}, uint16(port)}, nil
+}
+
+// Address returns the address without the zone
+func (me AddrPort) Address() Address {
+	return me.addr.addr
+}
+
+// ZonedAddress returns the address with the zone
+func (me AddrPort) ZonedAddress() ZonedAddress {
+	return me.addr
+}
+
+// Zone returns the zone or "" if there is none
+func (me AddrPort) Zone() string {
+	return me.addr.zone
+}
+
+// Port returns the port
+func (me AddrPort) Port() uint16 {
+	return me.port
+}
+
+// ToNetTCPAddr returns a *net.TCPAddr with the address, zone and port
+func (me AddrPort) ToNetTCPAddr() *net.TCPAddr {
+	return &net.TCPAddr{IP: me.addr.addr.ToNetIP(), Port: int(me.port), Zone: me.addr.zone}
+}
+
+// ToNetUDPAddr returns a *net.UDPAddr with the address, zone and port
+func (me AddrPort) ToNetUDPAddr() *net.UDPAddr {
+	return &net.UDPAddr{IP: me.addr.addr.ToNetIP(), Port: int(me.port), Zone: me.addr.zone}
+}
+
+// ToNetipAddrPort returns the netip.AddrPort representation including the zone
+func (me AddrPort) ToNetipAddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(me.addr.ToNetipAddr(), me.port)
+}
+
+// String returns the address and zone in brackets followed by a colon and the
+// port (e.g. [2001:db8::1]:443)
+func (me AddrPort) String() string {
+	return fmt.Sprintf("[%s]:%d", me.addr, me.port)
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me AddrPort) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using AddrPortFromString
+func (me *AddrPort) UnmarshalText(text []byte) error {
+	addrPort, err := AddrPortFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = addrPort
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The AddrPort is encoded as a JSON
+// string.
+func (me AddrPort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the AddrPort
+// unchanged.
+func (me *AddrPort) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
+// parsePort parses the decimal port in s starting at position i through the
+// end of s
+func parsePort(s string, i int) (port uint16, pos int, msg string) {
+	start := i
+	var val uint32
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if i > start && s[start] == '0' {
+			return 0, start, "port has a leading zero"
+		}
+		val = val*10 + uint32(s[i]-'0')
+		if val > 0xffff {
+			return 0, start, "port is greater than 65535"
+		}
+	}
+	if i == start {
+		return 0, i, "expected a decimal port"
+	}
+	if i != len(s) {
+		return 0, i, "unexpected character"
+	}
+	return uint16(val), 0, ""
+}
@@ -0,0 +1,68 @@
+//go:build !plan9
+// +build !plan9
+
+package ipv6
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+)
+
+// AddrPortFromSockaddr converts a syscall.Sockaddr to an AddrPort. It must be
+// a *syscall.SockaddrInet6. A non-zero zone index is converted to the name of
+// the interface with that index if there is one or to the index in decimal
+// otherwise.
+func AddrPortFromSockaddr(sa syscall.Sockaddr) (AddrPort, error) {
+	sa6, ok := sa.(*syscall.SockaddrInet6)
+	if !ok || sa6 == nil {
+		return AddrPort{}, fmt.Errorf("failed to convert %T to an IPv6 AddrPort", sa)
+	}
+	if sa6.Port < 0 || sa6.Port > 0xffff {
+		return AddrPort{}, fmt.Errorf("port is out of range: %d", sa6.Port)
+	}
+	return AddrPort{
+		ZonedAddress{Address{uint128FromArray(sa6.Addr)}, zoneFromIndex(sa6.ZoneId)},
+		uint16(sa6.Port),
+	}, nil
+}
+
+// ToSockaddr returns a *syscall.SockaddrInet6 with the address and port. The
+// zone is converted to an index. It may be a decimal index or the name of an
+// interface. It is an error if there is no interface with that name.
+func (me AddrPort) ToSockaddr() (syscall.Sockaddr, error) {
+	zoneID, err := zoneToIndex(me.addr.zone)
+	if err != nil {
+		return nil, err
+	}
+	return &syscall.SockaddrInet6{Port: int(me.port), ZoneId: zoneID, Addr: me.addr.addr.ui.toArray()}, nil
+}
+
+// zoneToIndex returns the interface index for a zone which is either a
+// decimal index or the name of an interface. The empty zone is index 0.
+func zoneToIndex(zone string) (uint32, error) {
+	if zone == "" {
+		return 0, nil
+	}
+	if index, err := strconv.ParseUint(zone, 10, 32); err == nil {
+		return uint32(index), nil
+	}
+	iface, err := net.InterfaceByName(zone)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the index of zone %q: %w", zone, err)
+	}
+	return uint32(iface.Index), nil
+}
+
+// zoneFromIndex returns the name of the interface with the given index or the
+// index in decimal if there is none. Index 0 is the empty zone.
+func zoneFromIndex(index uint32) string {
+	if index == 0 {
+		return ""
+	}
+	if iface, err := net.InterfaceByIndex(int(index)); err == nil {
+		return iface.Name
+	}
+	return strconv.FormatUint(uint64(index), 10)
+}
@@ -0,0 +1,40 @@
+//go:build !plan9
+// +build !plan9
+
+package ipv6
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddrPortSockaddr(t *testing.T) {
+	addrPort := AddrPortFromAddressPort(_a("2001:db8::1"), 443)
+	sa, err := addrPort.ToSockaddr()
+	assert.Nil(t, err)
+	assert.Equal(t, &syscall.SockaddrInet6{
+		Port: 443,
+		Addr: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1},
+	}, sa)
+
+	converted, err := AddrPortFromSockaddr(sa)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	// A numeric zone is used as the index directly
+	sa, err = AddrPortFromZonedAddressPort(_a("fe80::1").WithZone("4000000"), 443).ToSockaddr()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(4000000), sa.(*syscall.SockaddrInet6).ZoneId)
+	converted, err = AddrPortFromSockaddr(sa)
+	assert.Nil(t, err)
+	assert.Equal(t, "4000000", converted.Zone())
+
+	_, err = AddrPortFromZonedAddressPort(_a("fe80::1").WithZone("no-such-interface"), 443).ToSockaddr()
+	assert.NotNil(t, err)
+	_, err = AddrPortFromSockaddr(&syscall.SockaddrInet4{Port: 80})
+	assert.NotNil(t, err)
+	_, err = AddrPortFromSockaddr(nil)
+	assert.NotNil(t, err)
+}
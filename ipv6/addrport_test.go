@@ -0,0 +1,122 @@
+package ipv6
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddrPortFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		address Address
+		zone    string
+		port    uint16
+		str     string
+	}{
+		{"[2001:db8::1]:443", _a("2001:db8::1"), "", 443, "[2001:db8::1]:443"},
+		{"[::]:0", _a("::"), "", 0, "[::]:0"},
+		{"[fe80::1%eth0]:65535", _a("fe80::1"), "eth0", 65535, "[fe80::1%eth0]:65535"},
+		{"[2001:DB8:0::1]:80", _a("2001:db8::1"), "", 80, "[2001:db8::1]:80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			addrPort, err := AddrPortFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addrPort.Address())
+			assert.Equal(t, tt.zone, addrPort.Zone())
+			assert.Equal(t, tt.address.WithZone(tt.zone), addrPort.ZonedAddress())
+			assert.Equal(t, tt.port, addrPort.Port())
+			assert.Equal(t, AddrPortFromZonedAddressPort(tt.address.WithZone(tt.zone), tt.port), addrPort)
+			assert.Equal(t, tt.str, addrPort.String())
+		})
+	}
+}
+
+func TestAddrPortFromStringErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"2001:db8::1:443", 0},
+		{"[2001:db8::1:443", 16},
+		{"[2001:db8::1]", 13},
+		{"[2001:db8::1]443", 13},
+		{"[2001:db8::1]:", 14},
+		{"[2001:db8::1]:65536", 14},
+		{"[2001:db8::g]:443", 11},
+		{"[fe80::1%]:443", 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := AddrPortFromString(tt.input)
+			assert.NotNil(t, err)
+			if parseErr, ok := err.(*ParseError); assert.True(t, ok) {
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+			}
+		})
+	}
+}
+
+func TestAddrPortComparable(t *testing.T) {
+	a := AddrPortFromAddressPort(_a("2001:db8::1"), 443)
+	m := map[AddrPort]int{a: 1}
+	assert.Equal(t, 1, m[AddrPortFromAddressPort(_a("2001:db8::1"), 443)])
+	assert.Equal(t, 0, m[AddrPortFromAddressPort(_a("2001:db8::1"), 80)])
+	assert.Equal(t, 0, m[AddrPortFromZonedAddressPort(_a("2001:db8::1").WithZone("eth0"), 443)])
+}
+
+func TestAddrPortNetConversions(t *testing.T) {
+	addrPort := AddrPortFromZonedAddressPort(_a("fe80::1").WithZone("eth0"), 443)
+
+	tcp := addrPort.ToNetTCPAddr()
+	assert.Equal(t, &net.TCPAddr{IP: net.ParseIP("fe80::1"), Port: 443, Zone: "eth0"}, tcp)
+	converted, err := AddrPortFromNetTCPAddr(tcp)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	udp := addrPort.ToNetUDPAddr()
+	assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 443, Zone: "eth0"}, udp)
+	converted, err = AddrPortFromNetUDPAddr(udp)
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	_, err = AddrPortFromNetTCPAddr(nil)
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetUDPAddr(nil)
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetTCPAddr(&net.TCPAddr{IP: net.IP{203, 0, 113, 17}, Port: 80})
+	assert.NotNil(t, err)
+	_, err = AddrPortFromNetUDPAddr(&net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: -1})
+	assert.NotNil(t, err)
+}
+
+func TestAddrPortNetipAddrPort(t *testing.T) {
+	addrPort := AddrPortFromZonedAddressPort(_a("fe80::1").WithZone("eth0"), 443)
+	assert.Equal(t, netip.MustParseAddrPort("[fe80::1%eth0]:443"), addrPort.ToNetipAddrPort())
+
+	converted, err := AddrPortFromNetipAddrPort(netip.MustParseAddrPort("[fe80::1%eth0]:443"))
+	assert.Nil(t, err)
+	assert.Equal(t, addrPort, converted)
+
+	_, err = AddrPortFromNetipAddrPort(netip.MustParseAddrPort("203.0.113.17:80"))
+	assert.NotNil(t, err)
+}
+
+func TestAddrPortJSON(t *testing.T) {
+	addrPort := AddrPortFromZonedAddressPort(_a("fe80::1").WithZone("eth0"), 443)
+	data, err := json.Marshal(addrPort)
+	assert.Nil(t, err)
+	assert.Equal(t, `"[fe80::1%eth0]:443"`, string(data))
+
+	var decoded AddrPort
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, addrPort, decoded)
+	assert.NotNil(t, json.Unmarshal([]byte(`"fe80::1"`), &decoded))
+}
@@ -0,0 +1,293 @@
+package ipv6
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatFlags select how an address is written by the FormatString methods.
+// They can be combined with |. The zero value is the canonical form.
+type FormatFlags uint8
+
+const (
+	// FormatCanonical is the canonical form from RFC 5952 (e.g. "2001:db8::1").
+	// Hexadecimal digits are lowercase, leading zeros are omitted, and "::"
+	// replaces the first longest run of two or more zero groups. IPv4-mapped
+	// addresses are written with a dotted-quad tail (e.g. "::ffff:192.0.2.1").
+	// This is what String returns.
+	FormatCanonical FormatFlags = 0
+	// FormatExpanded writes all eight groups with four digits each and never
+	// uses "::" (e.g. "2001:0db8:0000:0000:0000:0000:0000:0001"). Expanded
+	// addresses all have the same length so they sort correctly as strings.
+	FormatExpanded FormatFlags = 1 << (iota - 1)
+	// FormatBracketed encloses the address in square brackets as it appears in
+	// a URL (e.g. "[2001:db8::1]")
+	FormatBracketed
+	// FormatMixed writes the last 32 bits of any address in dotted-quad
+	// notation as is common for addresses which embed an IPv4 address such
+	// as those synthesized by NAT64 (e.g. "64:ff9b::192.0.2.33")
+	FormatMixed
+	// FormatUppercase writes hexadecimal digits in uppercase
+	FormatUppercase
+)
+
+// FormatString returns the address written according to the given flags
+func (me Address) FormatString(flags FormatFlags) string {
+	return string(me.appendFormat(make([]byte, 0, 48), flags))
+}
+
+// appendFormat appends the address written according to the given flags to b
+func (me Address) appendFormat(b []byte, flags FormatFlags) []byte {
+	digits := "0123456789abcdef"
+	if flags&FormatUppercase != 0 {
+		digits = "0123456789ABCDEF"
+	}
+	expanded := flags&FormatExpanded != 0
+	dotted := flags&FormatMixed != 0 || (!expanded && me.IsIPv4Mapped())
+
+	var groups [8]uint16
+	for i := range groups {
+		groups[i] = uint16(me.ui.rightShift(112 - 16*i).low)
+	}
+	n := len(groups)
+	if dotted {
+		n -= 2
+	}
+
+	// Find the first, longest run of at least two zero groups to replace
+	runStart, runEnd := -1, -1
+	if !expanded {
+		for i := 0; i < n; {
+			if groups[i] != 0 {
+				i++
+				continue
+			}
+			j := i
+			for j < n && groups[j] == 0 {
+				j++
+			}
+			if j-i > 1 && j-i > runEnd-runStart {
+				runStart, runEnd = i, j
+			}
+			i = j
+		}
+	}
+
+	if flags&FormatBracketed != 0 {
+		b = append(b, '[')
+	}
+	for i := 0; i < n; {
+		if i == runStart {
+			b = append(b, ':', ':')
+			i = runEnd
+			continue
+		}
+		if i > 0 && i != runEnd {
+			b = append(b, ':')
+		}
+		group := groups[i]
+		if expanded {
+			b = append(b, digits[group>>12], digits[group>>8&0xf], digits[group>>4&0xf], digits[group&0xf])
+		} else {
+			started := false
+			for shift := 12; shift >= 0; shift -= 4 {
+				digit := group >> shift & 0xf
+				if digit != 0 || started || shift == 0 {
+					b = append(b, digits[digit])
+					started = true
+				}
+			}
+		}
+		i++
+	}
+	if dotted {
+		if runEnd != n {
+			b = append(b, ':')
+		}
+		for i, octet := range []uint16{groups[6] >> 8, groups[6] & 0xff, groups[7] >> 8, groups[7] & 0xff} {
+			if i > 0 {
+				b = append(b, '.')
+			}
+			b = strconv.AppendUint(b, uint64(octet), 10)
+		}
+	}
+	if flags&FormatBracketed != 0 {
+		b = append(b, ']')
+	}
+	return b
+}
+
+// FormatString returns the mask written like an address according to the
+// given flags
+func (me Mask) FormatString(flags FormatFlags) string {
+	return Address{me.ui}.FormatString(flags)
+}
+
+// FormatString returns the prefix in CIDR notation with the address written
+// according to the given flags
+func (me Prefix) FormatString(flags FormatFlags) string {
+	b := me.addr.appendFormat(make([]byte, 0, 52), flags)
+	b = append(b, '/')
+	return string(strconv.AppendUint(b, uint64(me.length), 10))
+}
+
+// FormatString returns the range in the format "[first,last]" with the
+// addresses written according to the given flags
+func (me Range) FormatString(flags FormatFlags) string {
+	b := append(make([]byte, 0, 100), '[')
+	b = me.first.appendFormat(b, flags)
+	b = append(b, ',')
+	b = me.last.appendFormat(b, flags)
+	return string(append(b, ']'))
+}
+
+// Format implements fmt.Formatter. The verbs are:
+//
+//	%s, %v  the canonical form as returned by String
+//	%q      the same in double quotes
+//	%x, %X  the 128 bits in lowercase or uppercase hexadecimal, zero-padded
+//	%b      the 128 bits in binary, zero-padded
+//	%d      the 128 bits as a decimal integer
+//
+// The '#' flag adds a "0x" or "0b" prefix to %x, %X and %b. With %v, it
+// writes the Go syntax representation. A width pads the result with spaces,
+// on the left unless the '-' flag is given.
+func (me Address) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv6.Address{ui:%#v}", me.ui)
+		return
+	}
+	formatValue(f, verb, "ipv6.Address", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		return me.ui.appendFormat(b, verb, sharp)
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address
+func (me Mask) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv6.Mask{ui:%#v}", me.ui)
+		return
+	}
+	formatValue(f, verb, "ipv6.Mask", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		return me.ui.appendFormat(b, verb, sharp)
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to the address and the length is always decimal.
+func (me Prefix) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv6.Prefix{addr:%#v, length:%#v}", me.addr, me.length)
+		return
+	}
+	formatValue(f, verb, "ipv6.Prefix", me.String(), me.appendFormatInt)
+}
+
+// appendFormatInt appends the prefix with the address formatted for one of
+// the integer verbs supported by Format
+func (me Prefix) appendFormatInt(b []byte, verb rune, sharp bool) ([]byte, bool) {
+	b, ok := me.addr.ui.appendFormat(b, verb, sharp)
+	b = append(b, '/')
+	return strconv.AppendUint(b, uint64(me.length), 10), ok
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to both addresses.
+func (me Range) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv6.Range{first:%#v, last:%#v}", me.first, me.last)
+		return
+	}
+	formatValue(f, verb, "ipv6.Range", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		b = append(b, '[')
+		b, ok := me.first.ui.appendFormat(b, verb, sharp)
+		b = append(b, ',')
+		b, _ = me.last.ui.appendFormat(b, verb, sharp)
+		return append(b, ']'), ok
+	})
+}
+
+// Format implements fmt.Formatter with the same verbs as Address. The verb
+// applies to each prefix in the set.
+func (me Set) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "ipv6.Set{trie:%#v}", me.trie)
+		return
+	}
+	formatValue(f, verb, "ipv6.Set", me.String(), func(b []byte, verb rune, sharp bool) ([]byte, bool) {
+		ok := true
+		b = append(b, '[')
+		first := true
+		me.WalkPrefixes(func(p Prefix) bool {
+			if !first {
+				b = append(b, ',', ' ')
+			}
+			first = false
+			b, ok = p.appendFormatInt(b, verb, sharp)
+			return ok
+		})
+		return append(b, ']'), ok
+	})
+}
+
+// appendFormat appends the number formatted for one of the integer verbs
+// supported by Format. If the verb isn't supported, ok is false.
+func (me Uint128) appendFormat(b []byte, verb rune, sharp bool) (result []byte, ok bool) {
+	var base, width int
+	switch verb {
+	case 'x', 'X':
+		base, width = 16, 32
+		if sharp {
+			b = append(b, '0', byte(verb))
+		}
+	case 'b':
+		base, width = 2, 128
+		if sharp {
+			b = append(b, '0', 'b')
+		}
+	case 'd':
+		return append(b, me.String()...), true
+	default:
+		return b, false
+	}
+
+	high, low := strconv.FormatUint(me.high, base), strconv.FormatUint(me.low, base)
+	if verb == 'X' {
+		high, low = strings.ToUpper(high), strings.ToUpper(low)
+	}
+	half := width / 2
+	b = append(b, strings.Repeat("0", half-len(high))...)
+	b = append(b, high...)
+	b = append(b, strings.Repeat("0", half-len(low))...)
+	return append(b, low...), true
+}
+
+// formatValue implements the parts of fmt.Formatter that are common to all of
+// the value types. str is the result for %s and %v. appendInt appends the
+// result for the integer verbs or returns false if the verb isn't supported.
+func formatValue(f fmt.State, verb rune, typeName, str string, appendInt func(b []byte, verb rune, sharp bool) ([]byte, bool)) {
+	var b []byte
+	switch verb {
+	case 's', 'v':
+		b = []byte(str)
+	case 'q':
+		b = strconv.AppendQuote(nil, str)
+	default:
+		var ok bool
+		if b, ok = appendInt(nil, verb, f.Flag('#')); !ok {
+			fmt.Fprintf(f, "%%!%c(%s=%s)", verb, typeName, str)
+			return
+		}
+	}
+
+	if width, ok := f.Width(); ok && width > len(b) {
+		padding := strings.Repeat(" ", width-len(b))
+		if f.Flag('-') {
+			b = append(b, padding...)
+		} else {
+			b = append([]byte(padding), b...)
+		}
+	}
+	f.Write(b)
+}
@@ -0,0 +1,146 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressFormatString(t *testing.T) {
+	tests := []struct {
+		address  Address
+		flags    FormatFlags
+		expected string
+	}{
+		{_a("2001:db8::1"), FormatCanonical, "2001:db8::1"},
+		{_a("2001:db8::1"), FormatExpanded, "2001:0db8:0000:0000:0000:0000:0000:0001"},
+		{_a("2001:db8::1"), FormatBracketed, "[2001:db8::1]"},
+		{_a("2001:db8::1"), FormatBracketed | FormatExpanded, "[2001:0db8:0000:0000:0000:0000:0000:0001]"},
+		{_a("2001:db8::abcd"), FormatUppercase, "2001:DB8::ABCD"},
+		{_a("2001:db8::abcd"), FormatUppercase | FormatExpanded, "2001:0DB8:0000:0000:0000:0000:0000:ABCD"},
+		{_a("::"), FormatCanonical, "::"},
+		{_a("::"), FormatExpanded, "0000:0000:0000:0000:0000:0000:0000:0000"},
+		{_a("::1"), FormatCanonical, "::1"},
+		{_a("1::"), FormatCanonical, "1::"},
+		{_a("2001:db8:0:1:1:1:1:1"), FormatCanonical, "2001:db8:0:1:1:1:1:1"},
+		{_a("2001:0:0:1:0:0:0:1"), FormatCanonical, "2001:0:0:1::1"},
+		{_a("2001:db8:0:0:1:0:0:1"), FormatCanonical, "2001:db8::1:0:0:1"},
+		{_a("::ffff:192.0.2.1"), FormatCanonical, "::ffff:192.0.2.1"},
+		{_a("::ffff:192.0.2.1"), FormatExpanded, "0000:0000:0000:0000:0000:ffff:c000:0201"},
+		{_a("::ffff:192.0.2.1"), FormatExpanded | FormatMixed, "0000:0000:0000:0000:0000:ffff:192.0.2.1"},
+		{_a("::ffff:192.0.2.1"), FormatBracketed, "[::ffff:192.0.2.1]"},
+		{_a("64:ff9b::c000:221"), FormatCanonical, "64:ff9b::c000:221"},
+		{_a("64:ff9b::c000:221"), FormatMixed, "64:ff9b::192.0.2.33"},
+		{_a("64:ff9b::c000:221"), FormatMixed | FormatUppercase, "64:FF9B::192.0.2.33"},
+		{_a("2001:db8:122:344::c000:221"), FormatMixed, "2001:db8:122:344::192.0.2.33"},
+		{_a("::c000:221"), FormatMixed, "::192.0.2.33"},
+		{_a("::"), FormatMixed, "::0.0.0.0"},
+		{_a("1:2:3:4:5:6:c000:221"), FormatMixed, "1:2:3:4:5:6:192.0.2.33"},
+		{_a("1:0:3:4:5:6:c000:221"), FormatMixed, "1:0:3:4:5:6:192.0.2.33"},
+		{_a("1:0:0:4:5:6:c000:221"), FormatMixed, "1::4:5:6:192.0.2.33"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.address.FormatString(tt.flags))
+
+			if tt.flags&(FormatBracketed) == 0 {
+				addr, err := AddressFromString(tt.expected)
+				assert.Nil(t, err)
+				assert.Equal(t, tt.address, addr)
+			}
+		})
+	}
+}
+
+func TestAddressStringCanonical(t *testing.T) {
+	// String should agree with net.IP for anything that isn't IPv4-mapped and
+	// the strict parser should accept all of it
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		var groups [8]uint16
+		for j := range groups {
+			// Make zeros common so that there are lots of runs
+			if r.Intn(2) == 0 {
+				groups[j] = uint16(r.Intn(0x10000) >> (4 * r.Intn(4)))
+			}
+		}
+		addr := AddressFromUint16(groups[0], groups[1], groups[2], groups[3], groups[4], groups[5], groups[6], groups[7])
+		if addr.IsIPv4Mapped() {
+			continue
+		}
+		assert.Equal(t, addr.ToNetIP().String(), addr.String())
+
+		parsed, err := AddressFromStringStrict(addr.String())
+		assert.Nil(t, err)
+		assert.Equal(t, addr, parsed)
+	}
+
+	mapped := _a("::ffff:192.0.2.1")
+	parsed, err := AddressFromStringStrict(mapped.String())
+	assert.Nil(t, err)
+	assert.Equal(t, mapped, parsed)
+}
+
+func TestFormatStringTypes(t *testing.T) {
+	assert.Equal(t, "[2001:0db8:0000:0000:0000:0000:0000:0000]/32", _p("2001:db8::/32").FormatString(FormatExpanded|FormatBracketed))
+	assert.Equal(t, "64:ff9b::192.0.2.0/120", _p("64:ff9b::c000:200/120").FormatString(FormatMixed))
+	assert.Equal(t, "[2001:DB8::A,2001:DB8::F]", _r(_a("2001:db8::a"), _a("2001:db8::f")).FormatString(FormatUppercase))
+	assert.Equal(t, "ffff:ffff:ffff:ffff:0000:0000:0000:0000", _m(64).FormatString(FormatExpanded))
+}
+
+func TestFormatter(t *testing.T) {
+	addr := _a("2001:db8::1")
+	prefix := _p("2001:db8::/32")
+	r := _r(_a("2001:db8::a"), _a("2001:db8::f"))
+
+	tests := []struct {
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{"%s", addr, "2001:db8::1"},
+		{"%v", addr, "2001:db8::1"},
+		{"%q", addr, `"2001:db8::1"`},
+		{"%x", addr, "20010db8000000000000000000000001"},
+		{"%#x", addr, "0x20010db8000000000000000000000001"},
+		{"%X", _a("2001:db8::abcd"), "20010DB800000000000000000000ABCD"},
+		{"%#X", _a("2001:db8::abcd"), "0X20010DB800000000000000000000ABCD"},
+		{"%b", _a("8000::1"), "1" + fmt.Sprintf("%0126d", 0) + "1"},
+		{"%#b", _a("::1"), "0b" + fmt.Sprintf("%0127d", 0) + "1"},
+		{"%d", _a("::1:0:0:0:0"), "18446744073709551616"},
+		{"%d", _a("::ff"), "255"},
+		{"%20s", _a("::1"), "                 ::1"},
+		{"%-20s|", _a("::1"), "::1                 |"},
+		{"%z", addr, "%!z(ipv6.Address=2001:db8::1)"},
+		{"%#v", addr, "ipv6.Address{ui:ipv6.Uint128{high:0x20010db800000000, low:0x1}}"},
+		{"%v", []Address{addr, addr}, "[2001:db8::1 2001:db8::1]"},
+
+		{"%s", prefix, "2001:db8::/32"},
+		{"%x", prefix, "20010db8000000000000000000000000/32"},
+		{"%24s", _p("2001:db8::/32"), "           2001:db8::/32"},
+		{"%#v", _p("::1/128"), "ipv6.Prefix{addr:ipv6.Address{ui:ipv6.Uint128{high:0x0, low:0x1}}, length:0x80}"},
+
+		{"%s", r, "[2001:db8::a,2001:db8::f]"},
+		{"%x", r, "[20010db800000000000000000000000a,20010db800000000000000000000000f]"},
+
+		{"%s", _m(32), "ffff:ffff::"},
+		{"%x", _m(32), "ffffffff000000000000000000000000"},
+
+		{"%s", prefix.Set(), "[2001:db8::/32]"},
+		{"%x", Set{}.Union(_a("::1")).Union(_a("::3")), "[00000000000000000000000000000001/128, 00000000000000000000000000000003/128]"},
+		{"%z", prefix.Set(), "%!z(ipv6.Set=[2001:db8::/32])"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, tt.value))
+		})
+	}
+}
+
+func TestAddressStringMapped(t *testing.T) {
+	assert.Equal(t, "::ffff:203.0.113.17", IPv4MappedAddress(_a4("203.0.113.17")).String())
+}
@@ -1,6 +1,7 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 )
@@ -10,10 +11,10 @@ import (
 // zeroes or all ones.
 // The zero value of a Mask is "/0"
 type Mask struct {
-	ui uint128
+	ui Uint128
 }
 
-var maxUint128 = uint128{^uint64(0), ^uint64(0)}
+var maxUint128 = Uint128{^uint64(0), ^uint64(0)}
 
 // MaskFromLength converts the given length into a mask with that number of leading 1s
 func MaskFromLength(length int) (Mask, error) {
@@ -37,7 +38,7 @@ func MaskFromUint16(a, b, c, d, e, f, g, h uint16) (Mask, error) {
 // MaskFromUint64 returns the mask from its two uint64 unsigned integer
 // representation.
 func MaskFromUint64(high uint64, low uint64) (Mask, error) {
-	m := Mask{uint128{high, low}}
+	m := Mask{Uint128{high, low}}
 	if !m.valid() {
 		return Mask{}, fmt.Errorf("failed to create a valid mask from uint64: %x, %x", high, low)
 	}
@@ -60,6 +61,21 @@ func MaskFromNetIPMask(mask net.IPMask) (Mask, error) {
 	return m, nil
 }
 
+// MaskFromString returns the Mask represented by `mask` in colon notation
+// (e.g. ffff:ffff:ffff:ffff::). If it cannot be parsed or isn't a valid mask,
+// then error is non-nil and the Mask returned must be ignored.
+func MaskFromString(mask string) (Mask, error) {
+	address, err := AddressFromString(mask)
+	if err != nil {
+		return Mask{}, err
+	}
+	m := Mask{address.ui}
+	if !m.valid() {
+		return Mask{}, fmt.Errorf("failed to create a valid mask from string: %s", mask)
+	}
+	return m, nil
+}
+
 // Length returns the number of leading 1s in the mask
 func (me Mask) Length() int {
 	return me.ui.complement().leadingZeros()
@@ -77,7 +93,35 @@ func (me Mask) String() string {
 
 // Uint64 returns the mask as two uint64s
 func (me Mask) Uint64() (uint64, uint64) {
-	return me.ui.uint64()
+	return me.ui.Uint64()
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Mask) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using MaskFromString
+func (me *Mask) UnmarshalText(text []byte) error {
+	mask, err := MaskFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = mask
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The mask is encoded as a JSON
+// string.
+func (me Mask) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the mask
+// unchanged.
+func (me *Mask) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
 }
 
 func (me Mask) valid() bool {
@@ -1,6 +1,7 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"net"
 	"testing"
 
@@ -15,13 +16,13 @@ func TestMaskComparable(t *testing.T) {
 	}{
 		{
 			description: "equal",
-			a:           Mask{ui: uint128{0xffffffffffffffff, 0xffff000000000000}},
-			b:           Mask{ui: uint128{0xffffffffffffffff, 0xffff000000000000}},
+			a:           Mask{ui: Uint128{0xffffffffffffffff, 0xffff000000000000}},
+			b:           Mask{ui: Uint128{0xffffffffffffffff, 0xffff000000000000}},
 			equal:       true,
 		}, {
 			description: "not equal",
-			a:           Mask{ui: uint128{0xffffffffffffffff, 0x0000000000000000}},
-			b:           Mask{ui: uint128{0xffffffffffffffff, 0xffffe00000000000}},
+			a:           Mask{ui: Uint128{0xffffffffffffffff, 0x0000000000000000}},
+			b:           Mask{ui: Uint128{0xffffffffffffffff, 0xffffe00000000000}},
 			equal:       false,
 		},
 	}
@@ -35,13 +36,13 @@ func TestMaskComparable(t *testing.T) {
 }
 
 func TestMaskLength(t *testing.T) {
-	assert.Equal(t, 0, Mask{uint128{0x0000000000000000, 0}}.Length())
-	assert.Equal(t, 16, Mask{uint128{0xffff000000000000, 0}}.Length())
-	assert.Equal(t, 27, Mask{uint128{0xffffffe000000000, 0}}.Length())
-	assert.Equal(t, 32, Mask{uint128{0xffffffff00000000, 0}}.Length())
-	assert.Equal(t, 64, Mask{uint128{0xffffffffffffffff, 0}}.Length())
-	assert.Equal(t, 80, Mask{uint128{0xffffffffffffffff, 0xffff000000000000}}.Length())
-	assert.Equal(t, 128, Mask{uint128{0xffffffffffffffff, 0xffffffffffffffff}}.Length())
+	assert.Equal(t, 0, Mask{Uint128{0x0000000000000000, 0}}.Length())
+	assert.Equal(t, 16, Mask{Uint128{0xffff000000000000, 0}}.Length())
+	assert.Equal(t, 27, Mask{Uint128{0xffffffe000000000, 0}}.Length())
+	assert.Equal(t, 32, Mask{Uint128{0xffffffff00000000, 0}}.Length())
+	assert.Equal(t, 64, Mask{Uint128{0xffffffffffffffff, 0}}.Length())
+	assert.Equal(t, 80, Mask{Uint128{0xffffffffffffffff, 0xffff000000000000}}.Length())
+	assert.Equal(t, 128, Mask{Uint128{0xffffffffffffffff, 0xffffffffffffffff}}.Length())
 }
 
 func _m(length int) Mask {
@@ -76,13 +77,13 @@ func TestMaskFromUint64Error(t *testing.T) {
 }
 
 func TestMaskFromUint16(t *testing.T) {
-	assert.Equal(t, Mask{uint128{0x0000000000000000, 0}}, _m(0))
-	assert.Equal(t, Mask{uint128{0xffff000000000000, 0}}, _m(16))
-	assert.Equal(t, Mask{uint128{0xffffffe000000000, 0}}, _m(27))
-	assert.Equal(t, Mask{uint128{0xffffffff00000000, 0}}, _m(32))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0}}, _m(64))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0xffff000000000000}}, _m(80))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0xffffffffffffffff}}, _m(128))
+	assert.Equal(t, Mask{Uint128{0x0000000000000000, 0}}, _m(0))
+	assert.Equal(t, Mask{Uint128{0xffff000000000000, 0}}, _m(16))
+	assert.Equal(t, Mask{Uint128{0xffffffe000000000, 0}}, _m(27))
+	assert.Equal(t, Mask{Uint128{0xffffffff00000000, 0}}, _m(32))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0}}, _m(64))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0xffff000000000000}}, _m(80))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0xffffffffffffffff}}, _m(128))
 }
 
 func TestMaskFromNetIPMask(t *testing.T) {
@@ -92,14 +93,14 @@ func TestMaskFromNetIPMask(t *testing.T) {
 		assert.Nil(t, err)
 		return mask
 	}
-	assert.Equal(t, Mask{uint128{0x0000000000000000, 0}}, convert(0, addressSize))
-	assert.Equal(t, Mask{uint128{0xffff000000000000, 0}}, convert(16, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffe000000000, 0}}, convert(27, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffff00000000, 0}}, convert(32, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0}}, convert(64, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0xffff000000000000}}, convert(80, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0xfffffffffe000000}}, convert(103, addressSize))
-	assert.Equal(t, Mask{uint128{0xffffffffffffffff, 0xffffffffffffffff}}, convert(128, addressSize))
+	assert.Equal(t, Mask{Uint128{0x0000000000000000, 0}}, convert(0, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffff000000000000, 0}}, convert(16, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffe000000000, 0}}, convert(27, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffff00000000, 0}}, convert(32, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0}}, convert(64, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0xffff000000000000}}, convert(80, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0xfffffffffe000000}}, convert(103, addressSize))
+	assert.Equal(t, Mask{Uint128{0xffffffffffffffff, 0xffffffffffffffff}}, convert(128, addressSize))
 
 	runWithError := func(ones, bits int) {
 		stdMask := net.CIDRMask(ones, bits)
@@ -112,8 +113,8 @@ func TestMaskFromNetIPMask(t *testing.T) {
 }
 
 func TestMaskToNetIPMask(t *testing.T) {
-	assert.Equal(t, net.CIDRMask(25, addressSize), Mask{uint128{0xffffff8000000000, 0}}.ToNetIPMask())
-	assert.Equal(t, net.CIDRMask(72, addressSize), Mask{uint128{0xffffffffffffffff, 0xff00000000000000}}.ToNetIPMask())
+	assert.Equal(t, net.CIDRMask(25, addressSize), Mask{Uint128{0xffffff8000000000, 0}}.ToNetIPMask())
+	assert.Equal(t, net.CIDRMask(72, addressSize), Mask{Uint128{0xffffffffffffffff, 0xff00000000000000}}.ToNetIPMask())
 }
 
 func TestAddressString(t *testing.T) {
@@ -172,3 +173,28 @@ func TestMaskAsMapKey(t *testing.T) {
 
 	assert.True(t, m[_m(71)])
 }
+
+func TestMaskFromString(t *testing.T) {
+	m, err := MaskFromString("ffff:ffff:ffff:ffff::")
+	assert.Nil(t, err)
+	assert.Equal(t, _m(64), m)
+
+	_, err = MaskFromString("ffff::ffff")
+	assert.NotNil(t, err)
+
+	_, err = MaskFromString("bogus")
+	assert.NotNil(t, err)
+}
+
+func TestMaskMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(_m(56))
+	assert.Nil(t, err)
+	assert.Equal(t, `"ffff:ffff:ffff:ff00::"`, string(data))
+
+	var m Mask
+	assert.Nil(t, json.Unmarshal(data, &m))
+	assert.Equal(t, _m(56), m)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"ffff::ffff"`), &m))
+	assert.Equal(t, _m(56), m)
+}
@@ -0,0 +1,183 @@
+package ipv6
+
+import (
+	"fmt"
+
+	"gopkg.in/addrs.v1/ipv4"
+)
+
+// NAT64 translates between IPv4 addresses and IPv4-embedded IPv6 addresses
+// using a NAT64 prefix as described in RFC 6052. The IPv4 address is placed
+// right after the prefix except that bits 64 to 71 (the "u" octet) are always
+// skipped and left zero. Any bits after the IPv4 address (the suffix) are zero.
+//
+// The zero value is not valid. Use NAT64FromPrefix or WellKnownNAT64.
+type NAT64 struct {
+	prefix Prefix
+}
+
+// WellKnownNAT64 returns a NAT64 using the well-known prefix, 64:ff9b::/96
+func WellKnownNAT64() NAT64 {
+	return NAT64{Prefix{Address{Uint128{0x0064ff9b00000000, 0}}, 96}}
+}
+
+// NAT64FromPrefix returns a NAT64 using the given prefix. Its length must be
+// one of 32, 40, 48, 56, 64 or 96. For a /96, bits 64 to 71 must be zero. Any
+// host bits in the prefix are ignored.
+func NAT64FromPrefix(prefix Prefix) (NAT64, error) {
+	switch prefix.length {
+	case 32, 40, 48, 56, 64, 96:
+	default:
+		return NAT64{}, fmt.Errorf("NAT64 prefix length must be 32, 40, 48, 56, 64 or 96: %s", prefix)
+	}
+	prefix = prefix.Network()
+	if uOctet(prefix.addr) != 0 {
+		return NAT64{}, fmt.Errorf("bits 64 to 71 of a NAT64 prefix must be zero: %s", prefix)
+	}
+	return NAT64{prefix}, nil
+}
+
+// Prefix returns the NAT64 prefix
+func (me NAT64) Prefix() Prefix {
+	return me.prefix
+}
+
+// String returns the NAT64 prefix in CIDR notation
+func (me NAT64) String() string {
+	return me.prefix.String()
+}
+
+// uOctet returns bits 64 to 71 of the address
+func uOctet(addr Address) byte {
+	return byte(addr.ui.low >> 56)
+}
+
+// layout returns the number of bits of the IPv4 address which come between
+// the end of the prefix and the u octet and the position of the first bit of
+// the rest of the IPv4 address.
+func (me NAT64) layout() (before, rest int) {
+	length := int(me.prefix.length)
+	if length >= 64 {
+		return 0, intMax(72, length)
+	}
+	return intMin(32, 64-length), 72
+}
+
+// SynthesizeAddress returns the IPv4-embedded IPv6 address for the given IPv4
+// address
+func (me NAT64) SynthesizeAddress(addr ipv4.Address) Address {
+	before, rest := me.layout()
+	after := 32 - before
+	v4 := uint64(addr.Uint32())
+	high := Uint128{0, v4 >> after}.leftShift(128 - int(me.prefix.length) - before)
+	low := Uint128{0, v4 & (1<<after - 1)}.leftShift(128 - rest - after)
+	return Address{me.prefix.addr.ui.or(high).or(low)}
+}
+
+// extract returns the IPv4 address embedded in the given address without any
+// validation
+func (me NAT64) extract(addr Address) ipv4.Address {
+	before, rest := me.layout()
+	after := 32 - before
+	high := addr.ui.rightShift(128-int(me.prefix.length)-before).low & (1<<before - 1)
+	low := addr.ui.rightShift(128-rest-after).low & (1<<after - 1)
+	return ipv4.AddressFromUint32(uint32(high<<after | low))
+}
+
+// ExtractAddress returns the IPv4 address embedded in the given
+// IPv4-embedded IPv6 address. The address must be within the NAT64 prefix and
+// its u octet must be zero. The suffix is ignored.
+func (me NAT64) ExtractAddress(addr Address) (ipv4.Address, error) {
+	if !me.prefix.containsAddress(addr) {
+		return ipv4.Address{}, fmt.Errorf("address %s is not in the NAT64 prefix %s", addr, me.prefix)
+	}
+	if uOctet(addr) != 0 {
+		return ipv4.Address{}, fmt.Errorf("bits 64 to 71 of a NAT64 address must be zero: %s", addr)
+	}
+	return me.extract(addr), nil
+}
+
+// SynthesizePrefix returns the IPv6 prefix containing the IPv4-embedded IPv6
+// addresses for all of the addresses in the given IPv4 prefix. The result
+// ends right after the last bit of the IPv4 prefix so the suffix, and the u
+// octet if the IPv4 prefix ends before it, are not constrained. That way, the
+// result is always a prefix. Any host bits in the IPv4 prefix are kept.
+func (me NAT64) SynthesizePrefix(prefix ipv4.Prefix) Prefix {
+	before, rest := me.layout()
+	length := int(me.prefix.length) + prefix.Length()
+	if prefix.Length() > before {
+		length = rest + prefix.Length() - before
+	}
+	return Prefix{
+		me.SynthesizeAddress(prefix.Address()),
+		uint32(length),
+	}
+}
+
+// extractPrefix returns the IPv4 prefix represented by the given prefix which
+// must be within the NAT64 prefix. If the prefix doesn't contain any
+// IPv4-embedded addresses because bits in the u octet or suffix are set, ok is
+// false.
+func (me NAT64) extractPrefix(prefix Prefix) (result ipv4.Prefix, ok bool) {
+	before, rest := me.layout()
+	length := int(prefix.length)
+	network := prefix.Network()
+
+	var v4Length int
+	switch {
+	case length <= int(me.prefix.length)+before:
+		v4Length = length - int(me.prefix.length)
+	case length <= rest:
+		v4Length = before
+	default:
+		v4Length = intMin(32, before+length-rest)
+	}
+
+	// Any bits set after the IPv4 address must be in the u octet or suffix
+	synthesized := me.SynthesizeAddress(me.extract(network.addr))
+	if synthesized.ui.and(network.Mask().ui) != network.addr.ui {
+		return ipv4.Prefix{}, false
+	}
+	return ipv4PrefixFromAddressLength(me.extract(prefix.addr), v4Length), true
+}
+
+// ExtractPrefix returns the IPv4 prefix represented by the given IPv6 prefix.
+// It is the inverse of SynthesizePrefix. The prefix must be within the NAT64
+// prefix. It is an error if it fixes any bits in the u octet or suffix to
+// something other than zero because then it wouldn't contain any
+// IPv4-embedded addresses.
+func (me NAT64) ExtractPrefix(prefix Prefix) (ipv4.Prefix, error) {
+	if prefix.length < me.prefix.length || !me.prefix.containsAddress(prefix.addr) {
+		return ipv4.Prefix{}, fmt.Errorf("prefix %s is not in the NAT64 prefix %s", prefix, me.prefix)
+	}
+	result, ok := me.extractPrefix(prefix)
+	if !ok {
+		return ipv4.Prefix{}, fmt.Errorf("prefix %s does not contain any IPv4-embedded addresses", prefix)
+	}
+	return result, nil
+}
+
+// SynthesizeSet returns the set of IPv6 addresses made by calling
+// SynthesizePrefix on each prefix in the given IPv4 set
+func (me NAT64) SynthesizeSet(set ipv4.Set) Set {
+	s := NewSet_()
+	set.WalkPrefixes(func(prefix ipv4.Prefix) bool {
+		s.Insert(me.SynthesizePrefix(prefix))
+		return true
+	})
+	return s.Set()
+}
+
+// ExtractSet returns the set of IPv4 addresses embedded in the given IPv6
+// set. Any addresses outside of the NAT64 prefix or which aren't valid
+// IPv4-embedded addresses are ignored.
+func (me NAT64) ExtractSet(set Set) ipv4.Set {
+	s := ipv4.NewSet_()
+	set.Intersection(me.prefix).WalkPrefixes(func(prefix Prefix) bool {
+		if p, ok := me.extractPrefix(prefix); ok {
+			s.Insert(p)
+		}
+		return true
+	})
+	return s.Set()
+}
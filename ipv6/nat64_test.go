@@ -0,0 +1,199 @@
+package ipv6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/addrs.v1/ipv4"
+)
+
+func _nat64(cidr string) NAT64 {
+	nat64, _ := NAT64FromPrefix(_p(cidr))
+	return nat64
+}
+
+func TestNAT64FromPrefix(t *testing.T) {
+	tests := []struct {
+		prefix Prefix
+		valid  bool
+	}{
+		{_p("2001:db8::/32"), true},
+		{_p("2001:db8:100::/40"), true},
+		{_p("2001:db8:122::/48"), true},
+		{_p("2001:db8:122:300::/56"), true},
+		{_p("2001:db8:122:344::/64"), true},
+		{_p("2001:db8:122:344::/96"), true},
+		{_p("2001:db8::/31"), false},
+		{_p("2001:db8::/33"), false},
+		{_p("2001:db8::/128"), false},
+		{_p("2001:db8:122:344:100::/96"), false},
+		{_p("2001:db8:122:344:ff00::/96"), false},
+		{_p("2001:db8:122:344:ff:ffff::/96"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			nat64, err := NAT64FromPrefix(tt.prefix)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.prefix, nat64.Prefix())
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+
+	t.Run("host bits", func(t *testing.T) {
+		nat64, err := NAT64FromPrefix(_p("2001:db8::1/32"))
+		assert.Nil(t, err)
+		assert.Equal(t, _p("2001:db8::/32"), nat64.Prefix())
+	})
+
+	t.Run("well known", func(t *testing.T) {
+		assert.Equal(t, _p("64:ff9b::/96"), WellKnownNAT64().Prefix())
+		assert.Equal(t, "64:ff9b::/96", WellKnownNAT64().String())
+	})
+}
+
+func TestNAT64Address(t *testing.T) {
+	// These are the examples from RFC 6052 section 2.4
+	tests := []struct {
+		nat64    NAT64
+		embedded Address
+	}{
+		{_nat64("2001:db8::/32"), _a("2001:db8:c000:221::")},
+		{_nat64("2001:db8:100::/40"), _a("2001:db8:1c0:2:21::")},
+		{_nat64("2001:db8:122::/48"), _a("2001:db8:122:c000:2:2100::")},
+		{_nat64("2001:db8:122:300::/56"), _a("2001:db8:122:3c0:0:221::")},
+		{_nat64("2001:db8:122:344::/64"), _a("2001:db8:122:344:c0:2:2100:0")},
+		{_nat64("2001:db8:122:344::/96"), _a("2001:db8:122:344::c000:221")},
+		{WellKnownNAT64(), _a("64:ff9b::c000:221")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nat64.String(), func(t *testing.T) {
+			assert.Equal(t, tt.embedded, tt.nat64.SynthesizeAddress(_a4("192.0.2.33")))
+
+			addr, err := tt.nat64.ExtractAddress(tt.embedded)
+			assert.Nil(t, err)
+			assert.Equal(t, _a4("192.0.2.33"), addr)
+
+			for _, v4 := range []string{"0.0.0.0", "255.255.255.255", "128.0.0.1", "1.2.3.4"} {
+				embedded := tt.nat64.SynthesizeAddress(_a4(v4))
+				assert.True(t, tt.nat64.Prefix().containsAddress(embedded))
+				assert.Equal(t, byte(0), uOctet(embedded))
+
+				addr, err := tt.nat64.ExtractAddress(embedded)
+				assert.Nil(t, err)
+				assert.Equal(t, _a4(v4), addr)
+			}
+		})
+	}
+
+	t.Run("suffix ignored", func(t *testing.T) {
+		addr, err := _nat64("2001:db8::/32").ExtractAddress(_a("2001:db8:c000:221::1"))
+		assert.Nil(t, err)
+		assert.Equal(t, _a4("192.0.2.33"), addr)
+	})
+
+	t.Run("outside prefix", func(t *testing.T) {
+		_, err := _nat64("2001:db8::/32").ExtractAddress(_a("2001:db9:c000:221::"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("u octet set", func(t *testing.T) {
+		_, err := _nat64("2001:db8::/32").ExtractAddress(_a("2001:db8:c000:221:100::"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNAT64Prefix(t *testing.T) {
+	tests := []struct {
+		nat64 NAT64
+		v4    ipv4.Prefix
+		v6    Prefix
+	}{
+		{_nat64("2001:db8::/32"), _p4("0.0.0.0/0"), _p("2001:db8::/32")},
+		{_nat64("2001:db8::/32"), _p4("192.0.2.0/24"), _p("2001:db8:c000:200::/56")},
+		{_nat64("2001:db8::/32"), _p4("192.0.2.33/32"), _p("2001:db8:c000:221::/64")},
+		{_nat64("2001:db8:100::/40"), _p4("192.0.2.0/24"), _p("2001:db8:1c0:2::/64")},
+		{_nat64("2001:db8:100::/40"), _p4("192.0.2.32/27"), _p("2001:db8:1c0:2:20::/75")},
+		{_nat64("2001:db8:122:344::/64"), _p4("0.0.0.0/0"), _p("2001:db8:122:344::/64")},
+		{_nat64("2001:db8:122:344::/64"), _p4("192.0.0.0/8"), _p("2001:db8:122:344:c0::/80")},
+		{_nat64("2001:db8:122:344::/64"), _p4("192.0.2.33/32"), _p("2001:db8:122:344:c0:2:2100:0/104")},
+		{_nat64("2001:db8:122:344::/96"), _p4("192.0.2.0/24"), _p("2001:db8:122:344::c000:200/120")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.v4.String()+" in "+tt.nat64.String(), func(t *testing.T) {
+			assert.Equal(t, tt.v6, tt.nat64.SynthesizePrefix(tt.v4))
+
+			v4, err := tt.nat64.ExtractPrefix(tt.v6)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.v4, v4)
+		})
+	}
+
+	t.Run("within u octet", func(t *testing.T) {
+		v4, err := _nat64("2001:db8::/32").ExtractPrefix(_p("2001:db8:c000:221::/68"))
+		assert.Nil(t, err)
+		assert.Equal(t, _p4("192.0.2.33/32"), v4)
+	})
+
+	t.Run("u octet set", func(t *testing.T) {
+		_, err := _nat64("2001:db8::/32").ExtractPrefix(_p("2001:db8:c000:221:100::/72"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("suffix set", func(t *testing.T) {
+		_, err := _nat64("2001:db8::/32").ExtractPrefix(_p("2001:db8:c000:221:1::/80"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("outside prefix", func(t *testing.T) {
+		_, err := _nat64("2001:db8::/32").ExtractPrefix(_p("2001:db8::/31"))
+		assert.NotNil(t, err)
+		_, err = _nat64("2001:db8::/32").ExtractPrefix(_p("2001:db9::/48"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNAT64Set(t *testing.T) {
+	s := ipv4.NewSet_()
+	s.Insert(_p4("10.0.0.0/8"))
+	s.Insert(_p4("192.0.2.0/24"))
+	s.Insert(_a4("203.0.113.17"))
+	v4 := s.Set()
+
+	for _, nat64 := range []NAT64{
+		_nat64("2001:db8::/32"),
+		_nat64("2001:db8:100::/40"),
+		_nat64("2001:db8:122::/48"),
+		_nat64("2001:db8:122:300::/56"),
+		_nat64("2001:db8:122:344::/64"),
+		WellKnownNAT64(),
+	} {
+		t.Run(nat64.String(), func(t *testing.T) {
+			v6 := nat64.SynthesizeSet(v4)
+			assert.True(t, v6.Contains(nat64.SynthesizeAddress(_a4("10.1.2.3"))))
+			assert.True(t, v6.Contains(nat64.SynthesizeAddress(_a4("192.0.2.33"))))
+			assert.True(t, v6.Contains(nat64.SynthesizeAddress(_a4("203.0.113.17"))))
+			assert.False(t, v6.Contains(nat64.SynthesizeAddress(_a4("203.0.113.18"))))
+			assert.True(t, v4.Equal(nat64.ExtractSet(v6)))
+		})
+	}
+
+	t.Run("extract ignores others", func(t *testing.T) {
+		nat64 := _nat64("2001:db8::/32")
+		s := NewSet_()
+		s.Insert(_p("2001:db8:c000:221:100::/72"))
+		s.Insert(_p("2001:db8:c000:222:0:1::/96"))
+		s.Insert(_p("2001:db9::/32"))
+		s.Insert(_p("2001:db8:a01:203::/64"))
+		assert.True(t, ipv4.Set{}.Union(_a4("10.1.2.3")).Equal(nat64.ExtractSet(s.Set())))
+
+		s = NewSet_()
+		s.Insert(_p("::/0"))
+		assert.True(t, ipv4.Set{}.Union(_p4("0.0.0.0/0")).Equal(nat64.ExtractSet(s.Set())))
+	})
+}
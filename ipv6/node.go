@@ -2,11 +2,13 @@ package ipv6
 
 import (
 	"fmt"
+	"math/big"
 )
 
 type trieNode struct {
 	Prefix   Prefix
 	Data     interface{}
+	numAddrs Uint128 // zero means the entire address space; a node is never empty
 	size     uint32
 	h        uint16
 	isActive bool
@@ -48,7 +50,7 @@ func intMax(a, b int) int {
 // | true    | false | 1     | `longer` belongs in `shorter`'s `children[1]`
 // | true    | true  | NA    | `shorter` and `longer` are the same key
 func contains(shorter, longer Prefix) (matches, exact bool, common uint32, child int) {
-	mask := uint128{0xffffffffffffffff, 0xffffffffffffffff}.leftShift(int(128 - shorter.length))
+	mask := Uint128{0xffffffffffffffff, 0xffffffffffffffff}.leftShift(int(128 - shorter.length))
 
 	matches = shorter.addr.ui.and(mask) == longer.addr.ui.and(mask)
 	if matches {
@@ -59,8 +61,8 @@ func contains(shorter, longer Prefix) (matches, exact bool, common uint32, child
 	}
 	if !exact {
 		// Whether `longer` goes on the left (0) or right (1)
-		pivotMask := uint128{0x8000000000000000, 0}.rightShift(int(common))
-		if (longer.addr.ui.and(pivotMask) != uint128{}) {
+		pivotMask := Uint128{0x8000000000000000, 0}.rightShift(int(common))
+		if (longer.addr.ui.and(pivotMask) != Uint128{}) {
 			child = 1
 		}
 	}
@@ -109,8 +111,13 @@ func (me *trieNode) mutate(mutator func(*trieNode)) *trieNode {
 
 	me.size = uint32(numNodes)
 	me.h = uint16(height)
+	// The arithmetic wraps so the entire address space comes out as zero
+	left, _ := me.children[0].numAddresses()
+	right, _ := me.children[1].numAddresses()
+	me.numAddrs, _ = left.add(right)
 	if me.isActive {
 		me.size++
+		me.numAddrs = me.Prefix.numAddresses()
 	}
 	return me
 }
@@ -239,6 +246,30 @@ func (me *trieNode) Match(searchKey Prefix) *trieNode {
 	return me
 }
 
+// NumAddresses returns the number of addresses that could match this node.
+func (me *trieNode) NumAddresses() *big.Int {
+	count, all := me.numAddresses()
+	if all {
+		return allAddresses()
+	}
+	return count.Big()
+}
+
+// numAddresses returns the number of addresses that could match this node. If
+// they make up the entire address space, the count doesn't fit in a Uint128
+// and all is true instead. It takes constant time.
+func (me *trieNode) numAddresses() (count Uint128, all bool) {
+	if me == nil {
+		return Uint128{}, false
+	}
+	return me.numAddrs, me.numAddrs.IsZero()
+}
+
+// allAddresses returns the number of addresses in the entire address space
+func allAddresses() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(addressSize))
+}
+
 // IsEmpty returns whether the number of IP addresses is equal to zero
 func (me *trieNode) IsEmpty() bool {
 	if me == nil {
@@ -292,6 +323,15 @@ func (me *trieNode) isValidLen(minLen uint32) bool {
 	if me.h != 1+uint16(uint16(intMax(left.height(), right.height()))) {
 		return false
 	}
+	leftAddrs, _ := left.numAddresses()
+	rightAddrs, _ := right.numAddresses()
+	numAddrs, _ := leftAddrs.add(rightAddrs)
+	if me.isActive {
+		numAddrs = me.Prefix.numAddresses()
+	}
+	if me.numAddrs != numAddrs {
+		return false
+	}
 	if me.Prefix.length < minLen {
 		return false
 	}
@@ -448,7 +488,7 @@ func (me *trieNode) insert(node *trieNode, opts insertOpts) (newHead *trieNode,
 		newNode := &trieNode{
 			Prefix: Prefix{
 				addr: Address{
-					ui: me.Prefix.addr.ui.and(uint128{0xffffffffffffffff, 0xffffffffffffffff}.rightShift(int(common)).complement()), // zero out bits not in common
+					ui: me.Prefix.addr.ui.and(Uint128{0xffffffffffffffff, 0xffffffffffffffff}.rightShift(int(common)).complement()), // zero out bits not in common
 				},
 				length: common,
 			},
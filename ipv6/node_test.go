@@ -53,8 +53,8 @@ func TestStructSizes(t *testing.T) {
 	)
 	assert.Equal(t,
 		intMin(
-			64,
-			keySize+6*nodeAlign,
+			80,
+			keySize+10*nodeAlign,
 		),
 		nodeSize,
 	)
@@ -90,7 +90,7 @@ func TestInsertOrUpdateNewKey(t *testing.T) {
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0, 0}}, 1}
 	trie = trie.InsertOrUpdate(newKey, false, ieq)
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
@@ -100,7 +100,7 @@ func TestInsertOrUpdateNewKey(t *testing.T) {
 func TestInsertOrUpdateNarrowerKey(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie = trie.InsertOrUpdate(key, true, ieq)
 	assert.True(t, trie.isValid())
@@ -116,13 +116,13 @@ func TestInsertOrUpdateNarrowerKey(t *testing.T) {
 func TestInsertOrUpdateDisjointKeys(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie = trie.InsertOrUpdate(key, true, ieq)
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0x8000000000000000, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0x8000000000000000, 0}}, 1}
 	trie = trie.InsertOrUpdate(newKey, false, ieq)
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
@@ -132,13 +132,13 @@ func TestInsertOrUpdateDisjointKeys(t *testing.T) {
 func TestInsertOrUpdateInactive(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie = trie.InsertOrUpdate(key, true, ieq)
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0x8000000000000000, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0x8000000000000000, 0}}, 1}
 	trie = trie.InsertOrUpdate(newKey, false, ieq)
 	assert.True(t, trie.isValid())
 	assert.True(t, trie.Match(key).Data.(bool))
@@ -178,7 +178,7 @@ func TestUpdateNewKey(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0, 0}}, 1}
 	trie, err = trie.Update(newKey, false, ieq)
 	assert.True(t, trie.isValid())
 	assert.NotNil(t, err)
@@ -189,7 +189,7 @@ func TestUpdateNewKey(t *testing.T) {
 func TestUpdateNarrowerKey(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie, err := trie.Insert(key, true)
 	assert.True(t, trie.isValid())
@@ -207,14 +207,14 @@ func TestUpdateNarrowerKey(t *testing.T) {
 func TestUpdateDisjointKeys(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie, err := trie.Insert(key, true)
 	assert.True(t, trie.isValid())
 	assert.Nil(t, err)
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0x8000000000000000, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0x8000000000000000, 0}}, 1}
 	trie, err = trie.Update(newKey, false, ieq)
 	assert.True(t, trie.isValid())
 	assert.NotNil(t, err)
@@ -225,14 +225,14 @@ func TestUpdateDisjointKeys(t *testing.T) {
 func TestUpdateInactive(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 1}
+	key := Prefix{Address{Uint128{0, 0}}, 1}
 
 	trie, err := trie.Insert(key, true)
 	assert.True(t, trie.isValid())
 	assert.Nil(t, err)
 	assert.True(t, trie.Match(key).Data.(bool))
 
-	newKey := Prefix{Address{uint128{0x8000000000000000, 0}}, 1}
+	newKey := Prefix{Address{Uint128{0x8000000000000000, 0}}, 1}
 	trie, err = trie.Insert(newKey, false)
 	assert.True(t, trie.isValid())
 	assert.Nil(t, err)
@@ -259,7 +259,7 @@ func TestMatchZeroLength(t *testing.T) {
 	var trie *trieNode
 
 	trie, err := trie.Insert(Prefix{
-		Address{uint128{0, 0}},
+		Address{Uint128{0, 0}},
 		0,
 	}, nil)
 	assert.Nil(t, err)
@@ -278,7 +278,7 @@ func TestGetOrInsertTrivial(t *testing.T) {
 	assert.Equal(t, int64(0), trie.NumNodes())
 	assert.True(t, trie.isValid())
 
-	key := Prefix{Address{uint128{0, 0}}, 0}
+	key := Prefix{Address{Uint128{0, 0}}, 0}
 
 	trie, node := trie.GetOrInsert(key, true)
 	assert.True(t, trie.isValid())
@@ -289,7 +289,7 @@ func TestGetOrInsertTrivial(t *testing.T) {
 func TestGetOrInsertExists(t *testing.T) {
 	var trie *trieNode
 
-	key := Prefix{Address{uint128{0, 0}}, 0}
+	key := Prefix{Address{Uint128{0, 0}}, 0}
 
 	trie, err := trie.Insert(key, true)
 	assert.Nil(t, err)
@@ -495,7 +495,7 @@ func TestMatchSimplePrefixMatch(t *testing.T) {
 		},
 		{
 			desc:        "partial byte",
-			nodeAddress: Address{uint128{0xfe00000000000000, 0}},
+			nodeAddress: Address{Uint128{0xfe00000000000000, 0}},
 			nodeLength:  15,
 		},
 	}
@@ -521,39 +521,39 @@ func TestMatchSimplePrefixMatch(t *testing.T) {
 
 func TestMatchPartialByteMatches(t *testing.T) {
 	tests := []struct {
-		nodeAddress uint128
+		nodeAddress Uint128
 		nodeLength  uint32
 	}{
 		{
-			nodeAddress: uint128{0x8000000000000000, 0},
+			nodeAddress: Uint128{0x8000000000000000, 0},
 			nodeLength:  1,
 		},
 		{
-			nodeAddress: uint128{0xc000000000000000, 0},
+			nodeAddress: Uint128{0xc000000000000000, 0},
 			nodeLength:  2,
 		},
 		{
-			nodeAddress: uint128{0xe000000000000000, 0},
+			nodeAddress: Uint128{0xe000000000000000, 0},
 			nodeLength:  3,
 		},
 		{
-			nodeAddress: uint128{0xf000000000000000, 0},
+			nodeAddress: Uint128{0xf000000000000000, 0},
 			nodeLength:  4,
 		},
 		{
-			nodeAddress: uint128{0xf800000000000000, 0},
+			nodeAddress: Uint128{0xf800000000000000, 0},
 			nodeLength:  5,
 		},
 		{
-			nodeAddress: uint128{0xfc00000000000000, 0},
+			nodeAddress: Uint128{0xfc00000000000000, 0},
 			nodeLength:  6,
 		},
 		{
-			nodeAddress: uint128{0xfe00000000000000, 0},
+			nodeAddress: Uint128{0xfe00000000000000, 0},
 			nodeLength:  7,
 		},
 		{
-			nodeAddress: uint128{0xff00000000000000, 0},
+			nodeAddress: Uint128{0xff00000000000000, 0},
 			nodeLength:  8,
 		},
 	}
@@ -575,12 +575,12 @@ func TestMatchPartialByteMatches(t *testing.T) {
 			assert := assert.New(t)
 			assert.Equal(trie, trie.Match(Prefix{
 				// Always use 0xff to ensure that extraneous bits in the data are ignored
-				Address{uint128{0xff00000000000000, 0}},
+				Address{Uint128{0xff00000000000000, 0}},
 				tt.nodeLength,
 			}))
 
 			// byte with 0 in the last bit to match based on nodeLength
-			var mismatch uint128 = uint128{0xff00000000000000, 0}.and(uint128{0x8000000000000000, 0}.rightShift(int(tt.nodeLength - 1)).complement())
+			var mismatch Uint128 = Uint128{0xff00000000000000, 0}.and(Uint128{0x8000000000000000, 0}.rightShift(int(tt.nodeLength - 1)).complement())
 
 			assert.Nil(trie.Match(Prefix{
 				// Always use a byte with a 0 is the last matched bit
@@ -605,12 +605,12 @@ func TestInsertOverlapping(t *testing.T) {
 		{
 			desc: "34 and 60",
 			a:    Prefix{_a("10:200::"), 34},
-			b:    Prefix{Address{uint128{0x0ac800e000000000, 0}}, 60},
-			c:    Prefix{Address{uint128{0x0ac800f800000000, 0}}, 127},
+			b:    Prefix{Address{Uint128{0x0ac800e000000000, 0}}, 60},
+			c:    Prefix{Address{Uint128{0x0ac800f800000000, 0}}, 127},
 		},
 		{
 			desc: "0 and 32",
-			a:    Prefix{Address{uint128{0, 0}}, 0},
+			a:    Prefix{Address{Uint128{0, 0}}, 0},
 			b:    Prefix{_a("10::"), 16},
 			c:    Prefix{_a("10:10::"), 32},
 		},
@@ -679,9 +679,9 @@ func TestInsertDisjoint(t *testing.T) {
 	}{
 		{
 			desc:  "first bit",
-			a:     Prefix{Address{uint128{0, 0}}, 1},
+			a:     Prefix{Address{Uint128{0, 0}}, 1},
 			b:     Prefix{_a("8000::"), 1},
-			super: Prefix{Address{uint128{0, 0}}, 0},
+			super: Prefix{Address{Uint128{0, 0}}, 0},
 		},
 		{
 			desc:  "thirty-third bit",
@@ -746,21 +746,21 @@ func TestInsertMoreComplex(t *testing.T) {
 		{
 			desc: "mix disjoint and overlapping",
 			keys: []Prefix{
-				Prefix{Address{uint128{0, 0}}, 0},
-				Prefix{Address{uint128{0xff00000000000000, 0}}, 8},
-				Prefix{Address{uint128{0xfe00000000000000, 0}}, 8},
-				Prefix{Address{uint128{0xffff000000000000, 0}}, 16},
-				Prefix{Address{uint128{0xfffe000000000000, 0}}, 16},
-				Prefix{Address{uint128{0xffff000000000000, 0}}, 17},
-				Prefix{Address{uint128{0xfffe800000000000, 0}}, 17},
-				Prefix{Address{uint128{0xfffe800000000000, 0}}, 18},
-				Prefix{Address{uint128{0xffffb00000000000, 0}}, 18},
-				Prefix{Address{uint128{0xfffebf0000000000, 0}}, 24},
-				Prefix{Address{uint128{0xffffbe0000000000, 0}}, 24},
-				Prefix{Address{uint128{0xfffffffffffffebf, 0}}, 64},
-				Prefix{Address{uint128{0xffffffffffffffbe, 0}}, 64},
-				Prefix{Address{uint128{0xffffffffffffffff, 0xffffffff00000000}}, 96},
-				Prefix{Address{uint128{0xffffffffffffffff, 0xfffffffe00000000}}, 96},
+				Prefix{Address{Uint128{0, 0}}, 0},
+				Prefix{Address{Uint128{0xff00000000000000, 0}}, 8},
+				Prefix{Address{Uint128{0xfe00000000000000, 0}}, 8},
+				Prefix{Address{Uint128{0xffff000000000000, 0}}, 16},
+				Prefix{Address{Uint128{0xfffe000000000000, 0}}, 16},
+				Prefix{Address{Uint128{0xffff000000000000, 0}}, 17},
+				Prefix{Address{Uint128{0xfffe800000000000, 0}}, 17},
+				Prefix{Address{Uint128{0xfffe800000000000, 0}}, 18},
+				Prefix{Address{Uint128{0xffffb00000000000, 0}}, 18},
+				Prefix{Address{Uint128{0xfffebf0000000000, 0}}, 24},
+				Prefix{Address{Uint128{0xffffbe0000000000, 0}}, 24},
+				Prefix{Address{Uint128{0xfffffffffffffebf, 0}}, 64},
+				Prefix{Address{Uint128{0xffffffffffffffbe, 0}}, 64},
+				Prefix{Address{Uint128{0xffffffffffffffff, 0xffffffff00000000}}, 96},
+				Prefix{Address{Uint128{0xffffffffffffffff, 0xfffffffe00000000}}, 96},
 			},
 		},
 	}
@@ -803,8 +803,8 @@ func TestContains(t *testing.T) {
 	}{
 		{
 			desc:    "trivial",
-			a:       Prefix{Address{uint128{}}, 0},
-			b:       Prefix{Address{uint128{}}, 0},
+			a:       Prefix{Address{Uint128{}}, 0},
+			b:       Prefix{Address{Uint128{}}, 0},
 			matches: true,
 			exact:   true,
 			common:  0,
@@ -827,7 +827,7 @@ func TestContains(t *testing.T) {
 		},
 		{
 			desc:    "empty prefix match",
-			a:       Prefix{Address{uint128{}}, 0},
+			a:       Prefix{Address{Uint128{}}, 0},
 			b:       Prefix{_a("2001:10::"), 32},
 			matches: true,
 			exact:   false,
@@ -836,7 +836,7 @@ func TestContains(t *testing.T) {
 		},
 		{
 			desc:    "empty prefix match backwards",
-			a:       Prefix{Address{uint128{}}, 0},
+			a:       Prefix{Address{Uint128{}}, 0},
 			b:       Prefix{_a("F030:10::"), 32},
 			matches: true,
 			exact:   false,
@@ -881,7 +881,7 @@ func TestContains(t *testing.T) {
 		},
 		{
 			desc:    "disjoint",
-			a:       Prefix{Address{uint128{}}, 1},
+			a:       Prefix{Address{Uint128{}}, 1},
 			b:       Prefix{_a("8000::"), 1},
 			matches: false,
 			common:  0,
@@ -906,7 +906,7 @@ func TestContains(t *testing.T) {
 		{
 			desc:    "disjoint backwards",
 			a:       Prefix{_a("8000::"), 1},
-			b:       Prefix{Address{uint128{}}, 1},
+			b:       Prefix{Address{Uint128{}}, 1},
 			matches: false,
 			common:  0,
 			child:   0,
@@ -1566,8 +1566,8 @@ func TestTrieNodeEqual(t *testing.T) {
 		},
 		{
 			desc:  "inactive different prefixes",
-			a:     &trieNode{isActive: false, Prefix: Prefix{Address{uint128{}}, 128}},
-			b:     &trieNode{isActive: false, Prefix: Prefix{Address{uint128{0, 1}}, 128}},
+			a:     &trieNode{isActive: false, Prefix: Prefix{Address{Uint128{}}, 128}},
+			b:     &trieNode{isActive: false, Prefix: Prefix{Address{Uint128{0, 1}}, 128}},
 			equal: false,
 		},
 		{
@@ -0,0 +1,255 @@
+package ipv6
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is returned when a string cannot be parsed as an address or
+// prefix. It records where in the input the problem was found.
+type ParseError struct {
+	// Input is the complete string that was being parsed
+	Input string
+	// Pos is the byte offset in Input where the problem was found
+	Pos int
+	// Msg describes the problem
+	Msg string
+}
+
+// Error implements error
+func (me *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %q: %s at position %d", me.Input, me.Msg, me.Pos)
+}
+
+// parseDottedQuad parses s which must be exactly an IPv4 address in
+// dotted-quad notation as it appears in the last 32 bits of an IPv6 address.
+func parseDottedQuad(s string) (ui uint32, pos int, msg string) {
+	i := 0
+	for octet := 0; octet < 4; octet++ {
+		if octet > 0 {
+			if i == len(s) || s[i] != '.' {
+				return 0, i, "expected '.'"
+			}
+			i++
+		}
+		start := i
+		var val uint32
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			if i > start && s[start] == '0' {
+				return 0, start, "octet has a leading zero"
+			}
+			val = val*10 + uint32(s[i]-'0')
+			if val > 0xff {
+				return 0, start, "octet is greater than 255"
+			}
+		}
+		if i == start {
+			return 0, i, "expected a decimal octet"
+		}
+		ui = ui<<8 | val
+	}
+	if i != len(s) {
+		return 0, i, "unexpected character"
+	}
+	return ui, 0, ""
+}
+
+// hexDigit returns the value of the hexadecimal digit c and whether it is one
+func hexDigit(c byte) (uint16, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint16(c - '0'), true
+	case 'a' <= c && c <= 'f':
+		return uint16(c-'a') + 10, true
+	case 'A' <= c && c <= 'F':
+		return uint16(c-'A') + 10, true
+	}
+	return 0, false
+}
+
+// parseAddress parses s which must be exactly an address in colon notation.
+// The last 32 bits may be written in dotted-quad notation. It does not
+// allocate. On failure, msg describes the problem found at position pos.
+//
+// If strict, only the canonical form described in RFC 5952 is accepted:
+// hexadecimal digits are lowercase, groups have no leading zeros, and "::"
+// replaces the longest run of two or more zero groups (the first one if
+// there is a tie) and nothing else. Dotted-quad notation is required for
+// IPv4-mapped addresses (e.g. ::ffff:203.0.113.17) and not allowed otherwise.
+func parseAddress(s string, strict bool) (addr Address, pos int, msg string) {
+	if strings.IndexByte(s, ':') < 0 {
+		return Address{}, 0, "expected ':'"
+	}
+
+	var groups [8]uint16
+	n := 0                          // the number of groups parsed
+	ellipsis, ellipsisPos := -1, -1 // where "::" was found in groups and s
+	dotted := false
+
+	i := 0
+	if strings.HasPrefix(s, "::") {
+		ellipsis, ellipsisPos = 0, 0
+		i = 2
+	}
+	for i < len(s) {
+		if n == len(groups) {
+			return Address{}, i, "too many groups"
+		}
+		start := i
+		var val uint16
+		for ; i < len(s); i++ {
+			digit, ok := hexDigit(s[i])
+			if !ok {
+				break
+			}
+			if strict && 'A' <= s[i] && s[i] <= 'F' {
+				return Address{}, i, "hexadecimal digit is uppercase"
+			}
+			val = val<<4 | digit
+		}
+		if i < len(s) && s[i] == '.' {
+			if n > len(groups)-2 {
+				return Address{}, start, "too many groups"
+			}
+			ui, pos, msg := parseDottedQuad(s[start:])
+			if msg != "" {
+				return Address{}, start + pos, msg
+			}
+			groups[n], groups[n+1] = uint16(ui>>16), uint16(ui)
+			n += 2
+			dotted = true
+			i = len(s)
+			break
+		}
+		switch {
+		case i == start:
+			return Address{}, i, "expected a hexadecimal group"
+		case i-start > 4:
+			return Address{}, start, "group has more than 4 digits"
+		case strict && i-start > 1 && s[start] == '0':
+			return Address{}, start, "group has a leading zero"
+		}
+		groups[n] = val
+		n++
+
+		if i == len(s) {
+			break
+		}
+		if s[i] != ':' {
+			return Address{}, i, "unexpected character"
+		}
+		i++
+		if i < len(s) && s[i] == ':' {
+			if ellipsis >= 0 {
+				return Address{}, i - 1, "'::' appears more than once"
+			}
+			ellipsis, ellipsisPos = n, i-1
+			i++
+		} else if i == len(s) {
+			return Address{}, i, "expected a hexadecimal group"
+		}
+	}
+
+	if ellipsis < 0 {
+		if n != len(groups) {
+			return Address{}, len(s), "too few groups"
+		}
+	} else {
+		if n == len(groups) {
+			return Address{}, ellipsisPos, "'::' doesn't replace any groups"
+		}
+		elided := len(groups) - n
+		copy(groups[ellipsis+elided:], groups[ellipsis:n])
+		for j := ellipsis; j < ellipsis+elided; j++ {
+			groups[j] = 0
+		}
+	}
+
+	addr = AddressFromUint16(groups[0], groups[1], groups[2], groups[3], groups[4], groups[5], groups[6], groups[7])
+	if strict {
+		if pos, msg := checkCanonical(groups, ellipsis, len(groups)-n, ellipsisPos, dotted); msg != "" {
+			return Address{}, pos, msg
+		}
+	}
+	return addr, 0, ""
+}
+
+// checkCanonical checks the groups parsed by parseAddress against the rules
+// for the canonical form. elided is the number of groups replaced by "::".
+func checkCanonical(groups [8]uint16, ellipsis, elided, ellipsisPos int, dotted bool) (pos int, msg string) {
+	// Find the first, longest run of at least two zero groups
+	runStart, runLength := -1, 1
+	for i := 0; i < len(groups); {
+		if groups[i] != 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(groups) && groups[j] == 0 {
+			j++
+		}
+		if j-i > runLength {
+			runStart, runLength = i, j-i
+		}
+		i = j
+	}
+
+	switch {
+	case runStart < 0 && ellipsis >= 0:
+		return ellipsisPos, "'::' replaces a single zero group"
+	case runStart >= 0 && (ellipsis != runStart || elided != runLength):
+		if ellipsis < 0 {
+			return 0, "'::' must replace the longest run of zero groups"
+		}
+		return ellipsisPos, "'::' must replace the longest run of zero groups"
+	}
+
+	mapped := groups == [8]uint16{0, 0, 0, 0, 0, 0xffff, groups[6], groups[7]}
+	switch {
+	case mapped && !dotted:
+		return 0, "IPv4-mapped address must use dotted-quad notation"
+	case !mapped && dotted:
+		return 0, "dotted-quad notation is only used for IPv4-mapped addresses"
+	}
+	return 0, ""
+}
+
+// parseLength parses the prefix length in s starting at position i through
+// the end of s. If strict, a leading zero is rejected.
+func parseLength(s string, i int, strict bool) (length uint32, pos int, msg string) {
+	start := i
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if strict && i > start && s[start] == '0' {
+			return 0, start, "prefix length has a leading zero"
+		}
+		length = length*10 + uint32(s[i]-'0')
+		if length > uint32(addressSize) {
+			return 0, start, "prefix length is greater than 128"
+		}
+	}
+	if i == start {
+		return 0, i, "expected a decimal prefix length"
+	}
+	if i != len(s) {
+		return 0, i, "unexpected character"
+	}
+	return length, 0, ""
+}
+
+// parsePrefix parses s which must be exactly a prefix in colon CIDR notation.
+// Host bits are allowed in the address. It does not allocate.
+func parsePrefix(s string, strict bool) (prefix Prefix, pos int, msg string) {
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 {
+		return Prefix{}, len(s), "expected '/'"
+	}
+	addr, pos, msg := parseAddress(s[:slash], strict)
+	if msg != "" {
+		return Prefix{}, pos, msg
+	}
+	length, pos, msg := parseLength(s, slash+1, strict)
+	if msg != "" {
+		return Prefix{}, pos, msg
+	}
+	return Prefix{addr, length}, 0, ""
+}
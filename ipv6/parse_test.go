@@ -0,0 +1,133 @@
+package ipv6
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		expected    Address
+		lenient     bool // only the lenient parser accepts it
+		pos         int
+		isErr       bool
+	}{
+		{description: "unspecified", input: "::", expected: AddressFromUint64(0, 0)},
+		{description: "loopback", input: "::1", expected: AddressFromUint64(0, 1)},
+		{description: "trailing ellipsis", input: "2001:db8::", expected: AddressFromUint64(0x20010db800000000, 0)},
+		{description: "full", input: "2001:db8:85a3:1:2:8a2e:370:7334", expected: AddressFromUint64(0x20010db885a30001, 0x00028a2e03707334)},
+		{description: "compressed", input: "2001:db8:85a3::8a2e:370:7334", expected: AddressFromUint64(0x20010db885a30000, 0x8a2e03707334)},
+		{description: "mapped", input: "::ffff:203.0.113.17", expected: AddressFromUint64(0x0, 0xffffcb007111)},
+		{description: "max", input: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", expected: AddressFromUint64(0xffffffffffffffff, 0xffffffffffffffff)},
+		{description: "leading zeros", input: "2001:0db8:85a3:0000:0000:8a2e:0370:7334", expected: AddressFromUint64(0x20010db885a30000, 0x8a2e03707334), lenient: true},
+		{description: "uppercase", input: "2001:DB8::1", expected: AddressFromUint64(0x20010db800000000, 1), lenient: true},
+		{description: "single group ellipsis", input: "2001:db8::1:1:1:1:1", expected: AddressFromUint64(0x20010db800000001, 0x0001000100010001), lenient: true},
+		{description: "not longest run", input: "2001::1:0:0:0:1", expected: AddressFromUint64(0x2001000000000001, 1), lenient: true},
+		{description: "not first longest run", input: "2001:0:0:1:1::1", expected: AddressFromUint64(0x2001000000000001, 0x0001000000000001), lenient: true},
+		{description: "uncompressed run", input: "2001:db8:0:0:1:1:1:1", expected: AddressFromUint64(0x20010db800000000, 0x0001000100010001), lenient: true},
+		{description: "mapped hex", input: "::ffff:cb00:7111", expected: AddressFromUint64(0x0, 0xffffcb007111), lenient: true},
+		{description: "dotted not mapped", input: "64:ff9b::203.0.113.17", expected: AddressFromUint64(0x0064ff9b00000000, 0xcb007111), lenient: true},
+		{description: "empty", input: "", isErr: true, pos: 0},
+		{description: "ipv4", input: "203.0.113.17", isErr: true, pos: 0},
+		{description: "too few groups", input: "2001:db8:1:1:1:1:1", isErr: true, pos: 18},
+		{description: "too many groups", input: "2001:db8:1:1:1:1:1:1:1", isErr: true, pos: 21},
+		{description: "two ellipses", input: "2001::1::1", isErr: true, pos: 7},
+		{description: "empty ellipsis", input: "2001:db8:1:1::1:1:1:1", isErr: true, pos: 12},
+		{description: "long group", input: "2001:db8:12345::1", isErr: true, pos: 9},
+		{description: "bad hex", input: "2001:dg8::1", isErr: true, pos: 6},
+		{description: "leading colon", input: ":1::", isErr: true, pos: 0},
+		{description: "trailing colon", input: "1::1:", isErr: true, pos: 5},
+		{description: "triple colon", input: "1:::1", isErr: true, pos: 3},
+		{description: "bad dotted", input: "::ffff:203.0.113.256", isErr: true, pos: 17},
+		{description: "dotted too late", input: "1:1:1:1:1:1:1:1.2.3.4", isErr: true, pos: 14},
+		{description: "zone", input: "fe80::1%eth0", isErr: true, pos: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			addr, err := AddressFromString(tt.input)
+			strictAddr, strictErr := AddressFromStringStrict(tt.input)
+			if tt.isErr {
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, err, strictErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, addr)
+			if tt.lenient {
+				assert.NotNil(t, strictErr)
+			} else {
+				assert.Nil(t, strictErr)
+				assert.Equal(t, tt.expected, strictAddr)
+			}
+		})
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		expected    Prefix
+		lenient     bool // only the lenient parser accepts it
+		pos         int
+		isErr       bool
+	}{
+		{description: "default", input: "::/0", expected: unsafePrefixFromUint64(0, 0, 0)},
+		{description: "host", input: "2001:db8::1/128", expected: unsafePrefixFromUint64(0x20010db800000000, 1, 128)},
+		{description: "host bits", input: "2001:db8::1/32", expected: unsafePrefixFromUint64(0x20010db800000000, 1, 32)},
+		{description: "leading zero length", input: "2001:db8::/032", expected: unsafePrefixFromUint64(0x20010db800000000, 0, 32), lenient: true},
+		{description: "non-canonical address", input: "2001:0db8::/32", expected: unsafePrefixFromUint64(0x20010db800000000, 0, 32), lenient: true},
+		{description: "no length", input: "2001:db8::", isErr: true, pos: 10},
+		{description: "empty length", input: "2001:db8::/", isErr: true, pos: 11},
+		{description: "length too long", input: "2001:db8::/129", isErr: true, pos: 11},
+		{description: "length garbage", input: "2001:db8::/3x", isErr: true, pos: 12},
+		{description: "ipv4", input: "10.224.24.0/24", isErr: true, pos: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			prefix, err := PrefixFromString(tt.input)
+			strictPrefix, strictErr := PrefixFromStringStrict(tt.input)
+			if tt.isErr {
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, err, strictErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, prefix)
+			if tt.lenient {
+				assert.NotNil(t, strictErr)
+			} else {
+				assert.Nil(t, strictErr)
+				assert.Equal(t, tt.expected, strictPrefix)
+				assert.Equal(t, tt.input, strictPrefix.String())
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := AddressFromString("2001:db8::g")
+	assert.Equal(t, `failed to parse "2001:db8::g": expected a hexadecimal group at position 10`, err.Error())
+}
+
+func TestParseAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = AddressFromString("2001:db8:85a3::8a2e:370:7334")
+		_, _ = AddressFromStringStrict("2001:db8:85a3::8a2e:370:7334")
+		_, _ = PrefixFromString("2001:db8:85a3::/48")
+		_, _ = PrefixFromStringStrict("2001:db8:85a3::/48")
+	})
+	assert.Equal(t, float64(0), allocs)
+}
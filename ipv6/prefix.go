@@ -1,9 +1,12 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"net"
+	"net/netip"
 )
 
 // Prefix represents an IP prefix which is formally an Address plus a Mask. It
@@ -49,6 +52,22 @@ func PrefixFromNetIPNet(net *net.IPNet) (Prefix, error) {
 	}, nil
 }
 
+// PrefixFromNetipPrefix converts the given netip.Prefix to a Prefix. It does not
+// allocate. Its address must be IPv6 as described for AddressFromNetipAddr.
+func PrefixFromNetipPrefix(prefix netip.Prefix) (Prefix, error) {
+	if !prefix.IsValid() {
+		return Prefix{}, fmt.Errorf("failed to convert invalid netip.Prefix")
+	}
+	addr, err := AddressFromNetipAddr(prefix.Addr())
+	if err != nil {
+		return Prefix{}, err
+	}
+	return Prefix{
+		addr:   addr,
+		length: uint32(prefix.Bits()),
+	}, nil
+}
+
 // PrefixFromAddressMask combines the address and mask into a prefix
 func PrefixFromAddressMask(address Address, mask Mask) Prefix {
 	return Prefix{
@@ -57,28 +76,30 @@ func PrefixFromAddressMask(address Address, mask Mask) Prefix {
 	}
 }
 
-// parseNet returns a single *net.IPNet that unifies the IP address and
-// the mask. It leaves out the network address which net.ParseCIDR returns.
-// This may be considered an abuse of the IPNet construct as it is documented
-// that IP is supposed to be the "network number". However, the public IPNet
-// interface does not dissallow it and this usage has been spotted in the wild.
-func parseNet(prefix string) (*net.IPNet, error) {
-	ip, ipNet, err := net.ParseCIDR(prefix)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse prefix: %s", prefix)
-	}
-	return &net.IPNet{IP: ip, Mask: ipNet.Mask}, nil
-}
-
 // PrefixFromString returns the net represented by `addr` in ipv6 hex colon CIDR
 // notation. If the prefix cannot be parsed, then error is non-nil and the
 // prefix returned must be ignored.
+//
+// It does not allocate unless it fails. Host bits are allowed in the address
+// and are kept in the result. Errors that occur while parsing are of type
+// *ParseError.
 func PrefixFromString(prefix string) (Prefix, error) {
-	ipNet, err := parseNet(prefix)
-	if err != nil {
-		return Prefix{}, err
+	p, pos, msg := parsePrefix(prefix, false)
+	if msg != "" {
+		return Prefix{}, &ParseError{Input: prefix, Pos: pos, Msg: msg}
+	}
+	return p, nil
+}
+
+// PrefixFromStringStrict is like PrefixFromString but the address must be in
+// the canonical form accepted by AddressFromStringStrict and the length must
+// not have leading zeros. Host bits are still allowed.
+func PrefixFromStringStrict(prefix string) (Prefix, error) {
+	p, pos, msg := parsePrefix(prefix, true)
+	if msg != "" {
+		return Prefix{}, &ParseError{Input: prefix, Pos: pos, Msg: msg}
 	}
-	return PrefixFromNetIPNet(ipNet)
+	return p, nil
 }
 
 // Address returns the address part of the Prefix, including host bits
@@ -91,6 +112,13 @@ func (me Prefix) Prefix() Prefix {
 	return me
 }
 
+// ToNetipPrefix returns the netip.Prefix representation of this prefix. Like
+// Prefix, it keeps any bits set in the host part of the address. It does not
+// allocate.
+func (me Prefix) ToNetipPrefix() netip.Prefix {
+	return netip.PrefixFrom(me.addr.ToNetipAddr(), me.Length())
+}
+
 // ToNetIPNet returns a *net.IPNet representation of this prefix
 func (me Prefix) ToNetIPNet() *net.IPNet {
 	return &net.IPNet{
@@ -117,6 +145,20 @@ func (me Prefix) Length() int {
 	return int(me.length)
 }
 
+// NumAddresses returns the number of addresses in the prefix, including the
+// first and last addresses. It ignores any bits set in the host part of the
+// address. It is returned as a *big.Int because ::/0 has 2^128 addresses.
+func (me Prefix) NumAddresses() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(addressSize)-uint(me.length))
+}
+
+// numAddresses returns the number of addresses in the prefix modulo 2^128 so
+// ::/0 comes out as zero. An invalid length longer than 128 counts as one
+// address.
+func (me Prefix) numAddresses() Uint128 {
+	return Uint128{0, 1}.leftShift(intMax(0, addressSize-int(me.length)))
+}
+
 // NumPrefixes returns the number of prefixes of the given length contained in
 // this prefix.
 func (me Prefix) NumPrefixes(length uint32) (count uint64, err error) {
@@ -179,6 +221,34 @@ func (me Prefix) String() string {
 	return fmt.Sprintf("%s/%d", me.addr.String(), me.Length())
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Prefix) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using PrefixFromString
+func (me *Prefix) UnmarshalText(text []byte) error {
+	prefix, err := PrefixFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = prefix
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The prefix is encoded as a JSON
+// string.
+func (me Prefix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the prefix
+// unchanged.
+func (me *Prefix) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
 // Uint64 returns the address and mask as uint64s
 func (me Prefix) Uint64() (addressHigh, addressLow, maskHigh, maskLow uint64) {
 	addressHigh, addressLow = me.addr.Uint64()
@@ -218,7 +288,7 @@ func (me Prefix) Halves() (a, b Prefix) {
 			me.length + 1,
 		}
 		b = Prefix{
-			Address{base.ui.or(uint128{0x8000000000000000, 0}.rightShift(int(me.length)))},
+			Address{base.ui.or(Uint128{0x8000000000000000, 0}.rightShift(int(me.length)))},
 			me.length + 1,
 		}
 	}
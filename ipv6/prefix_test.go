@@ -1,8 +1,10 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -22,15 +24,7 @@ func unsafePrefixFromUint64(high, low uint64, length int) Prefix {
 	if err != nil {
 		panic("only use this in happy cases")
 	}
-	return PrefixFromAddressMask(Address{uint128{high, low}}, mask)
-}
-
-func unsafeParseNet(prefix string) *net.IPNet {
-	ipNet, err := parseNet(prefix)
-	if err != nil {
-		panic("only use this in happy cases")
-	}
-	return ipNet
+	return PrefixFromAddressMask(Address{Uint128{high, low}}, mask)
 }
 
 func TestPrefixComparable(t *testing.T) {
@@ -130,19 +124,19 @@ func TestPrefixFromNetIPNet(t *testing.T) {
 		},
 		{
 			description: "ipv4",
-			net:         unsafeParseNet("10.224.24.1/22"),
+			net:         &net.IPNet{IP: net.ParseIP("10.224.24.1"), Mask: net.CIDRMask(22, 32)},
 			isErr:       true,
 		},
 		{
 			description: "ipv6",
-			net:         unsafeParseNet("2001::/56"),
+			net:         &net.IPNet{IP: net.ParseIP("2001::"), Mask: net.CIDRMask(56, 128)},
 			expected:    unsafePrefixFromUint64(0x2001000000000000, 0x0, 56),
 		},
 		{
 			description: "mixed up IPv4/6 IPNet",
 			net: &net.IPNet{
-				IP:   unsafeParseNet("2001::/56").IP,
-				Mask: unsafeParseNet("10.0.0.0/16").Mask,
+				IP:   net.ParseIP("2001::"),
+				Mask: net.CIDRMask(16, 32),
 			},
 			isErr: true,
 		},
@@ -404,7 +398,7 @@ func TestPrefixUint64(t *testing.T) {
 }
 
 func TestPrefixFromAddressMask(t *testing.T) {
-	address := Address{ui: uint128{0x20010db885a30000, 0x8a2e03707334}}
+	address := Address{ui: Uint128{0x20010db885a30000, 0x8a2e03707334}}
 	mask, _ := MaskFromLength(80)
 	assert.Equal(t, Prefix{addr: address, length: 80}, PrefixFromAddressMask(address, mask))
 }
@@ -535,3 +529,69 @@ func TestPrefixNumPrefixes(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefixMarshalText(t *testing.T) {
+	text, err := _p("2001:db8::1/64").MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "2001:db8::1/64", string(text))
+
+	var p Prefix
+	assert.Nil(t, p.UnmarshalText(text))
+	assert.Equal(t, _p("2001:db8::1/64"), p)
+
+	assert.NotNil(t, p.UnmarshalText([]byte("2001:db8::1/129")))
+	assert.Equal(t, _p("2001:db8::1/64"), p)
+}
+
+func TestPrefixMarshalJSON(t *testing.T) {
+	prefixes := map[string]Prefix{
+		"a": _p("2001:db8::/32"),
+		"b": _p("2001:db8:1::17/48"),
+	}
+	data, err := json.Marshal(prefixes)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"2001:db8::/32","b":"2001:db8:1::17/48"}`, string(data))
+
+	var decoded map[string]Prefix
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, prefixes, decoded)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":"2001:db8::"}`), &decoded))
+}
+
+func TestPrefixAsJSONMapKey(t *testing.T) {
+	m := map[Prefix]int{
+		_p("2001:db8::/32"): 1,
+	}
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"2001:db8::/32":1}`, string(data))
+
+	var decoded map[Prefix]int
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, m, decoded)
+}
+
+func TestPrefixFromNetipPrefix(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		_, err := PrefixFromNetipPrefix(netip.Prefix{})
+		assert.NotNil(t, err)
+	})
+	t.Run("wrong family", func(t *testing.T) {
+		_, err := PrefixFromNetipPrefix(netip.MustParsePrefix("10.224.24.0/22"))
+		assert.NotNil(t, err)
+	})
+	t.Run("host bits", func(t *testing.T) {
+		prefix, err := PrefixFromNetipPrefix(netip.MustParsePrefix("2001:db8:85a3::1/64"))
+		assert.Nil(t, err)
+		assert.Equal(t, _p("2001:db8:85a3::1/64"), prefix)
+		assert.Equal(t, netip.MustParsePrefix("2001:db8:85a3::1/64"), prefix.ToNetipPrefix())
+	})
+}
+
+func TestPrefixNumAddresses(t *testing.T) {
+	assert.Equal(t, "1", _p("2001:db8::1/128").NumAddresses().String())
+	assert.Equal(t, "256", _p("2001:db8::1/120").NumAddresses().String())
+	assert.Equal(t, "18446744073709551616", _p("2001:db8::/64").NumAddresses().String())
+	assert.Equal(t, "340282366920938463463374607431768211456", _p("::/0").NumAddresses().String())
+}
@@ -1,16 +1,20 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
 // Range represents a range of addresses that don't have to be aligned to
 // powers of 2 like a Prefix does.
 //
-// Note that there is no instantiation of an empty range (.Size() == 0) because
+// Note that there is no instantiation of an empty range (.NumAddresses() == 0) because
 // .First() and .Last() would not make sense.
 //
-// The zero value of a Range is "[::, ::]" (.Size() == 1)
+// The zero value of a Range is "[::, ::]" (.NumAddresses() == 1)
 type Range struct {
 	first, last Address
 }
@@ -28,6 +32,75 @@ func RangeFromAddresses(first, last Address) (r Range, empty bool) {
 	}, false
 }
 
+// RangeFromString returns the Range represented by `r` which is two addresses
+// separated by a dash (e.g. "2001:db8::1-2001:db8::ff"). The last address may
+// be shortened to a single group of up to four hexadecimal digits which then
+// replaces the last group of the first address (e.g. "2001:db8::1-ff"). The
+// format produced by String() (e.g. "[2001:db8::1,2001:db8::ff]") is also
+// accepted. If it cannot be parsed or first comes after last, then error is
+// non-nil and the Range returned must be ignored.
+func RangeFromString(r string) (Range, error) {
+	if strings.HasPrefix(r, "[") {
+		return parseRange(r)
+	}
+	firstStr, lastStr, found := strings.Cut(r, "-")
+	if !found {
+		return Range{}, fmt.Errorf("failed to parse range (expected '-'): %s", r)
+	}
+	first, err := AddressFromString(strings.TrimSpace(firstStr))
+	if err != nil {
+		return Range{}, err
+	}
+	last, err := parseRangeLast(first, strings.TrimSpace(lastStr))
+	if err != nil {
+		return Range{}, err
+	}
+	result, empty := RangeFromAddresses(first, last)
+	if empty {
+		return Range{}, fmt.Errorf("failed to parse range with first address after last: %s", r)
+	}
+	return result, nil
+}
+
+// parseRangeLast parses the last address of a range which may be shortened to
+// its last group. That replaces the last group in first.
+func parseRangeLast(first Address, last string) (Address, error) {
+	if strings.IndexByte(last, ':') >= 0 {
+		return AddressFromString(last)
+	}
+	group, err := strconv.ParseUint(last, 16, 16)
+	if err != nil || len(last) > 4 {
+		return Address{}, fmt.Errorf("failed to parse the last address of range: %s", last)
+	}
+	return Address{Uint128{first.ui.high, first.ui.low&^0xffff | group}}, nil
+}
+
+// parseRange returns the Range represented by `r` in the format produced by
+// String() (e.g. "[first,last]"). If it cannot be parsed or first comes after
+// last, then error is non-nil and the Range returned must be ignored.
+func parseRange(r string) (Range, error) {
+	if !strings.HasPrefix(r, "[") || !strings.HasSuffix(r, "]") {
+		return Range{}, fmt.Errorf("failed to parse range: %s", r)
+	}
+	parts := strings.Split(r[1:len(r)-1], ",")
+	if len(parts) != 2 {
+		return Range{}, fmt.Errorf("failed to parse range: %s", r)
+	}
+	first, err := AddressFromString(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Range{}, err
+	}
+	last, err := AddressFromString(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Range{}, err
+	}
+	result, empty := RangeFromAddresses(first, last)
+	if empty {
+		return Range{}, fmt.Errorf("failed to parse range with first address after last: %s", r)
+	}
+	return result, nil
+}
+
 // First returns the first address in the range
 func (me Range) First() Address {
 	return me.first
@@ -38,21 +111,123 @@ func (me Range) Last() Address {
 	return me.last
 }
 
+// String returns the range in the format "[first,last]"
 func (me Range) String() string {
 	return fmt.Sprintf("[%s,%s]", me.first, me.last)
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Range) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the format
+// produced by String().
+func (me *Range) UnmarshalText(text []byte) error {
+	r, err := parseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*me = r
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The range is encoded as a JSON
+// string.
+func (me Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the range
+// unchanged.
+func (me *Range) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
+
 // Contains returns true iff this range entirely contains the given other range
 func (me Range) Contains(other SetI) bool {
 	return me.Set().Contains(other)
 }
 
+// NumAddresses returns the number of addresses in the range. It is returned
+// as a *big.Int because the range of all addresses has 2^128 of them.
+func (me Range) NumAddresses() *big.Int {
+	count := me.last.ui.subtract(me.first.ui).Big()
+	return count.Add(count, big.NewInt(1))
+}
+
 // NumPrefixes returns the number of prefixes of the given prefix length in
 // this range.
 func (me Range) NumPrefixes(length uint32) (count uint64, err error) {
 	return me.Set().NumPrefixes(length)
 }
 
+// Overlaps returns true if this range and the other have at least one address
+// in common
+func (me Range) Overlaps(other Range) bool {
+	return !me.last.lessThan(other.first) && !other.last.lessThan(me.first)
+}
+
+// WalkPrefixes calls `callback` for each prefix in the smallest list of
+// prefixes that covers the range exactly, in lexigraphical order. It computes
+// them directly without building a Set. It stops iteration immediately if
+// callback returns false.
+//
+// It returns false if iteration was stopped due to a callback return false or
+// true if it iterated all items.
+func (me Range) WalkPrefixes(callback func(Prefix) bool) bool {
+	first := me.first.ui
+	for {
+		// The prefix must start at an address aligned to its size and can't
+		// go past the end of the range. span is one less than the number of
+		// addresses left so that it can't overflow.
+		span := me.last.ui.subtract(first)
+		size := addressSize
+		if span != (Uint128{^uint64(0), ^uint64(0)}) {
+			size = 127 - span.addUint64(1).leadingZeros()
+		}
+		size = intMin(size, first.trailingZeros())
+		if !callback(Prefix{Address{first}, uint32(addressSize - size)}) {
+			return false
+		}
+		end := first.or(Uint128{^uint64(0), ^uint64(0)}.rightShift(addressSize - size))
+		if end == me.last.ui {
+			return true
+		}
+		first = end.addUint64(1)
+	}
+}
+
+// Prefixes returns the smallest list of prefixes that covers the range
+// exactly, in lexigraphical order. See WalkPrefixes.
+func (me Range) Prefixes() []Prefix {
+	prefixes := []Prefix{}
+	me.WalkPrefixes(func(prefix Prefix) bool {
+		prefixes = append(prefixes, prefix)
+		return true
+	})
+	return prefixes
+}
+
+// WalkAddresses calls `callback` for each address in the range in
+// lexographical order. It stops iteration immediately if callback returns
+// false. Beware that a range can have many more addresses than can be
+// iterated in practice.
+//
+// It returns false if iteration was stopped due to a callback return false or
+// true if it iterated all items.
+func (me Range) WalkAddresses(callback func(Address) bool) bool {
+	for addr := me.first; ; addr.ui = addr.ui.addUint64(1) {
+		if !callback(addr) {
+			return false
+		}
+		if addr == me.last {
+			return true
+		}
+	}
+}
+
 // Minus returns a slice of ranges resulting from subtracting the given range
 // The slice will contain from 0 to 2 new ranges depending on how they overlap
 func (me Range) Minus(other Range) []Range {
@@ -101,11 +276,13 @@ func (me Range) Set() Set {
 // prev returns the address just before the range (or maxint) if the range
 // starts at the beginning of the IP space due to overflow)
 func (me Range) prev() Address {
-	return Address{me.first.ui.subtractUint64(1)}
+	prev, _ := me.first.Prev()
+	return prev
 }
 
 // next returns the next address after the range (or 0 if the range goes to the
 // end of the IP space due to overflow)
 func (me Range) next() Address {
-	return Address{me.last.ui.addUint64(1)}
+	next, _ := me.last.Next()
+	return next
 }
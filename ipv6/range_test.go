@@ -1,6 +1,8 @@
 package ipv6
 
 import (
+	"encoding/json"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,15 +54,15 @@ func TestRangeFromAddresses(t *testing.T) {
 		return empty
 	}
 
-	assert.False(t, rangeEmpty(Address{uint128{100, 0}}, Address{uint128{200, 0}}))
-	assert.False(t, rangeEmpty(Address{uint128{100, 0}}, Address{uint128{100, 0}}))
-	assert.True(t, rangeEmpty(Address{uint128{200, 0}}, Address{uint128{100, 0}}))
-	assert.True(t, rangeEmpty(Address{uint128{200, 0}}, Address{uint128{199, 0}}))
-	assert.True(t, rangeEmpty(Address{uint128{0xffffffff, 0}}, Address{uint128{0, 0}}))
+	assert.False(t, rangeEmpty(Address{Uint128{100, 0}}, Address{Uint128{200, 0}}))
+	assert.False(t, rangeEmpty(Address{Uint128{100, 0}}, Address{Uint128{100, 0}}))
+	assert.True(t, rangeEmpty(Address{Uint128{200, 0}}, Address{Uint128{100, 0}}))
+	assert.True(t, rangeEmpty(Address{Uint128{200, 0}}, Address{Uint128{199, 0}}))
+	assert.True(t, rangeEmpty(Address{Uint128{0xffffffff, 0}}, Address{Uint128{0, 0}}))
 }
 
 func TestRangeString(t *testing.T) {
-	assert.Equal(t, "[2001:db8:85a3::8a2e:370:7334,2001:db8:9621::1234:c28:1]", _r(Address{ui: uint128{0x20010db885a30000, 0x8a2e03707334}}, Address{ui: uint128{0x20010db896210000, 0x12340c280001}}).String())
+	assert.Equal(t, "[2001:db8:85a3::8a2e:370:7334,2001:db8:9621::1234:c28:1]", _r(Address{ui: Uint128{0x20010db885a30000, 0x8a2e03707334}}, Address{ui: Uint128{0x20010db896210000, 0x12340c280001}}).String())
 	assert.Equal(t, "[2001:db8:85a3:1234:abcd:8a2e::,2001:db8:85a3:1234:abcd:8a2e:ffff:ffff]", _p("2001:db8:85a3:1234:abcd:8a2e:1:1/96").Range().String())
 }
 
@@ -72,9 +74,9 @@ func TestRangeFirstLast(t *testing.T) {
 	}{
 		{
 			description: "unaligned",
-			r:           _r(Address{ui: uint128{0x12345678, 0x0}}, Address{ui: uint128{0x23456789, 0x0}}),
-			first:       Address{ui: uint128{0x12345678, 0x0}},
-			last:        Address{ui: uint128{0x23456789, 0x0}},
+			r:           _r(Address{ui: Uint128{0x12345678, 0x0}}, Address{ui: Uint128{0x23456789, 0x0}}),
+			first:       Address{ui: Uint128{0x12345678, 0x0}},
+			last:        Address{ui: Uint128{0x23456789, 0x0}},
 		},
 		{
 			description: "prefix",
@@ -103,7 +105,7 @@ func TestRangeContains(t *testing.T) {
 		},
 		{
 			description: "unaligned",
-			a:           _r(Address{ui: uint128{low: 0xffff12345678}}, Address{ui: uint128{low: 0xffff23456789}}),
+			a:           _r(Address{ui: Uint128{low: 0xffff12345678}}, Address{ui: Uint128{low: 0xffff23456789}}),
 			b:           _p("::ffff:20.224.26.1/120").Range(),
 		},
 	}
@@ -136,13 +138,13 @@ func TestRangeMinus(t *testing.T) {
 		},
 		{
 			description: "overlap right",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 50}}, Address{uint128{0, 150}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 50}}, Address{Uint128{0, 150}}},
 			result: []Range{
-				Range{Address{uint128{0, 151}}, Address{uint128{0, 200}}},
+				Range{Address{Uint128{0, 151}}, Address{Uint128{0, 200}}},
 			},
 			backwards: []Range{
-				Range{Address{uint128{0, 50}}, Address{uint128{0, 99}}},
+				Range{Address{Uint128{0, 50}}, Address{Uint128{0, 99}}},
 			},
 		},
 		{
@@ -156,12 +158,12 @@ func TestRangeMinus(t *testing.T) {
 		},
 		{
 			description: "overlap all",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 50}}, Address{uint128{0, 250}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 50}}, Address{Uint128{0, 250}}},
 			result:      []Range{},
 			backwards: []Range{
-				Range{Address{uint128{0, 50}}, Address{uint128{0, 99}}},
-				Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+				Range{Address{Uint128{0, 50}}, Address{Uint128{0, 99}}},
+				Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			},
 		},
 		{
@@ -182,11 +184,11 @@ func TestRangeMinus(t *testing.T) {
 		},
 		{
 			description: "wholly contained",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 110}}, Address{uint128{0, 190}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 110}}, Address{Uint128{0, 190}}},
 			result: []Range{
-				Range{Address{uint128{0, 100}}, Address{uint128{0, 109}}},
-				Range{Address{uint128{0, 191}}, Address{uint128{0, 200}}},
+				Range{Address{Uint128{0, 100}}, Address{Uint128{0, 109}}},
+				Range{Address{Uint128{0, 191}}, Address{Uint128{0, 200}}},
 			},
 			backwards: []Range{},
 		},
@@ -201,46 +203,46 @@ func TestRangeMinus(t *testing.T) {
 		},
 		{
 			description: "overlap left",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 150}}, Address{uint128{0, 250}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 150}}, Address{Uint128{0, 250}}},
 			result: []Range{
-				Range{Address{uint128{0, 100}}, Address{uint128{0, 149}}},
+				Range{Address{Uint128{0, 100}}, Address{Uint128{0, 149}}},
 			},
 			backwards: []Range{
-				Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+				Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			},
 		},
 		{
 			description: "first equals last",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 200}}, Address{uint128{0, 250}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 200}}, Address{Uint128{0, 250}}},
 			result: []Range{
-				Range{Address{uint128{0, 100}}, Address{uint128{0, 199}}},
+				Range{Address{Uint128{0, 100}}, Address{Uint128{0, 199}}},
 			},
 			backwards: []Range{
-				Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+				Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			},
 		},
 		{
 			description: "first + 1 equals last",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 199}}, Address{uint128{0, 250}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 199}}, Address{Uint128{0, 250}}},
 			result: []Range{
-				Range{Address{uint128{0, 100}}, Address{uint128{0, 198}}},
+				Range{Address{Uint128{0, 100}}, Address{Uint128{0, 198}}},
 			},
 			backwards: []Range{
-				Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+				Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			},
 		},
 		{
 			description: "first equals last + 1",
-			a:           Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
-			b:           Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+			a:           Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
+			b:           Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			result: []Range{
-				Range{Address{uint128{0, 100}}, Address{uint128{0, 200}}},
+				Range{Address{Uint128{0, 100}}, Address{Uint128{0, 200}}},
 			},
 			backwards: []Range{
-				Range{Address{uint128{0, 201}}, Address{uint128{0, 250}}},
+				Range{Address{Uint128{0, 201}}, Address{Uint128{0, 250}}},
 			},
 		},
 		{
@@ -424,3 +426,196 @@ func TestRangeNumPrefixes(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeMarshalText(t *testing.T) {
+	tests := []struct {
+		description string
+		text        string
+		r           Range
+		isErr       bool
+	}{
+		{
+			description: "simple",
+			text:        "[2001:db8::1,2001:db8::ffff]",
+			r:           _r(_a("2001:db8::1"), _a("2001:db8::ffff")),
+		}, {
+			description: "spaces",
+			text:        "[2001:db8::1, 2001:db8::ffff]",
+			r:           _r(_a("2001:db8::1"), _a("2001:db8::ffff")),
+		}, {
+			description: "backwards",
+			text:        "[2001:db8::ffff,2001:db8::1]",
+			isErr:       true,
+		}, {
+			description: "no brackets",
+			text:        "2001:db8::1,2001:db8::ffff",
+			isErr:       true,
+		}, {
+			description: "bad address",
+			text:        "[2001:db8::1,2001:db8::g]",
+			isErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var r Range
+			err := r.UnmarshalText([]byte(tt.text))
+			if tt.isErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.r, r)
+
+			text, err := r.MarshalText()
+			assert.Nil(t, err)
+			assert.Equal(t, r.String(), string(text))
+		})
+	}
+}
+
+func TestRangeMarshalJSON(t *testing.T) {
+	r := _r(_a("2001:db8::1"), _a("2001:db8::ffff"))
+	data, err := json.Marshal(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `"[2001:db8::1,2001:db8::ffff]"`, string(data))
+
+	var decoded Range
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, r, decoded)
+}
+
+func TestRangeNumAddresses(t *testing.T) {
+	assert.Equal(t, "1", Range{}.NumAddresses().String())
+	assert.Equal(t, "101", _r(_a("2001:db8::1"), _a("2001:db8::65")).NumAddresses().String())
+	assert.Equal(t, "18446744073709551617", _r(_a("2001:db8::"), _a("2001:db8:0:1::")).NumAddresses().String())
+	assert.Equal(t, "340282366920938463463374607431768211456", _r(_a("::"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")).NumAddresses().String())
+}
+
+func TestRangeFromString(t *testing.T) {
+	tests := []struct {
+		input string
+		r     Range
+	}{
+		{"2001:db8::1-2001:db8::ff", _r(_a("2001:db8::1"), _a("2001:db8::ff"))},
+		{"2001:db8::1 - 2001:db8::ff", _r(_a("2001:db8::1"), _a("2001:db8::ff"))},
+		{"2001:db8::1-ff", _r(_a("2001:db8::1"), _a("2001:db8::ff"))},
+		{"2001:db8::1-FFFF", _r(_a("2001:db8::1"), _a("2001:db8::ffff"))},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", _r(_a("::"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"))},
+		{"[2001:db8::1,2001:db8::ff]", _r(_a("2001:db8::1"), _a("2001:db8::ff"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := RangeFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.r, r)
+		})
+	}
+
+	for _, input := range []string{
+		"",
+		"2001:db8::1",
+		"2001:db8::1-",
+		"2001:db8::1-10000",
+		"2001:db8::1-g",
+		"2001:db8::2-1",
+		"2001:db8::1-2001:db8::",
+		"2001:db8::g-ff",
+	} {
+		_, err := RangeFromString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	r := _r(_a("2001:db8::10"), _a("2001:db8::20"))
+	assert.True(t, r.Overlaps(r))
+	assert.True(t, r.Overlaps(_r(_a("2001:db8::"), _a("2001:db8::10"))))
+	assert.True(t, r.Overlaps(_r(_a("2001:db8::20"), _a("2001:db8::30"))))
+	assert.True(t, r.Overlaps(_r(_a("::"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"))))
+	assert.False(t, r.Overlaps(_r(_a("2001:db8::"), _a("2001:db8::f"))))
+	assert.False(t, r.Overlaps(_r(_a("2001:db8::21"), _a("2001:db8::30"))))
+}
+
+func TestRangePrefixes(t *testing.T) {
+	tests := []struct {
+		r        Range
+		prefixes []Prefix
+	}{
+		{_r(_a("2001:db8::"), _a("2001:db8::")), []Prefix{_p("2001:db8::/128")}},
+		{_r(_a("2001:db8::"), _a("2001:db8::ffff:ffff:ffff:ffff")), []Prefix{_p("2001:db8::/64")}},
+		{_r(_a("::"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")), []Prefix{_p("::/0")}},
+		{_r(_a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")), []Prefix{_p("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128")}},
+		{_r(_a("2001:db8::1"), _a("2001:db8::e")), []Prefix{
+			_p("2001:db8::1/128"),
+			_p("2001:db8::2/127"),
+			_p("2001:db8::4/126"),
+			_p("2001:db8::8/126"),
+			_p("2001:db8::c/127"),
+			_p("2001:db8::e/128"),
+		}},
+		{_r(_a("2001:db8:0:ffff::"), _a("2001:db8:1::ffff")), []Prefix{
+			_p("2001:db8:0:ffff::/64"),
+			_p("2001:db8:1::/112"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.r.String(), func(t *testing.T) {
+			assert.Equal(t, tt.prefixes, tt.r.Prefixes())
+		})
+	}
+}
+
+func TestRangeWalkPrefixesMatchesSet(t *testing.T) {
+	rand.Seed(29)
+	for i := 0; i < 1000; i++ {
+		a := Address{Uint128{rand.Uint64(), rand.Uint64()}}
+		b := Address{Uint128{rand.Uint64(), rand.Uint64()}}
+		if i%2 == 0 {
+			// Keep some ranges within a /64
+			b.ui.high = a.ui.high
+		}
+		r := _r(minAddress(a, b), maxAddress(a, b))
+
+		var fromSet []Prefix
+		r.Set().WalkPrefixes(func(p Prefix) bool {
+			fromSet = append(fromSet, p)
+			return true
+		})
+		assert.Equal(t, fromSet, r.Prefixes())
+	}
+}
+
+func TestRangeWalkPrefixesStop(t *testing.T) {
+	r := _r(_a("2001:db8::1"), _a("2001:db8::e"))
+	count := 0
+	assert.False(t, r.WalkPrefixes(func(Prefix) bool {
+		count++
+		return count < 3
+	}))
+	assert.Equal(t, 3, count)
+}
+
+func TestRangeWalkAddresses(t *testing.T) {
+	var addrs []Address
+	assert.True(t, _r(_a("2001:db8::ffff:ffff:ffff:fffe"), _a("2001:db8:0:1::1")).WalkAddresses(func(addr Address) bool {
+		addrs = append(addrs, addr)
+		return true
+	}))
+	assert.Equal(t, []Address{
+		_a("2001:db8::ffff:ffff:ffff:fffe"),
+		_a("2001:db8::ffff:ffff:ffff:ffff"),
+		_a("2001:db8:0:1::"),
+		_a("2001:db8:0:1::1"),
+	}, addrs)
+
+	count := 0
+	assert.False(t, _r(_a("::"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")).WalkAddresses(func(Address) bool {
+		count++
+		return count < 5
+	}))
+	assert.Equal(t, 5, count)
+}
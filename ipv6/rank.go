@@ -0,0 +1,90 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// addressAt returns the address at the given index counting from the lowest
+// address in the set. Each node stores the number of addresses under it so
+// it descends straight to the answer. The index must be less than the number
+// of addresses in the set.
+func (me *setNode) addressAt(index Uint128) Address {
+	n := me
+	for !n.isActive {
+		left := n.Left()
+		if index.compare(left.numAddrs) < 0 {
+			n = left
+		} else {
+			index = index.subtract(left.numAddrs)
+			n = n.Right()
+		}
+	}
+	ui, _ := n.Prefix.Network().addr.ui.add(index)
+	return Address{ui}
+}
+
+// indexOf returns the number of addresses in the set that are lower than the
+// given address. If the address isn't in the set, found is false.
+func (me *setNode) indexOf(addr Address) (index Uint128, found bool) {
+	n := me
+	for n != nil {
+		matches, _, _, child := contains(n.Prefix, addr.Prefix())
+		if !matches {
+			return Uint128{}, false
+		}
+		if n.isActive {
+			index, _ = index.add(addr.ui.subtract(n.Prefix.Network().addr.ui))
+			return index, true
+		}
+		if child == 1 {
+			index, _ = index.add(n.Left().numAddrs)
+		}
+		n = (*setNode)(n.children[child])
+	}
+	return Uint128{}, false
+}
+
+// AddressAt returns the address at the given index in the set where the
+// lowest address is at index 0. It is the inverse of IndexOf. It takes time
+// proportional to the height of the trie. It is an error if the index is not
+// less than NumAddresses().
+func (me Set) AddressAt(index Uint128) (Address, error) {
+	count, all := (*trieNode)(me.trie).numAddresses()
+	if !all && count.compare(index) <= 0 {
+		return Address{}, fmt.Errorf("index %s is out of range for a set of %s addresses", index, count)
+	}
+	return me.trie.addressAt(index), nil
+}
+
+// IndexOf returns the index of the given address in the set where the lowest
+// address is at index 0. It is the inverse of AddressAt. It takes time
+// proportional to the height of the trie. If the address is not in the set,
+// found is false and the index must be ignored.
+func (me Set) IndexOf(addr Address) (index Uint128, found bool) {
+	return me.trie.indexOf(addr)
+}
+
+// RandomAddress returns an address chosen uniformly at random from the set
+// using the given source. It takes time proportional to the height of the
+// trie. If the set is empty, ok is false and the address must be ignored.
+func (me Set) RandomAddress(source rand.Source) (addr Address, ok bool) {
+	if me.IsEmpty() {
+		return Address{}, false
+	}
+	random := rand.New(source)
+
+	// Draw indexes with only as many bits as the count needs and reject any
+	// that are too big. At least half of the draws are accepted.
+	count, all := (*trieNode)(me.trie).numAddresses()
+	mask := maxUint128
+	if !all {
+		mask = mask.rightShift(count.subtractUint64(1).leadingZeros())
+	}
+	for {
+		index := Uint128{random.Uint64(), random.Uint64()}.and(mask)
+		if all || index.compare(count) < 0 {
+			return me.trie.addressAt(index), true
+		}
+	}
+}
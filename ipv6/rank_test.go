@@ -0,0 +1,129 @@
+package ipv6
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAddressAt(t *testing.T) {
+	set := setFromPrefixes("2001:db8::/127", "2001:db8::4/126", "fd00::/112")
+
+	tests := []struct {
+		index    Uint128
+		expected Address
+	}{
+		{Uint128{0, 0}, _a("2001:db8::")},
+		{Uint128{0, 1}, _a("2001:db8::1")},
+		{Uint128{0, 2}, _a("2001:db8::4")},
+		{Uint128{0, 5}, _a("2001:db8::7")},
+		{Uint128{0, 6}, _a("fd00::")},
+		{Uint128{0, 65541}, _a("fd00::ffff")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected.String(), func(t *testing.T) {
+			addr, err := set.AddressAt(tt.index)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, addr)
+
+			index, found := set.IndexOf(tt.expected)
+			assert.True(t, found)
+			assert.Equal(t, tt.index, index)
+		})
+	}
+
+	_, err := set.AddressAt(Uint128{0, 65542})
+	assert.NotNil(t, err)
+	_, err = set.AddressAt(Uint128{1, 0})
+	assert.NotNil(t, err)
+	_, err = Set{}.AddressAt(Uint128{})
+	assert.NotNil(t, err)
+
+	_, found := set.IndexOf(_a("2001:db8::2"))
+	assert.False(t, found)
+	_, found = set.IndexOf(_a("fe80::1"))
+	assert.False(t, found)
+	_, found = Set{}.IndexOf(_a("2001:db8::"))
+	assert.False(t, found)
+}
+
+func TestSetAddressAtEverything(t *testing.T) {
+	set := setFromPrefixes("::/0")
+
+	addr, err := set.AddressAt(maxUint128)
+	assert.Nil(t, err)
+	assert.Equal(t, _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), addr)
+
+	index, found := set.IndexOf(_a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"))
+	assert.True(t, found)
+	assert.Equal(t, maxUint128, index)
+
+	// Every address is in the second half except for the first half
+	set = set.Difference(_p("::/1")).Union(_p("::/2"))
+	addr, err = set.AddressAt(Uint128{0x4000000000000000, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, _a("8000::"), addr)
+}
+
+func TestSetAddressAtRandom(t *testing.T) {
+	rand.Seed(29)
+	for i := 0; i < 100; i++ {
+		s := NewSet_()
+		for j := 0; j < 1+rand.Intn(8); j++ {
+			s.Insert(Prefix{Address{Uint128{0x20010db800000000, uint64(rand.Uint32() & 0xffff)}}, uint32(120 + rand.Intn(9))})
+		}
+		set := s.Set()
+
+		// Compare to counting addresses in order
+		var index Uint128
+		set.WalkPrefixes(func(prefix Prefix) bool {
+			first := prefix.Network().addr.ui
+			count, _ := prefix.NumPrefixes(128)
+			for j := uint64(0); j < count; j++ {
+				expected := Address{first.addUint64(j)}
+				addr, err := set.AddressAt(index)
+				assert.Nil(t, err)
+				assert.Equal(t, expected, addr)
+
+				i, found := set.IndexOf(expected)
+				assert.True(t, found)
+				assert.Equal(t, index, i)
+
+				index = index.addUint64(1)
+			}
+			return true
+		})
+		assert.Equal(t, set.NumAddresses(), index.Big())
+	}
+}
+
+func TestSetRandomAddress(t *testing.T) {
+	source := rand.NewSource(29)
+
+	_, ok := Set{}.RandomAddress(source)
+	assert.False(t, ok)
+
+	set := setFromPrefixes("2001:db8::/127", "2001:db8::4/126")
+	counts := map[Address]int{}
+	for i := 0; i < 6000; i++ {
+		addr, ok := set.RandomAddress(source)
+		assert.True(t, ok)
+		assert.True(t, set.Contains(addr))
+		counts[addr]++
+	}
+
+	// Each of the six addresses should be drawn about 1000 times
+	assert.Len(t, counts, 6)
+	for addr, count := range counts {
+		assert.InDelta(t, 1000, count, 150, addr.String())
+	}
+
+	// Every address is a valid draw from the whole address space
+	set = setFromPrefixes("::/0")
+	for i := 0; i < 10; i++ {
+		_, ok := set.RandomAddress(source)
+		assert.True(t, ok)
+	}
+}
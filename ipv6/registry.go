@@ -0,0 +1,217 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import "fmt"
+
+// SpecialPurpose is an entry in the IANA IPv6 Special-Purpose Address
+// Registry (RFC 6890). The boolean attributes have the meanings given in RFC
+// 6890 section 2.2.2. Where the registry says an attribute is "N/A", it is
+// false here.
+type SpecialPurpose struct {
+	// Prefix is the address block
+	Prefix Prefix
+	// Name is the name of the block as it appears in the registry
+	Name string
+	// RFC is the document that defines the block (e.g. "RFC 4193")
+	RFC string
+	// Source is true if an address from the block is valid as a source address
+	Source bool
+	// Destination is true if an address from the block is valid as a
+	// destination address
+	Destination bool
+	// Forwardable is true if a router may forward a packet with an address
+	// from the block as its destination beyond a single link
+	Forwardable bool
+	// GloballyReachable is true if a packet with an address from the block
+	// as its destination can be forwarded beyond a single administrative
+	// domain
+	GloballyReachable bool
+	// ReservedByProtocol is true if the block is reserved by a protocol
+	// specification rather than being allocated for a particular use
+	ReservedByProtocol bool
+}
+
+// specialPurposeEntries is the content of the registry. The columns follow
+// the order of the registry: source, destination, forwardable, globally
+// reachable, and reserved-by-protocol.
+var specialPurposeEntries = []SpecialPurpose{
+	{mustParsePrefix("::1/128"), "Loopback Address", "RFC 4291", false, false, false, false, true},
+	{mustParsePrefix("::/128"), "Unspecified Address", "RFC 4291", true, false, false, false, true},
+	{mustParsePrefix("::ffff:0:0/96"), "IPv4-mapped Address", "RFC 4291", false, false, false, false, true},
+	{mustParsePrefix("64:ff9b::/96"), "IPv4-IPv6 Translat.", "RFC 6052", true, true, true, true, false},
+	{mustParsePrefix("64:ff9b:1::/48"), "IPv4-IPv6 Translat.", "RFC 8215", true, true, true, false, false},
+	{mustParsePrefix("100::/64"), "Discard-Only Address Block", "RFC 6666", true, true, true, false, false},
+	{mustParsePrefix("2001::/23"), "IETF Protocol Assignments", "RFC 2928", false, false, false, false, false},
+	{mustParsePrefix("2001::/32"), "TEREDO", "RFC 4380", true, true, true, false, false},
+	{mustParsePrefix("2001:1::1/128"), "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false},
+	{mustParsePrefix("2001:1::2/128"), "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false},
+	{mustParsePrefix("2001:2::/48"), "Benchmarking", "RFC 5180", true, true, true, false, false},
+	{mustParsePrefix("2001:3::/32"), "AMT", "RFC 7450", true, true, true, true, false},
+	{mustParsePrefix("2001:4:112::/48"), "AS112-v6", "RFC 7535", true, true, true, true, false},
+	{mustParsePrefix("2001:20::/28"), "ORCHIDv2", "RFC 7343", true, true, true, true, false},
+	{mustParsePrefix("2001:30::/28"), "Drone Remote ID Protocol Entity Tags (DETs) Prefix", "RFC 9374", true, true, true, true, false},
+	{mustParsePrefix("2001:db8::/32"), "Documentation", "RFC 3849", false, false, false, false, false},
+	{mustParsePrefix("2002::/16"), "6to4", "RFC 3056", true, true, true, false, false},
+	{mustParsePrefix("2620:4f:8000::/48"), "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false},
+	{mustParsePrefix("3fff::/20"), "Documentation", "RFC 9637", false, false, false, false, false},
+	{mustParsePrefix("5f00::/16"), "Segment Routing (SRv6) SIDs", "RFC 9602", true, true, true, false, false},
+	{mustParsePrefix("fc00::/7"), "Unique-Local", "RFC 4193", true, true, true, false, false},
+	{mustParsePrefix("fe80::/10"), "Link-Local Unicast", "RFC 4291", true, true, false, false, true},
+}
+
+var (
+	specialPurposeRegistry = NewTable_[SpecialPurpose]().Table().Build(func(t Table_[SpecialPurpose]) bool {
+		for _, entry := range specialPurposeEntries {
+			t.Insert(entry.Prefix, entry)
+		}
+		return true
+	})
+
+	loopbackSet                = setFromStrings("::1/128")
+	uniqueLocalSet             = setFromStrings("fc00::/7")
+	multicastSet               = setFromStrings("ff00::/8")
+	linkLocalUnicastSet        = setFromStrings("fe80::/10")
+	linkLocalMulticastSet      = multicastScopeSet(0x2)
+	interfaceLocalMulticastSet = multicastScopeSet(0x1)
+	documentationSet           = setFromStrings("2001:db8::/32", "3fff::/20")
+	benchmarkingSet            = setFromStrings("2001:2::/48")
+)
+
+// SpecialPurposeRegistry returns a table with an entry for each block in the
+// IANA IPv6 Special-Purpose Address Registry. Some blocks are nested inside
+// others (e.g. 2001:db8::/32 is in 2001::/23) so use LongestMatch to find the
+// most specific entry for an address or prefix.
+func SpecialPurposeRegistry() Table[SpecialPurpose] {
+	return specialPurposeRegistry
+}
+
+// SpecialPurpose returns the most specific entry in the special-purpose
+// registry containing this address. If there is none, found is false. It is
+// equivalent to calling LongestMatch on the registry but doesn't allocate.
+func (me Address) SpecialPurpose() (entry SpecialPurpose, found bool) {
+	node := specialPurposeRegistry.t.trie.Match(me.Prefix())
+	if node == nil {
+		return SpecialPurpose{}, false
+	}
+	entry, found = node.Data.(SpecialPurpose)
+	return
+}
+
+// The predicates below classify IPv6 addresses only by their IPv6 meaning. An
+// IPv4-mapped address such as ::ffff:127.0.0.1 is not considered a loopback
+// address, for example.
+
+// IsUnspecified returns true if this is ::
+func (me Address) IsUnspecified() bool {
+	return me == Address{}
+}
+
+// IsLoopback returns true if this is the loopback address (::1)
+func (me Address) IsLoopback() bool {
+	return loopbackSet.containsAddress(me)
+}
+
+// IsPrivate returns true if this is a unique local address (fc00::/7) as
+// defined in RFC 4193. This is the IPv6 equivalent of the IPv4 private-use
+// blocks and matches net.IP's IsPrivate.
+func (me Address) IsPrivate() bool {
+	return uniqueLocalSet.containsAddress(me)
+}
+
+// IsMulticast returns true if this is a multicast address (ff00::/8)
+func (me Address) IsMulticast() bool {
+	return multicastSet.containsAddress(me)
+}
+
+// IsLinkLocalUnicast returns true if this is a link-local unicast address
+// (fe80::/10)
+func (me Address) IsLinkLocalUnicast() bool {
+	return linkLocalUnicastSet.containsAddress(me)
+}
+
+// IsLinkLocalMulticast returns true if this is a multicast address with
+// link-local scope (ffx2::/16 for any flags x)
+func (me Address) IsLinkLocalMulticast() bool {
+	return linkLocalMulticastSet.containsAddress(me)
+}
+
+// IsInterfaceLocalMulticast returns true if this is a multicast address with
+// interface-local scope (ffx1::/16 for any flags x)
+func (me Address) IsInterfaceLocalMulticast() bool {
+	return interfaceLocalMulticastSet.containsAddress(me)
+}
+
+// IsLinkLocal returns true if this is either a link-local unicast or a
+// link-local multicast address
+func (me Address) IsLinkLocal() bool {
+	return me.IsLinkLocalUnicast() || me.IsLinkLocalMulticast()
+}
+
+// IsDocumentation returns true if this is in one of the blocks reserved for
+// documentation (2001:db8::/32 from RFC 3849 and 3fff::/20 from RFC 9637)
+func (me Address) IsDocumentation() bool {
+	return documentationSet.containsAddress(me)
+}
+
+// IsBenchmarking returns true if this is in the block reserved for benchmarking
+// by RFC 5180 (2001:2::/48)
+func (me Address) IsBenchmarking() bool {
+	return benchmarkingSet.containsAddress(me)
+}
+
+// IsGlobalUnicast returns true if this is a global unicast address. This
+// follows the definition used by net.IP: it is any address that is not
+// unspecified, loopback, multicast or link-local unicast. Notably, unique
+// local addresses are global unicast addresses. See IsGloballyReachable to
+// exclude those too.
+func (me Address) IsGlobalUnicast() bool {
+	return !me.IsUnspecified() &&
+		!me.IsLoopback() &&
+		!me.IsMulticast() &&
+		!me.IsLinkLocalUnicast()
+}
+
+// IsGloballyReachable returns true if this address is globally reachable
+// according to the special-purpose registry. Addresses that aren't in any
+// special-purpose block are globally reachable. Note that the registry says
+// that global reachability is "N/A" for the 6to4 and Teredo blocks because it
+// depends on the embedded IPv4 address so they are reported as not globally
+// reachable.
+func (me Address) IsGloballyReachable() bool {
+	if entry, found := me.SpecialPurpose(); found {
+		return entry.GloballyReachable
+	}
+	return true
+}
+
+// multicastScopeSet returns the set of multicast addresses with the given
+// scope and any flags (ffxs::/16 where s is the scope)
+func multicastScopeSet(scope uint16) Set {
+	s := NewSet_()
+	for flags := uint16(0); flags < 0x10; flags++ {
+		s.Insert(mustParsePrefix(fmt.Sprintf("ff%x%x::/16", flags, scope)))
+	}
+	return s.Set()
+}
+
+// mustParsePrefix parses a prefix that is known to be valid. It is used to
+// initialize package variables.
+func mustParsePrefix(prefix string) Prefix {
+	p, err := PrefixFromString(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// setFromStrings returns a set of prefixes that are known to be valid. It is
+// used to initialize package variables.
+func setFromStrings(prefixes ...string) Set {
+	s := NewSet_()
+	for _, prefix := range prefixes {
+		s.Insert(mustParsePrefix(prefix))
+	}
+	return s.Set()
+}
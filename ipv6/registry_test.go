@@ -0,0 +1,111 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecialPurposeRegistry(t *testing.T) {
+	registry := SpecialPurposeRegistry()
+	assert.Equal(t, int64(len(specialPurposeEntries)), registry.NumEntries())
+
+	registry.Walk(func(p Prefix, entry SpecialPurpose) bool {
+		assert.Equal(t, p, entry.Prefix)
+		assert.Equal(t, p.Network(), p)
+		return true
+	})
+
+	entry, found, prefix := registry.LongestMatch(_a("2001:db8::1"))
+	assert.True(t, found)
+	assert.Equal(t, _p("2001:db8::/32"), prefix)
+	assert.Equal(t, "Documentation", entry.Name)
+
+	entry, found, _ = registry.LongestMatch(_p("2001:100::/24"))
+	assert.True(t, found)
+	assert.Equal(t, "IETF Protocol Assignments", entry.Name)
+
+	_, found, _ = registry.LongestMatch(_a("2600::1"))
+	assert.False(t, found)
+}
+
+func TestAddressSpecialPurpose(t *testing.T) {
+	tests := []struct {
+		address           Address
+		name              string
+		forwardable       bool
+		globallyReachable bool
+	}{
+		{_a("::"), "Unspecified Address", false, false},
+		{_a("::1"), "Loopback Address", false, false},
+		{_a("::ffff:203.0.113.17"), "IPv4-mapped Address", false, false},
+		{_a("64:ff9b::203.0.113.17"), "IPv4-IPv6 Translat.", true, true},
+		{_a("2001::1"), "TEREDO", true, false},
+		{_a("2001:1::1"), "Port Control Protocol Anycast", true, true},
+		{_a("2001:1::3"), "IETF Protocol Assignments", false, false},
+		{_a("2001:db8::1"), "Documentation", false, false},
+		{_a("fd00::1"), "Unique-Local", true, false},
+		{_a("fe80::1"), "Link-Local Unicast", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			entry, found := tt.address.SpecialPurpose()
+			assert.True(t, found)
+			assert.Equal(t, tt.name, entry.Name)
+			assert.Equal(t, tt.forwardable, entry.Forwardable)
+			assert.Equal(t, tt.globallyReachable, entry.GloballyReachable)
+			assert.Equal(t, tt.globallyReachable, tt.address.IsGloballyReachable())
+		})
+	}
+
+	_, found := _a("2600::1").SpecialPurpose()
+	assert.False(t, found)
+	assert.True(t, _a("2600::1").IsGloballyReachable())
+}
+
+func TestAddressPredicates(t *testing.T) {
+	addresses := []string{
+		"::", "::1", "::2", "2001:db8::1", "2600::1", "fbff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+		"fc00::", "fdff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "fe00::", "fe7f:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+		"fe80::", "febf:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "fec0::", "feff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+		"ff00::", "ff01::1", "ff02::1", "ff05::2", "ff11::1", "ff12::1", "ff32::1", "fff2::1",
+		"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+	}
+
+	for _, str := range addresses {
+		t.Run(str, func(t *testing.T) {
+			addr := _a(str)
+			ip := net.ParseIP(str)
+			assert.Equal(t, ip.IsUnspecified(), addr.IsUnspecified())
+			assert.Equal(t, ip.IsLoopback(), addr.IsLoopback())
+			assert.Equal(t, ip.IsPrivate(), addr.IsPrivate())
+			assert.Equal(t, ip.IsMulticast(), addr.IsMulticast())
+			assert.Equal(t, ip.IsLinkLocalUnicast(), addr.IsLinkLocalUnicast())
+			assert.Equal(t, ip.IsLinkLocalMulticast(), addr.IsLinkLocalMulticast())
+			assert.Equal(t, ip.IsInterfaceLocalMulticast(), addr.IsInterfaceLocalMulticast())
+			assert.Equal(t, ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast(), addr.IsLinkLocal())
+			assert.Equal(t, ip.IsGlobalUnicast(), addr.IsGlobalUnicast())
+		})
+	}
+
+	assert.False(t, _a("::ffff:127.0.0.1").IsLoopback())
+	assert.True(t, _a("3fff:fff:ffff::1").IsDocumentation())
+	assert.False(t, _a("3fff:1000::").IsDocumentation())
+	assert.True(t, _a("2001:2::1").IsBenchmarking())
+	assert.False(t, _a("2001:2:1::").IsBenchmarking())
+}
+
+func TestAddressPredicatesAllocs(t *testing.T) {
+	addr := _a("fd00::1")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = addr.IsPrivate()
+		_ = addr.IsGlobalUnicast()
+		_ = addr.IsGloballyReachable()
+	})
+	assert.Equal(t, float64(0), allocs)
+}
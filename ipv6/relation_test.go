@@ -0,0 +1,169 @@
+package ipv6
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setFromPrefixes(prefixes ...string) Set {
+	s := NewSet_()
+	for _, prefix := range prefixes {
+		s.Insert(_p(prefix))
+	}
+	return s.Set()
+}
+
+func TestRelation(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        SetI
+		relation    SetRelation
+	}{
+		{
+			description: "equal",
+			a:           setFromPrefixes("::ffff:10.0.0.0/120", "::ffff:10.0.2.0/120"),
+			b:           setFromPrefixes("::ffff:10.0.0.0/121", "::ffff:10.0.0.128/121", "::ffff:10.0.2.0/120"),
+			relation:    RelationEqual,
+		}, {
+			description: "subset",
+			a:           _p("::ffff:10.0.0.0/121"),
+			b:           setFromPrefixes("::ffff:10.0.0.0/120", "::ffff:10.0.2.0/120"),
+			relation:    RelationSubset,
+		}, {
+			description: "superset",
+			a:           setFromPrefixes("::ffff:10.0.0.0/104"),
+			b:           setFromPrefixes("::ffff:10.1.0.0/112", "::ffff:10.200.3.0/120"),
+			relation:    RelationSuperset,
+		}, {
+			description: "overlapping",
+			a:           setFromPrefixes("::ffff:10.0.0.0/120", "::ffff:10.0.2.0/120"),
+			b:           setFromPrefixes("::ffff:10.0.2.0/121", "::ffff:10.0.4.0/120"),
+			relation:    RelationOverlapping,
+		}, {
+			description: "overlapping ranges",
+			a:           _r(_a("::ffff:10.0.0.1"), _a("::ffff:10.0.0.20")),
+			b:           _r(_a("::ffff:10.0.0.20"), _a("::ffff:10.0.0.30")),
+			relation:    RelationOverlapping,
+		}, {
+			description: "disjoint",
+			a:           setFromPrefixes("::ffff:10.0.0.0/120", "::ffff:10.0.2.0/120"),
+			b:           setFromPrefixes("::ffff:10.0.1.0/120", "::ffff:10.0.3.0/120"),
+			relation:    RelationDisjoint,
+		}, {
+			description: "disjoint adjacent",
+			a:           _p("::ffff:10.0.0.0/121"),
+			b:           _p("::ffff:10.0.0.128/121"),
+			relation:    RelationDisjoint,
+		}, {
+			description: "address",
+			a:           _a("::ffff:10.0.0.1"),
+			b:           _p("::ffff:10.0.0.0/120"),
+			relation:    RelationSubset,
+		}, {
+			description: "empty",
+			a:           Set{},
+			b:           nil,
+			relation:    RelationEqual,
+		}, {
+			description: "empty subset",
+			a:           Set{},
+			b:           _p("::ffff:10.0.0.0/120"),
+			relation:    RelationSubset,
+		}, {
+			description: "everything",
+			a:           _p("::/0"),
+			b:           setFromPrefixes("::/1", "8000::/1"),
+			relation:    RelationEqual,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.relation, Relation(tt.a, tt.b))
+
+			var a Set
+			if tt.a != nil {
+				a = tt.a.Set()
+			}
+			overlaps := tt.relation != RelationDisjoint && !a.IsEmpty()
+			assert.Equal(t, overlaps, a.Overlaps(tt.b))
+			assert.Equal(t, !overlaps, a.IsDisjoint(tt.b))
+			subset := tt.relation == RelationSubset || tt.relation == RelationEqual
+			assert.Equal(t, subset, a.IsSubsetOf(tt.b))
+		})
+	}
+}
+
+func TestRelationReversed(t *testing.T) {
+	reversed := map[SetRelation]SetRelation{
+		RelationDisjoint:    RelationDisjoint,
+		RelationOverlapping: RelationOverlapping,
+		RelationSubset:      RelationSuperset,
+		RelationSuperset:    RelationSubset,
+		RelationEqual:       RelationEqual,
+	}
+
+	rand.Seed(29)
+	randomSet := func() Set {
+		s := NewSet_()
+		for i := 0; i < 1+rand.Intn(8); i++ {
+			s.Insert(Prefix{Address{Uint128{0x20010db800000000 | uint64(rand.Uint32()&0xffff), 0}}, uint32(50 + rand.Intn(15))})
+		}
+		return s.Set()
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := randomSet(), randomSet()
+
+		// Compare to the slower operations that build new sets
+		overlaps := !a.Intersection(b).IsEmpty()
+		subset := a.Difference(b).IsEmpty()
+		superset := b.Difference(a).IsEmpty()
+		assert.Equal(t, overlaps, a.Overlaps(b))
+		assert.Equal(t, subset, a.IsSubsetOf(b))
+		assert.Equal(t, superset, a.Contains(b))
+
+		relation := Relation(a, b)
+		assert.Equal(t, reversed[relation], Relation(b, a))
+		switch relation {
+		case RelationEqual:
+			assert.True(t, a.Equal(b))
+		case RelationSubset:
+			assert.True(t, subset && !superset)
+		case RelationSuperset:
+			assert.True(t, superset && !subset)
+		case RelationOverlapping:
+			assert.True(t, overlaps && !subset && !superset)
+		case RelationDisjoint:
+			assert.False(t, overlaps)
+		}
+	}
+}
+
+func TestRelationAllocs(t *testing.T) {
+	a := setFromPrefixes("::ffff:10.0.0.0/120", "::ffff:10.0.2.0/120", "::ffff:192.0.2.0/121")
+	b := setFromPrefixes("::ffff:10.0.0.0/121", "::ffff:10.0.4.0/120", "::ffff:192.0.2.128/121")
+	var p, addr SetI = _p("::ffff:10.0.2.0/119"), _a("::ffff:192.0.2.17")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = a.Overlaps(b)
+		_ = a.IsDisjoint(p)
+		_ = a.IsSubsetOf(addr)
+		_ = a.Contains(addr)
+		_ = a.Contains(p)
+		_ = Relation(a, b)
+		_ = Relation(p, a)
+		_ = Relation(addr, p)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestSetRelationString(t *testing.T) {
+	assert.Equal(t, "disjoint", RelationDisjoint.String())
+	assert.Equal(t, "overlapping", RelationOverlapping.String())
+	assert.Equal(t, "subset", RelationSubset.String())
+	assert.Equal(t, "superset", RelationSuperset.String())
+	assert.Equal(t, "equal", RelationEqual.String())
+	assert.Equal(t, "unknown", SetRelation(-1).String())
+}
@@ -0,0 +1,117 @@
+package ipv6
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reverseDomain is the domain under which reverse DNS names for IPv6 live
+const reverseDomain = "ip6.arpa."
+
+// ReverseZone is a DNS zone in ip6.arpa which holds the PTR records for a
+// block of addresses
+type ReverseZone struct {
+	// Prefix is the block of addresses whose PTR records are in the zone
+	Prefix Prefix
+	// Name is the fully qualified name of the zone (e.g.
+	// "8.b.d.0.1.0.0.2.ip6.arpa.")
+	Name string
+}
+
+// RecordName returns the owner name of the PTR record for the given address
+// in this zone. It is the same as addr.ReverseName() and exists for symmetry
+// with ipv4.ReverseZone.
+func (me ReverseZone) RecordName(addr Address) string {
+	return addr.ReverseName()
+}
+
+// ReverseName returns the fully qualified name of the PTR record for this
+// address which has one label for each of its 32 nibbles (e.g.
+// "1.0.0.0.[...].8.b.d.0.1.0.0.2.ip6.arpa.")
+func (me Address) ReverseName() string {
+	return Prefix{me, 128}.reverseZoneName()
+}
+
+// reverseZoneName returns the name of the zone for this prefix. The length
+// must be a multiple of 4.
+func (me Prefix) reverseZoneName() string {
+	const digits = "0123456789abcdef"
+
+	nibbles := int(me.length) / 4
+	b := make([]byte, 0, 2*nibbles+len(reverseDomain))
+	for i := nibbles - 1; i >= 0; i-- {
+		nibble := me.addr.ui.rightShift(124-4*i).low & 0xf
+		b = append(b, digits[nibble], '.')
+	}
+	return string(append(b, reverseDomain...))
+}
+
+// ReverseZones returns the reverse zones needed to delegate the addresses in
+// this prefix. A prefix whose length is not a multiple of 4 is split into the
+// zones at the next nibble boundary (e.g. a /62 needs four /64 zones). Host
+// bits are ignored.
+func (me Prefix) ReverseZones() []ReverseZone {
+	network := me.Network()
+	length := (me.length + 3) / 4 * 4
+	zones := make([]ReverseZone, 0, 1<<(length-me.length))
+	for i := uint64(0); i < 1<<(length-me.length); i++ {
+		zone := Prefix{
+			Address{network.addr.ui.or(Uint128{0, i}.leftShift(int(128 - length)))},
+			length,
+		}
+		zones = append(zones, ReverseZone{zone, zone.reverseZoneName()})
+	}
+	return zones
+}
+
+// AddressFromReverseName parses the name of a PTR record in ip6.arpa which
+// must have all 32 nibbles. The trailing dot is optional and case is ignored.
+func AddressFromReverseName(name string) (Address, error) {
+	prefix, err := PrefixFromReverseName(name)
+	if err != nil {
+		return Address{}, err
+	}
+	if prefix.length != 128 {
+		return Address{}, fmt.Errorf("reverse name %q does not contain a complete address", name)
+	}
+	return prefix.addr, nil
+}
+
+// PrefixFromReverseName parses the name of a reverse zone in ip6.arpa (e.g.
+// "8.b.d.0.1.0.0.2.ip6.arpa." is 2001:db8::/32). Each nibble adds 4 bits to
+// the length. The name of a single address gives a /128. The trailing dot is
+// optional and case is ignored.
+func PrefixFromReverseName(name string) (Prefix, error) {
+	fail := func(reason string) (Prefix, error) {
+		return Prefix{}, fmt.Errorf("failed to parse reverse name %q: %s", name, reason)
+	}
+
+	s := strings.ToLower(strings.TrimSuffix(name, "."))
+	domain := strings.TrimSuffix(reverseDomain, ".")
+	var labels []string
+	switch {
+	case s == domain:
+	case strings.HasSuffix(s, "."+domain):
+		labels = strings.Split(strings.TrimSuffix(s, "."+domain), ".")
+	default:
+		return fail("not in " + reverseDomain)
+	}
+	if len(labels) > 32 {
+		return fail("too many labels")
+	}
+
+	var prefix Prefix
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := labels[i]
+		if len(label) != 1 {
+			return fail(fmt.Sprintf("invalid nibble %q", label))
+		}
+		nibble, ok := hexDigit(label[0])
+		if !ok {
+			return fail(fmt.Sprintf("invalid nibble %q", label))
+		}
+		prefix.addr.ui = prefix.addr.ui.or(Uint128{0, uint64(nibble)}.leftShift(int(124 - prefix.length)))
+		prefix.length += 4
+	}
+	return prefix, nil
+}
@@ -0,0 +1,131 @@
+package ipv6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		address Address
+		name    string
+	}{
+		{_a("2001:db8::567:89ab"), "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+		{_a("::"), "0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."},
+		{_a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), "f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.f.ip6.arpa."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.address.ReverseName())
+
+			addr, err := AddressFromReverseName(tt.name)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addr)
+		})
+	}
+}
+
+func TestAddressFromReverseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		valid   bool
+	}{
+		{"B.A.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.IP6.ARPA", _a("2001:db8::567:89ab"), true},
+		{"8.b.d.0.1.0.0.2.ip6.arpa.", Address{}, false},
+		{"0.b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", Address{}, false},
+		{"ba.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", Address{}, false},
+		{"g.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", Address{}, false},
+		{"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.in-addr.arpa.", Address{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := AddressFromReverseName(tt.name)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.address, addr)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestPrefixFromReverseName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix Prefix
+		valid  bool
+	}{
+		{"ip6.arpa.", _p("::/0"), true},
+		{"2.ip6.arpa", _p("2000::/4"), true},
+		{"8.b.d.0.1.0.0.2.ip6.arpa.", _p("2001:db8::/32"), true},
+		{"1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", _p("2001:db8:1::/48"), true},
+		{"8.b.d.0.1.0.0..ip6.arpa.", Prefix{}, false},
+		{"xip6.arpa.", Prefix{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, err := PrefixFromReverseName(tt.name)
+			if tt.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.prefix, prefix)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestReverseZones(t *testing.T) {
+	tests := []struct {
+		prefix Prefix
+		names  []string
+	}{
+		{_p("::/0"), []string{"ip6.arpa."}},
+		{_p("2001:db8::/32"), []string{"8.b.d.0.1.0.0.2.ip6.arpa."}},
+		{_p("2001:db8::1/32"), []string{"8.b.d.0.1.0.0.2.ip6.arpa."}},
+		{_p("2001:db8::/31"), []string{"8.b.d.0.1.0.0.2.ip6.arpa.", "9.b.d.0.1.0.0.2.ip6.arpa."}},
+		{_p("2001:db8:4::/46"), []string{
+			"4.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"5.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"6.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"7.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			zones := tt.prefix.ReverseZones()
+			var names []string
+			set := NewSet_()
+			for _, zone := range zones {
+				names = append(names, zone.Name)
+				set.Insert(zone.Prefix)
+
+				prefix, err := PrefixFromReverseName(zone.Name)
+				assert.Nil(t, err)
+				assert.Equal(t, zone.Prefix, prefix)
+			}
+			assert.Equal(t, tt.names, names)
+			assert.True(t, tt.prefix.Network().Set().Equal(set.Set()))
+		})
+	}
+
+	t.Run("sizes", func(t *testing.T) {
+		for length := 0; length <= 128; length++ {
+			mask, _ := MaskFromLength(length)
+			zones := PrefixFromAddressMask(_a("2001:db8::1"), mask).ReverseZones()
+			assert.Len(t, zones, 1<<((4-length%4)%4), "/%d", length)
+		}
+	})
+}
+
+func TestReverseZoneRecordName(t *testing.T) {
+	zone := _p("2001:db8::/32").ReverseZones()[0]
+	assert.Equal(t, _a("2001:db8::1").ReverseName(), zone.RecordName(_a("2001:db8::1")))
+}
@@ -1,8 +1,10 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 )
 
@@ -89,6 +91,55 @@ func (me Set_) Remove(other SetI) {
 	})
 }
 
+// NumAddresses returns the number of IP addresses
+func (me Set_) NumAddresses() *big.Int {
+	if me.s == nil {
+		return new(big.Int)
+	}
+	return me.s.NumAddresses()
+}
+
+// Toggle inserts the addresses in the given set which are not already in this
+// one and removes those which are. It is effectively a SymmetricDifference
+// with the other set in place.
+func (me Set_) Toggle(other SetI) {
+	if me.s == nil {
+		panic("cannot modify an unitialized Set_")
+	}
+	if other == nil {
+		other = Set{}
+	}
+	me.mutate(func() (bool, *setNode) {
+		return true, me.s.trie.SymmetricDifference(other.Set().trie)
+	})
+}
+
+// Invert replaces the contents of the set with every address that was not in
+// it. It is effectively a Complement in place.
+func (me Set_) Invert() {
+	if me.s == nil {
+		panic("cannot modify an unitialized Set_")
+	}
+	me.mutate(func() (bool, *setNode) {
+		return true, me.s.trie.Complement(Prefix{})
+	})
+}
+
+// InvertWithin replaces the contents of the set with the addresses in the
+// given universe that were not in it. It is effectively a ComplementWithin in
+// place.
+func (me Set_) InvertWithin(universe SetI) {
+	if me.s == nil {
+		panic("cannot modify an unitialized Set_")
+	}
+	if universe == nil {
+		universe = Set{}
+	}
+	me.mutate(func() (bool, *setNode) {
+		return true, universe.Set().trie.Difference(me.s.trie)
+	})
+}
+
 // IsEmpty returns whether the number of IP addresses is equal to zero
 func (me Set_) IsEmpty() bool {
 	if me.s == nil {
@@ -151,6 +202,36 @@ func (me Set_) Difference(other SetI) Set {
 	return me.s.Difference(other)
 }
 
+// SymmetricDifference returns a new fixed set with all addresses that appear
+// in exactly one of the two sets
+func (me Set_) SymmetricDifference(other SetI) Set {
+	if other == nil {
+		other = Set{}
+	}
+	if me.s == nil {
+		return other.Set()
+	}
+	return me.s.SymmetricDifference(other)
+}
+
+// Complement returns a new fixed set with all addresses that do not appear in
+// this set
+func (me Set_) Complement() Set {
+	if me.s == nil {
+		return Set{}.Complement()
+	}
+	return me.s.Complement()
+}
+
+// ComplementWithin returns a new fixed set with all addresses in the given
+// universe that do not appear in this set
+func (me Set_) ComplementWithin(universe SetI) Set {
+	if me.s == nil {
+		return Set{}.ComplementWithin(universe)
+	}
+	return me.s.ComplementWithin(universe)
+}
+
 // Set is a structure that efficiently stores sets of addresses and supports
 // testing if an address or prefix is contained (entirely) in it. It supports
 // the standard set operations: union, intersection, and difference. It
@@ -174,6 +255,31 @@ var _ SetI = Range{}
 var _ SetI = Set_{}
 var _ SetI = Set{}
 
+// SetFromString returns the Set represented by `set` in the format produced by
+// String() -- a bracketed, comma-separated list of prefixes in CIDR notation
+// (e.g. "[10.0.0.0/8, 192.168.0.0/16]"). The prefixes do not need to be
+// disjoint, ordered, or minimal. If it cannot be parsed, then error is non-nil
+// and the Set returned must be ignored.
+func SetFromString(set string) (Set, error) {
+	set = strings.TrimSpace(set)
+	if !strings.HasPrefix(set, "[") || !strings.HasSuffix(set, "]") {
+		return Set{}, fmt.Errorf("failed to parse set: %s", set)
+	}
+	inner := strings.TrimSpace(set[1 : len(set)-1])
+	if inner == "" {
+		return Set{}, nil
+	}
+	s_ := NewSet_()
+	for _, str := range strings.Split(inner, ",") {
+		prefix, err := PrefixFromString(strings.TrimSpace(str))
+		if err != nil {
+			return Set{}, err
+		}
+		s_.Insert(prefix)
+	}
+	return s_.Set(), nil
+}
+
 // Set_ returns a Set_ initialized with the contents of the fixed set
 func (me Set) Set_() Set_ {
 	return Set_{
@@ -193,6 +299,12 @@ func (me Set) IsEmpty() bool {
 	return me.trie.IsEmpty()
 }
 
+// NumAddresses returns the number of IP addresses. It is returned as a
+// *big.Int because a set containing every address has 2^128 of them.
+func (me Set) NumAddresses() *big.Int {
+	return me.trie.NumAddresses()
+}
+
 // WalkPrefixes calls `callback` for each prefix stored in lexographical
 // order. It stops iteration immediately if callback returns false. It always
 // uses the largest prefixes possible so if two prefixes are adjacent and can
@@ -225,6 +337,53 @@ func (me Set) String() string {
 	return builder.String()
 }
 
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me Set) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using SetFromString
+func (me *Set) UnmarshalText(text []byte) error {
+	set, err := SetFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = set
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The set is encoded as a JSON array of
+// strings containing the same prefixes, in CIDR notation, as String().
+func (me Set) MarshalJSON() ([]byte, error) {
+	prefixes := []Prefix{}
+	me.WalkPrefixes(func(p Prefix) bool {
+		prefixes = append(prefixes, p)
+		return true
+	})
+	return json.Marshal(prefixes)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of
+// strings, each a prefix in CIDR notation. As with SetFromString, the prefixes
+// do not need to be disjoint, ordered, or minimal. A JSON null leaves the set
+// unchanged.
+func (me *Set) UnmarshalJSON(data []byte) error {
+	var prefixes []Prefix
+	if err := json.Unmarshal(data, &prefixes); err != nil {
+		return err
+	}
+	if prefixes == nil {
+		return nil
+	}
+	s_ := NewSet_()
+	for _, prefix := range prefixes {
+		s_.Insert(prefix)
+	}
+	*me = s_.Set()
+	return nil
+}
+
 // NumPrefixes returns the number of prefixes of the given prefix length in
 // this set.
 func (me Set) NumPrefixes(length uint32) (count uint64, err error) {
@@ -285,13 +444,41 @@ func (me Set) Equal(other Set) bool {
 	return me.trie.Equal(other.trie)
 }
 
-// Contains tests if the given prefix is entirely contained in the set
-func (me Set) Contains(other SetI) bool {
-	if other == nil {
-		other = Set{}
+// Diff invokes the given callbacks with the minimal set of prefixes that were
+// added or removed to get from this set to the other, each in lexigraphical
+// order. `added` is called with prefixes covering the addresses that are only
+// in the other set and `removed` with those that are only in this one.
+//
+// It is safe to pass nil for either callback. Subtrees that the two sets share
+// are skipped without visiting them so, when one set is derived from the
+// other, it takes time proportional to the size of the changes.
+//
+// It returns false if iteration was stopped due to a callback returning false
+// or true if it iterated all items.
+func (me Set) Diff(other Set, added, removed func(Prefix) bool) bool {
+	noop := func(Prefix) bool {
+		return true
+	}
+	if added == nil {
+		added = noop
+	}
+	if removed == nil {
+		removed = noop
 	}
-	// NOTE This is the not the most efficient way to do this
-	return other.Set().Difference(me).IsEmpty()
+	return me.trie.Diff(other.trie, added, removed)
+}
+
+// Contains tests if the given prefix is entirely contained in the set. It
+// doesn't allocate unless it is passed a Range.
+func (me Set) Contains(other SetI) bool {
+	var node setNode
+	return trieOf(other, &node).IsSubsetOf(me.trie)
+}
+
+// containsAddress returns true if the address is in the set. Unlike Contains,
+// it doesn't allocate.
+func (me Set) containsAddress(addr Address) bool {
+	return me.trie.Match(addr.Prefix()) != nil
 }
 
 // Union returns a new set with all addresses from both sets
@@ -325,6 +512,37 @@ func (me Set) Difference(other SetI) Set {
 	}
 }
 
+// SymmetricDifference returns a new set with all addresses that appear in
+// exactly one of the two sets
+func (me Set) SymmetricDifference(other SetI) Set {
+	if other == nil {
+		other = Set{}
+	}
+	return Set{
+		trie: me.trie.SymmetricDifference(other.Set().trie),
+	}
+}
+
+// Complement returns a new set with all addresses that do not appear in this
+// set
+func (me Set) Complement() Set {
+	return Set{
+		trie: me.trie.Complement(Prefix{}),
+	}
+}
+
+// ComplementWithin returns a new set with all addresses in the given universe
+// that do not appear in this set. It is the same as the universe's Difference
+// with this set.
+func (me Set) ComplementWithin(universe SetI) Set {
+	if universe == nil {
+		universe = Set{}
+	}
+	return Set{
+		trie: universe.Set().trie.Difference(me.trie),
+	}
+}
+
 func (me Set) isValid() bool {
 	return me.trie.isValid()
 }
@@ -1,6 +1,7 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"math"
 	"math/rand"
 	"strconv"
@@ -725,7 +726,7 @@ func TestOldEqualAllIPv6(t *testing.T) {
 	// This list was constructed starting with ::/128 and ::1/128,
 	// then adding ::2/127, ::4/126, ..., 128::/1
 	bNets := []Prefix{_p("::/128")}
-	mask := uint128{0x8000000000000000, 0}
+	mask := Uint128{0x8000000000000000, 0}
 	for length := 129; length > 0; length-- {
 		prefix := Prefix{Address{ui: mask.rightShift(length - 1)}, uint32(length)}
 		bNets = append(bNets, prefix)
@@ -741,7 +742,7 @@ func TestOldEqualAllIPv6(t *testing.T) {
 
 	// Constructed a different way
 	cNets := []Prefix{_p("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128")}
-	mask = uint128{0xffffffffffffffff, 0xffffffffffffffff}
+	mask = Uint128{0xffffffffffffffff, 0xffffffffffffffff}
 	for length := 128; length > 0; length-- {
 		prefix := Prefix{Address{ui: mask.leftShift(128 - (length - 1))}, uint32(length)}
 		cNets = append(cNets, prefix)
@@ -1128,3 +1129,447 @@ func countPrefixes(s Set) int {
 	})
 	return numPrefixes
 }
+
+func TestSetFromString(t *testing.T) {
+	tests := []struct {
+		description string
+		str         string
+		set         Set
+		isErr       bool
+	}{
+		{
+			description: "empty",
+			str:         "[]",
+			set:         Set{},
+		}, {
+			description: "one",
+			str:         "[2001:db8::/32]",
+			set:         _p("2001:db8::/32").Set(),
+		}, {
+			description: "unordered and overlapping",
+			str:         "[ 2001:db8:1::/48,2001:db8::/48 , 2001:db8::/49 ]",
+			set:         _p("2001:db8::/47").Set(),
+		}, {
+			description: "no brackets",
+			str:         "2001:db8::/32",
+			isErr:       true,
+		}, {
+			description: "bad prefix",
+			str:         "[2001:db8::/32, 2001:db8::/129]",
+			isErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			set, err := SetFromString(tt.str)
+			if tt.isErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, tt.set.Equal(set))
+		})
+	}
+}
+
+func TestSetStringRoundTrip(t *testing.T) {
+	s := Set{}.Build(func(s_ Set_) bool {
+		s_.Insert(_p("2001:db8::/32"))
+		s_.Insert(_p("fd00::/8"))
+		s_.Remove(_p("2001:db8:1::/48"))
+		return true
+	})
+
+	var decoded Set
+	text, err := s.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, s.String(), string(text))
+	assert.Nil(t, decoded.UnmarshalText(text))
+	assert.True(t, s.Equal(decoded))
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	s := Set{}.Build(func(s_ Set_) bool {
+		s_.Insert(_p("2001:db8::/32"))
+		s_.Insert(_p("fd00::/8"))
+		return true
+	})
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, `["2001:db8::/32","fd00::/8"]`, string(data))
+
+	data, err = json.Marshal(Set{})
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	var decoded Set
+	assert.Nil(t, json.Unmarshal([]byte(`["fd00::/9","2001:db8::/32","fd80::/9"]`), &decoded))
+	assert.True(t, s.Equal(decoded))
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &decoded))
+	assert.True(t, s.Equal(decoded))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`["2001:db8::"]`), &decoded))
+}
+
+func TestSetNumAddresses(t *testing.T) {
+	tests := []struct {
+		description string
+		prefixes    []SetI
+		count       string
+	}{
+		{
+			description: "empty set",
+			prefixes:    []SetI{},
+			count:       "0",
+		}, {
+			description: "single address",
+			prefixes:    []SetI{_a("2001:db8::1")},
+			count:       "1",
+		}, {
+			description: "/48",
+			prefixes:    []SetI{_p("2001:db8::/48")},
+			count:       "1208925819614629174706176",
+		}, {
+			description: "disjoint",
+			prefixes:    []SetI{_p("2001:db8::/64"), _p("2001:db8:1::/64"), _a("::1")},
+			count:       "36893488147419103233",
+		}, {
+			description: "halves",
+			prefixes:    []SetI{_p("::/1"), _p("8000::/1")},
+			count:       "340282366920938463463374607431768211456",
+		}, {
+			description: "everything",
+			prefixes:    []SetI{_p("::/0")},
+			count:       "340282366920938463463374607431768211456",
+		}, {
+			description: "all but one",
+			prefixes:    []SetI{Range{_a("::1"), _a("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")}},
+			count:       "340282366920938463463374607431768211455",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			s := NewSet_()
+			for _, p := range tt.prefixes {
+				s.Insert(p)
+			}
+			assert.Equal(t, tt.count, s.NumAddresses().String())
+			assert.Equal(t, tt.count, s.Set().NumAddresses().String())
+		})
+	}
+	assert.Equal(t, "0", Set_{}.NumAddresses().String())
+}
+
+func TestSetSymmetricDifferenceNil(t *testing.T) {
+	assert.True(t, Set_{}.SymmetricDifference(nil).IsEmpty())
+	assert.True(t, Set{}.SymmetricDifference(nil).IsEmpty())
+}
+
+func TestSetComplementNil(t *testing.T) {
+	assert.True(t, Set_{}.Complement().Contains(_p("::/0")))
+	assert.True(t, Set{}.Complement().Contains(_p("::/0")))
+	assert.True(t, Set_{}.ComplementWithin(nil).IsEmpty())
+	assert.True(t, Set{}.ComplementWithin(nil).IsEmpty())
+}
+
+func TestSetSymmetricDifference(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        Set
+		expected    Set
+	}{
+		{
+			description: "disjoint",
+			a:           setFromPrefixes("2001:db8::/48"),
+			b:           setFromPrefixes("2001:db8:2::/48"),
+			expected:    setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+		}, {
+			description: "same",
+			a:           setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+			b:           setFromPrefixes("2001:db8::/49", "2001:db8:0:8000::/49", "2001:db8:2::/48"),
+			expected:    Set{},
+		}, {
+			description: "contained",
+			a:           setFromPrefixes("2001:db8::/32"),
+			b:           setFromPrefixes("2001:db8::/33"),
+			expected:    setFromPrefixes("2001:db8:8000::/33"),
+		}, {
+			description: "overlapping",
+			a:           setFromPrefixes("2001:db8::/47"),
+			b:           setFromPrefixes("2001:db8:1::/48", "2001:db8:2::/48"),
+			expected:    setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+		}, {
+			description: "everything",
+			a:           setFromPrefixes("::/0"),
+			b:           setFromPrefixes("8000::/1"),
+			expected:    setFromPrefixes("::/1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := tt.a.SymmetricDifference(tt.b)
+			assert.True(t, result.isValid())
+			assert.True(t, tt.expected.Equal(result), result.String())
+			assert.True(t, tt.expected.Equal(tt.b.SymmetricDifference(tt.a)))
+		})
+	}
+}
+
+func TestSetComplement(t *testing.T) {
+	tests := []struct {
+		description string
+		set         Set
+		expected    Set
+	}{
+		{
+			description: "empty",
+			set:         Set{},
+			expected:    setFromPrefixes("::/0"),
+		}, {
+			description: "everything",
+			set:         setFromPrefixes("::/0"),
+			expected:    Set{},
+		}, {
+			description: "half",
+			set:         setFromPrefixes("::/1"),
+			expected:    setFromPrefixes("8000::/1"),
+		}, {
+			description: "global unicast",
+			set:         setFromPrefixes("2000::/3"),
+			expected:    setFromPrefixes("::/3", "4000::/2", "8000::/1"),
+		}, {
+			description: "unique local and link local",
+			set:         setFromPrefixes("fc00::/7", "fe80::/10"),
+			expected:    setFromPrefixes("::/1", "8000::/2", "c000::/3", "e000::/4", "f000::/5", "f800::/6", "fe00::/9", "fec0::/10", "ff00::/8"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := tt.set.Complement()
+			assert.True(t, result.isValid())
+			assert.True(t, tt.expected.Equal(result), result.String())
+			assert.True(t, tt.set.Equal(result.Complement()))
+		})
+	}
+}
+
+func TestSetComplementWithin(t *testing.T) {
+	universe := setFromPrefixes("2001:db8::/48", "fd00::/64")
+	set := setFromPrefixes("2001:db8::/49", "fd00::/65", "fe80::/10")
+
+	result := set.ComplementWithin(universe)
+	assert.True(t, result.isValid())
+	assert.True(t, setFromPrefixes("2001:db8:0:8000::/49", "fd00::8000:0:0:0/65").Equal(result), result.String())
+}
+
+func TestSetSymmetricDifferenceRandom(t *testing.T) {
+	rand.Seed(29)
+	randomSet := func() Set {
+		s := NewSet_()
+		for i := 0; i < 1+rand.Intn(8); i++ {
+			s.Insert(Prefix{Address{Uint128{0x20010db800000000 | uint64(rand.Uint32()&0xffff), 0}}, uint32(50 + rand.Intn(15))})
+		}
+		return s.Set()
+	}
+	universe := setFromPrefixes("2001:db8::/48")
+	for i := 0; i < 1000; i++ {
+		a, b := randomSet(), randomSet()
+
+		// Compare to composing the basic operations
+		expected := a.Difference(b).Union(b.Difference(a))
+		result := a.SymmetricDifference(b)
+		assert.True(t, result.isValid())
+		assert.True(t, expected.Equal(result))
+
+		complement := a.Complement()
+		assert.True(t, complement.isValid())
+		assert.True(t, complement.Intersection(a).IsEmpty())
+		assert.True(t, complement.Union(a).Contains(_p("::/0")))
+		assert.True(t, universe.Difference(a).Equal(a.ComplementWithin(universe)))
+	}
+}
+
+func TestSetToggle(t *testing.T) {
+	s := NewSet_()
+	s.Insert(_p("2001:db8::/47"))
+	s.Toggle(setFromPrefixes("2001:db8:1::/48", "2001:db8:2::/48"))
+	assert.True(t, setFromPrefixes("2001:db8::/48", "2001:db8:2::/48").Equal(s.Set()))
+
+	s.Toggle(s.Set())
+	assert.True(t, s.IsEmpty())
+
+	s.Toggle(nil)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSetInvert(t *testing.T) {
+	s := NewSet_()
+	s.Invert()
+	assert.True(t, s.Contains(_p("::/0")))
+
+	s.Remove(_p("2001:db8::/32"))
+	s.Invert()
+	assert.True(t, setFromPrefixes("2001:db8::/32").Equal(s.Set()))
+
+	s.InvertWithin(_p("2001:db8::/31"))
+	assert.True(t, setFromPrefixes("2001:db9::/32").Equal(s.Set()))
+
+	s.InvertWithin(nil)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSetDiff(t *testing.T) {
+	tests := []struct {
+		description    string
+		a, b           Set
+		added, removed []Prefix
+	}{
+		{
+			description: "empty",
+		}, {
+			description: "same",
+			a:           setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+			b:           setFromPrefixes("2001:db8::/49", "2001:db8:0:8000::/49", "2001:db8:2::/48"),
+		}, {
+			description: "all added",
+			b:           setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+			added:       []Prefix{_p("2001:db8::/48"), _p("2001:db8:2::/48")},
+		}, {
+			description: "all removed",
+			a:           setFromPrefixes("2001:db8::/48", "2001:db8:2::/48"),
+			removed:     []Prefix{_p("2001:db8::/48"), _p("2001:db8:2::/48")},
+		}, {
+			description: "disjoint",
+			a:           setFromPrefixes("2001:db8:2::/48"),
+			b:           setFromPrefixes("2001:db8::/48"),
+			added:       []Prefix{_p("2001:db8::/48")},
+			removed:     []Prefix{_p("2001:db8:2::/48")},
+		}, {
+			description: "split",
+			a:           setFromPrefixes("2001:db8::/46"),
+			b:           setFromPrefixes("2001:db8:1::/48", "2001:db8:2::/47"),
+			removed:     []Prefix{_p("2001:db8::/48")},
+		}, {
+			description: "merged",
+			a:           setFromPrefixes("2001:db8:1::/48", "2001:db8:2::/47"),
+			b:           setFromPrefixes("2001:db8::/46"),
+			added:       []Prefix{_p("2001:db8::/48")},
+		}, {
+			description: "both",
+			a:           setFromPrefixes("2001:db8::/47", "fd00::/64"),
+			b:           setFromPrefixes("2001:db8:1::/48", "2001:db8:2::/48", "fd00::/63"),
+			added:       []Prefix{_p("2001:db8:2::/48"), _p("fd00:0:0:1::/64")},
+			removed:     []Prefix{_p("2001:db8::/48")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var added, removed []Prefix
+			assert.True(t, tt.a.Diff(tt.b,
+				func(p Prefix) bool {
+					added = append(added, p)
+					return true
+				},
+				func(p Prefix) bool {
+					removed = append(removed, p)
+					return true
+				},
+			))
+			assert.Equal(t, tt.added, added)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestSetDiffNil(t *testing.T) {
+	a := setFromPrefixes("2001:db8::/48")
+	b := setFromPrefixes("2001:db8:2::/48")
+	assert.True(t, a.Diff(b, nil, nil))
+
+	var removed []Prefix
+	assert.True(t, a.Diff(b, nil, func(p Prefix) bool {
+		removed = append(removed, p)
+		return true
+	}))
+	assert.Equal(t, []Prefix{_p("2001:db8::/48")}, removed)
+}
+
+func TestSetDiffStop(t *testing.T) {
+	a := setFromPrefixes("2001:db8::/48", "2001:db8:2::/48")
+	count := 0
+	assert.False(t, Set{}.Diff(a,
+		func(p Prefix) bool {
+			count++
+			return false
+		}, nil,
+	))
+	assert.Equal(t, 1, count)
+}
+
+func TestSetDiffShared(t *testing.T) {
+	s := NewSet_()
+	for i := 0; i < 1000; i++ {
+		s.Insert(Prefix{Address{Uint128{0x20010db800000000 | uint64(i)<<16, 0}}, 48})
+	}
+	a := s.Set()
+	b := a.Build(func(s Set_) bool {
+		s.Remove(_p("2001:db8:7::/48"))
+		s.Insert(_p("fd00::/16"))
+		return true
+	})
+
+	var added, removed []Prefix
+	a.Diff(b,
+		func(p Prefix) bool {
+			added = append(added, p)
+			return true
+		},
+		func(p Prefix) bool {
+			removed = append(removed, p)
+			return true
+		},
+	)
+	assert.Equal(t, []Prefix{_p("fd00::/16")}, added)
+	assert.Equal(t, []Prefix{_p("2001:db8:7::/48")}, removed)
+}
+
+func TestSetDiffRandom(t *testing.T) {
+	rand.Seed(29)
+	randomSet := func() Set {
+		s := NewSet_()
+		for i := 0; i < 1+rand.Intn(8); i++ {
+			s.Insert(Prefix{Address{Uint128{0x20010db800000000 | uint64(rand.Uint32()&0xffff), 0}}, uint32(50 + rand.Intn(15))})
+		}
+		return s.Set()
+	}
+	collect := func(s Set) (prefixes []Prefix) {
+		s.WalkPrefixes(func(p Prefix) bool {
+			prefixes = append(prefixes, p)
+			return true
+		})
+		return
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := randomSet(), randomSet()
+
+		var added, removed []Prefix
+		a.Diff(b,
+			func(p Prefix) bool {
+				added = append(added, p)
+				return true
+			},
+			func(p Prefix) bool {
+				removed = append(removed, p)
+				return true
+			},
+		)
+		assert.Equal(t, collect(b.Difference(a)), added)
+		assert.Equal(t, collect(a.Difference(b)), removed)
+	}
+}
@@ -2,6 +2,7 @@ package ipv6
 
 import (
 	"fmt"
+	"math/big"
 )
 
 // setNode is currently the same data structure as trieNode. However,
@@ -15,6 +16,7 @@ func setNodeFromPrefix(p Prefix) *setNode {
 	return &setNode{
 		isActive: true,
 		Prefix:   p,
+		numAddrs: p.numAddresses(),
 		size:     1,
 		h:        1,
 	}
@@ -37,8 +39,8 @@ func setNodeFromRange(r Range) *setNode {
 	// prefixes in the range. The result is the smallest set of prefixes that
 	// covers it. It takes Log(p) time where p is the number of prefixes in the
 	// result -- bounded by 128 x 2 in the worst case
-	pivot := r.first.ui.and(uint128{0xffffffffffffffff, 0xffffffffffffffff}.leftShift(128 - numCommonBits))
-	pivot = pivot.or(uint128{0x8000000000000000, 0}.rightShift(numCommonBits))
+	pivot := r.first.ui.and(Uint128{0xffffffffffffffff, 0xffffffffffffffff}.leftShift(128 - numCommonBits))
+	pivot = pivot.or(Uint128{0x8000000000000000, 0}.rightShift(numCommonBits))
 
 	a := setNodeFromRange(Range{r.first, Address{pivot.subtractUint64(1)}})
 	b := setNodeFromRange(Range{Address{pivot}, r.last})
@@ -169,7 +171,7 @@ func (me *setNode) Union(other *setNode) (rc *setNode) {
 		newHead := &setNode{
 			Prefix: Prefix{
 				addr: Address{
-					ui: me.Prefix.addr.ui.and(uint128{0xffffffffffffffff, 0xffffffffffffffff}.rightShift(int(common)).complement()), // zero out bits not in common
+					ui: me.Prefix.addr.ui.and(Uint128{0xffffffffffffffff, 0xffffffffffffffff}.rightShift(int(common)).complement()), // zero out bits not in common
 				},
 				length: common,
 			},
@@ -245,6 +247,148 @@ func (me *setNode) Difference(other *setNode) (rc *setNode) {
 	panic("unreachable")
 }
 
+// SymmetricDifference returns the flattened set of prefixes in exactly one
+// of the two sets. Subtrees shared by both cancel out without being visited.
+func (me *setNode) SymmetricDifference(other *setNode) *setNode {
+	if me == other {
+		return nil
+	}
+	if other == nil {
+		return me
+	}
+	if me == nil {
+		return other
+	}
+
+	result, reversed, _, child := compare(me.Prefix, other.Prefix)
+	switch result {
+	case compareDisjoint:
+		return me.Union(other)
+	case compareSame:
+		switch {
+		case me.isActive && other.isActive:
+			return nil
+		case me.isActive:
+			return me.Difference(other)
+		case other.isActive:
+			return other.Difference(me)
+		}
+		return me.Left().SymmetricDifference(other.Left()).Union(
+			me.Right().SymmetricDifference(other.Right()),
+		)
+	}
+
+	super, sub := me, other
+	if reversed {
+		super, sub = sub, super
+	}
+	if super.isActive {
+		return super.Difference(sub)
+	}
+	if child == 1 {
+		return super.Left().Union(super.Right().SymmetricDifference(sub))
+	}
+	return super.Left().SymmetricDifference(sub).Union(super.Right())
+}
+
+// Complement returns the flattened set of prefixes covering the addresses in
+// the given prefix which are not in this set
+func (me *setNode) Complement(prefix Prefix) *setNode {
+	if me == nil {
+		return setNodeFromPrefix(prefix)
+	}
+
+	result, _, _, child := compare(prefix, me.Prefix)
+	switch result {
+	case compareDisjoint:
+		return setNodeFromPrefix(prefix)
+	case compareSame:
+		if me.isActive {
+			return nil
+		}
+		a, b := prefix.Halves()
+		return me.Left().Complement(a).Union(me.Right().Complement(b))
+	case compareContains:
+		// `me` is within one half of the prefix so the other half is whole
+		a, b := prefix.Halves()
+		halves := [2]Prefix{a, b}
+		whole := setNodeFromPrefix(halves[(child+1)%2])
+		return whole.Union(me.Complement(halves[child]))
+	default:
+		if me.isActive {
+			return nil
+		}
+		return (*setNode)(me.children[child]).Complement(prefix)
+	}
+}
+
+// Diff calls `removed` with the minimal prefixes covering the addresses only
+// in this set and `added` with those only in the other set. Subtrees shared
+// by both are skipped without being visited so it takes time proportional to
+// the difference. Neither callback may be nil.
+//
+// It returns false if iteration was stopped due to a callback returning false
+// or true if it iterated all items.
+func (me *setNode) Diff(other *setNode, added, removed func(Prefix) bool) bool {
+	walk := func(n *setNode, callback func(Prefix) bool) bool {
+		return n.Walk(func(prefix Prefix, data interface{}) bool {
+			return callback(prefix)
+		})
+	}
+
+	if me == other {
+		return true
+	}
+	if other == nil {
+		return walk(me, removed)
+	}
+	if me == nil {
+		return walk(other, added)
+	}
+
+	result, reversed, _, child := compare(me.Prefix, other.Prefix)
+	switch result {
+	case compareDisjoint:
+		// `child` is the first differing bit of the longer prefix so the
+		// other node comes first if its bit is zero
+		if (child == 0) != reversed {
+			return walk(other, added) && walk(me, removed)
+		}
+		return walk(me, removed) && walk(other, added)
+	case compareSame:
+		switch {
+		case me.isActive && other.isActive:
+			return true
+		case me.isActive:
+			return walk(me.Difference(other), removed)
+		case other.isActive:
+			return walk(other.Difference(me), added)
+		}
+		return me.Left().Diff(other.Left(), added, removed) &&
+			me.Right().Diff(other.Right(), added, removed)
+	}
+
+	// One contains the other. Only the super node's part is shared.
+	super, sub := me, other
+	superCallback := removed
+	if reversed {
+		super, sub = sub, super
+		superCallback = added
+	}
+	if super.isActive {
+		return walk(super.Difference(sub), superCallback)
+	}
+
+	var halves [2]*setNode
+	halves[child] = sub
+	if reversed {
+		return halves[0].Diff(super.Left(), added, removed) &&
+			halves[1].Diff(super.Right(), added, removed)
+	}
+	return super.Left().Diff(halves[0], added, removed) &&
+		super.Right().Diff(halves[1], added, removed)
+}
+
 // Intersect returns the flattened intersection of prefixes
 func (me *setNode) Intersect(other *setNode) *setNode {
 	if me == nil || other == nil {
@@ -272,12 +416,84 @@ func (me *setNode) Intersect(other *setNode) *setNode {
 	return other
 }
 
+// Overlaps returns true if the two sets have at least one address in common.
+// It stops as soon as it finds one and doesn't allocate.
+func (me *setNode) Overlaps(other *setNode) bool {
+	if me == nil || other == nil {
+		return false
+	}
+
+	result, reversed, _, child := compare(me.Prefix, other.Prefix)
+	switch result {
+	case compareDisjoint:
+		return false
+	case compareSame:
+		if me.isActive || other.isActive {
+			return true
+		}
+		return me.Left().Overlaps(other.Left()) || me.Right().Overlaps(other.Right())
+	}
+
+	super, sub := me, other
+	if reversed {
+		super, sub = sub, super
+	}
+	if super.isActive {
+		return true
+	}
+	return (*setNode)(super.children[child]).Overlaps(sub)
+}
+
+// IsSubsetOf returns true if every address in this set is also in the other.
+// It stops as soon as it finds one that isn't and doesn't allocate. It relies
+// on the trie being flattened so that an inactive node always has two
+// non-empty children.
+func (me *setNode) IsSubsetOf(other *setNode) bool {
+	if me == nil {
+		return true
+	}
+	if other == nil {
+		return false
+	}
+
+	result, _, _, child := compare(me.Prefix, other.Prefix)
+	switch result {
+	case compareDisjoint:
+		return false
+	case compareSame:
+		if other.isActive {
+			return true
+		}
+		if me.isActive {
+			return false
+		}
+		return me.Left().IsSubsetOf(other.Left()) && me.Right().IsSubsetOf(other.Right())
+	case compareContains:
+		// `me` is shorter. Some of its addresses are outside of `other`
+		// unless they are all in the child on other's side.
+		if me.isActive || me.children[(child+1)%2] != nil {
+			return false
+		}
+		return (*setNode)(me.children[child]).IsSubsetOf(other)
+	default:
+		if other.isActive {
+			return true
+		}
+		return me.IsSubsetOf((*setNode)(other.children[child]))
+	}
+}
+
 func (me *setNode) Equal(other *setNode) bool {
 	return (*trieNode)(me).Equal((*trieNode)(other), func(a, b interface{}) bool {
 		return true
 	})
 }
 
+// NumAddresses calls trieNode NumAddresses
+func (me *setNode) NumAddresses() *big.Int {
+	return (*trieNode)(me).NumAddresses()
+}
+
 // IsEmpty calls trieNode IsEmpty
 func (me *setNode) IsEmpty() bool {
 	return (*trieNode)(me).IsEmpty()
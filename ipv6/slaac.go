@@ -0,0 +1,114 @@
+package ipv6
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// checkSLAACPrefix returns an error unless the prefix is a /64 which is the
+// only length SLAAC uses with 64-bit interface identifiers
+func checkSLAACPrefix(prefix Prefix) error {
+	if prefix.length != 64 {
+		return fmt.Errorf("SLAAC prefix must be a /64: %s", prefix)
+	}
+	return nil
+}
+
+// EUI64Address returns the address formed from the given /64 prefix and the
+// modified EUI-64 interface identifier (RFC 4291 appendix A) for the given
+// hardware address. The hardware address may be a 48-bit MAC address, which
+// has ff:fe inserted in the middle, or a 64-bit EUI-64. In both cases, the
+// universal/local bit is inverted.
+func EUI64Address(prefix Prefix, mac net.HardwareAddr) (Address, error) {
+	if err := checkSLAACPrefix(prefix); err != nil {
+		return Address{}, err
+	}
+	var id [8]byte
+	switch len(mac) {
+	case 6:
+		copy(id[:3], mac[:3])
+		id[3], id[4] = 0xff, 0xfe
+		copy(id[5:], mac[3:])
+	case 8:
+		copy(id[:], mac)
+	default:
+		return Address{}, fmt.Errorf("hardware address must be 6 or 8 bytes: %s", mac)
+	}
+	id[0] ^= 0x02
+	return Address{Uint128{prefix.addr.ui.high, binary.BigEndian.Uint64(id[:])}}, nil
+}
+
+// IsEUI64 returns true if this address has a modified EUI-64 interface
+// identifier derived from a 48-bit MAC address (it has ff:fe in the middle).
+// An identifier derived from a 64-bit EUI-64 can't be told apart from any
+// other so it is not detected.
+func (me Address) IsEUI64() bool {
+	return me.ui.low>>24&0xffff == 0xfffe
+}
+
+// HardwareAddr returns the 48-bit MAC address from which this address's
+// modified EUI-64 interface identifier was derived. If IsEUI64 is false, ok is
+// false and the result must be ignored.
+func (me Address) HardwareAddr() (mac net.HardwareAddr, ok bool) {
+	if !me.IsEUI64() {
+		return nil, false
+	}
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], me.ui.low)
+	id[0] ^= 0x02
+	return net.HardwareAddr{id[0], id[1], id[2], id[5], id[6], id[7]}, true
+}
+
+// isReservedInterfaceID returns true if the interface identifier is one of
+// those reserved by RFC 5453 which must not be used by SLAAC
+func isReservedInterfaceID(id uint64) bool {
+	switch {
+	case id == 0:
+		// Subnet-Router Anycast (RFC 4291)
+		return true
+	case 0x02005efffe000000 <= id && id <= 0x02005efffeffffff:
+		// Reserved IPv6 Interface Identifiers corresponding to the IANA
+		// Ethernet Block (RFC 4291)
+		return true
+	case 0xfdffffffffffff80 <= id && id <= 0xfdffffffffffffff:
+		// Reserved Subnet Anycast Addresses (RFC 2526)
+		return true
+	}
+	return false
+}
+
+// StableOpaqueAddress returns the address formed from the given /64 prefix and
+// a stable, semantically opaque interface identifier as described in RFC
+// 7217. The identifier is the first 64 bits of the SHA-256 hash of the
+// concatenation of the prefix (8 bytes), the interface, the network ID, the
+// DAD counter (1 byte) and the secret key. The interface is usually a name or
+// index, the network ID may be empty, and the secret key should be at least
+// 128 bits.
+//
+// dadCounter starts at 0 and is incremented by the caller each time Duplicate
+// Address Detection fails. If the result would be a reserved interface
+// identifier, the counter is incremented internally and the computation is
+// repeated.
+func StableOpaqueAddress(prefix Prefix, iface, networkID []byte, dadCounter uint8, secretKey []byte) (Address, error) {
+	if err := checkSLAACPrefix(prefix); err != nil {
+		return Address{}, err
+	}
+	var prefixBytes [8]byte
+	binary.BigEndian.PutUint64(prefixBytes[:], prefix.addr.ui.high)
+
+	for {
+		h := sha256.New()
+		h.Write(prefixBytes[:])
+		h.Write(iface)
+		h.Write(networkID)
+		h.Write([]byte{dadCounter})
+		h.Write(secretKey)
+		id := binary.BigEndian.Uint64(h.Sum(nil))
+		if !isReservedInterfaceID(id) {
+			return Address{Uint128{prefix.addr.ui.high, id}}, nil
+		}
+		dadCounter++
+	}
+}
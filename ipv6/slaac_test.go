@@ -0,0 +1,129 @@
+package ipv6
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEUI64Address(t *testing.T) {
+	tests := []struct {
+		mac     string
+		address Address
+	}{
+		{"00:1b:21:3a:4c:5d", _a("2001:db8:1:2:21b:21ff:fe3a:4c5d")},
+		{"02:00:00:00:00:01", _a("2001:db8:1:2::ff:fe00:1")},
+		{"00:1b:21:ff:fe:3a:4c:5d", _a("2001:db8:1:2:21b:21ff:fe3a:4c5d")},
+		{"00:1b:21:01:02:3a:4c:5d", _a("2001:db8:1:2:21b:2101:23a:4c5d")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mac, func(t *testing.T) {
+			mac, err := net.ParseMAC(tt.mac)
+			assert.Nil(t, err)
+			addr, err := EUI64Address(_p("2001:db8:1:2::/64"), mac)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, addr)
+		})
+	}
+
+	t.Run("bad prefix", func(t *testing.T) {
+		mac, _ := net.ParseMAC("00:1b:21:3a:4c:5d")
+		_, err := EUI64Address(_p("2001:db8:1::/48"), mac)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("bad mac", func(t *testing.T) {
+		_, err := EUI64Address(_p("2001:db8:1:2::/64"), net.HardwareAddr{1, 2, 3, 4})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestHardwareAddr(t *testing.T) {
+	tests := []struct {
+		address Address
+		mac     string
+	}{
+		{_a("2001:db8:1:2:21b:21ff:fe3a:4c5d"), "00:1b:21:3a:4c:5d"},
+		{_a("fe80::ff:fe00:1"), "02:00:00:00:00:01"},
+		{_a("fe80::1"), ""},
+		{_a("2001:db8:1:2:21b:2101:23a:4c5d"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			mac, ok := tt.address.HardwareAddr()
+			assert.Equal(t, tt.mac != "", ok)
+			assert.Equal(t, tt.mac != "", tt.address.IsEUI64())
+			if ok {
+				assert.Equal(t, tt.mac, mac.String())
+
+				addr, err := EUI64Address(Prefix{tt.address, 64}.Network(), mac)
+				assert.Nil(t, err)
+				assert.Equal(t, tt.address, addr)
+			}
+		})
+	}
+}
+
+func TestIsReservedInterfaceID(t *testing.T) {
+	tests := []struct {
+		id       uint64
+		reserved bool
+	}{
+		{0, true},
+		{1, false},
+		{0x02005efffdffffff, false},
+		{0x02005efffe000000, true},
+		{0x02005efffe005213, true},
+		{0x02005efffeffffff, true},
+		{0x02005eff00000000, false},
+		{0xfdffffffffffff7f, false},
+		{0xfdffffffffffff80, true},
+		{0xfdffffffffffffff, true},
+		{0xfe00000000000000, false},
+		{0xffffffffffffffff, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.reserved, isReservedInterfaceID(tt.id), "%x", tt.id)
+	}
+}
+
+func TestStableOpaqueAddress(t *testing.T) {
+	prefix := _p("2001:db8:1:2::/64")
+	secret := []byte("0123456789abcdef")
+
+	addr, err := StableOpaqueAddress(prefix, []byte("eth0"), nil, 0, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, _a("2001:db8:1:2:8cab:89e0:ec3c:6fad"), addr)
+	assert.True(t, prefix.containsAddress(addr))
+
+	again, err := StableOpaqueAddress(prefix, []byte("eth0"), nil, 0, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, again)
+
+	for _, other := range []struct {
+		prefix     Prefix
+		iface      string
+		networkID  string
+		dadCounter uint8
+		secret     string
+	}{
+		{_p("2001:db8:1:3::/64"), "eth0", "", 0, string(secret)},
+		{prefix, "eth1", "", 0, string(secret)},
+		{prefix, "eth0", "home", 0, string(secret)},
+		{prefix, "eth0", "", 1, string(secret)},
+		{prefix, "eth0", "", 0, "fedcba9876543210"},
+	} {
+		different, err := StableOpaqueAddress(other.prefix, []byte(other.iface), []byte(other.networkID), other.dadCounter, []byte(other.secret))
+		assert.Nil(t, err)
+		assert.NotEqual(t, addr.ui.low, different.ui.low)
+	}
+
+	t.Run("bad prefix", func(t *testing.T) {
+		_, err := StableOpaqueAddress(_p("2001:db8:1::/48"), []byte("eth0"), nil, 0, secret)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package ipv6
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEncodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		description string
+		set         Set
+	}{
+		{
+			description: "empty",
+			set:         Set{},
+		}, {
+			description: "everything",
+			set:         _p("::/0").Set(),
+		}, {
+			description: "one address",
+			set:         _a("2001:db8::1").Set(),
+		}, {
+			description: "holes",
+			set: Set{}.Build(func(s_ Set_) bool {
+				s_.Insert(_p("2001:db8::/32"))
+				s_.Remove(_p("2001:db8:1::/48"))
+				s_.Remove(_a("2001:db8::1"))
+				s_.Insert(_p("fd00::/8"))
+				return true
+			}),
+		}, {
+			description: "random",
+			set: Set{}.Build(func(s_ Set_) bool {
+				r := rand.New(rand.NewSource(0))
+				for i := 0; i < 10000; i++ {
+					s_.Insert(Prefix{AddressFromUint64(r.Uint64(), r.Uint64()), uint32(16 + r.Intn(113))}.Network())
+				}
+				return true
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.Nil(t, tt.set.Encode(&buf))
+
+			s, err := SetFromReader(&buf)
+			assert.Nil(t, err)
+			assert.True(t, s.isValid())
+			assert.True(t, tt.set.Equal(s))
+			assert.Equal(t, 0, buf.Len())
+		})
+	}
+}
+
+func TestSetEncodeCompact(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, _p("2001:db8::/32").Set().Union(_p("fd00::/8")).Encode(&buf))
+	assert.Equal(t, []byte{
+		'A', 'D', 'R', 'S', 1, 6, 's', 2,
+		32, 0x20, 0x01, 0x0d, 0xb8,
+		8, 0xfd,
+	}, buf.Bytes())
+}
+
+func TestSetFromReaderFlattens(t *testing.T) {
+	// Two adjacent halves and a nested prefix are written by hand
+	data := []byte{
+		'A', 'D', 'R', 'S', 1, 6, 's', 3,
+		33, 0x20, 0x01, 0x0d, 0xb8, 0x00,
+		33, 0x20, 0x01, 0x0d, 0xb8, 0x80,
+		34, 0x20, 0x01, 0x0d, 0xb8, 0xc0,
+	}
+	s, err := SetFromReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, s.isValid())
+	assert.True(t, _p("2001:db8::/32").Set().Equal(s))
+}
+
+func TestSetFromReaderErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		data        []byte
+	}{
+		{
+			description: "empty",
+			data:        []byte{},
+		}, {
+			description: "bad magic",
+			data:        []byte{'A', 'D', 'R', 'X', 1, 6, 's', 0},
+		}, {
+			description: "bad version",
+			data:        []byte{'A', 'D', 'R', 'S', 2, 6, 's', 0},
+		}, {
+			description: "ipv4",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 4, 's', 0},
+		}, {
+			description: "table",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 6, 't', 0},
+		}, {
+			description: "truncated",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 6, 's', 1, 32, 0x20, 0x01},
+		}, {
+			description: "bad length",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 6, 's', 1, 129},
+		}, {
+			description: "out of order",
+			data:        []byte{'A', 'D', 'R', 'S', 1, 6, 's', 2, 8, 0xfd, 16, 0x20, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			_, err := SetFromReader(bytes.NewReader(tt.data))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestBuildTrie(t *testing.T) {
+	prefixes := []Prefix{
+		_p("2001:db8::/32"),
+		_p("2001:db8::/48"),
+		_p("2001:db8::/64"),
+		_p("2001:db8:1::/48"),
+		_p("2001:db8:ffff::/48"),
+		_p("fd00::/8"),
+		_p("fd00::1/128"),
+	}
+
+	var expected *trieNode
+	var nodes []*trieNode
+	for i, p := range prefixes {
+		expected, _ = expected.Insert(p, i)
+		nodes = append(nodes, &trieNode{Prefix: p, Data: i})
+	}
+
+	trie := buildTrie(nodes, false)
+	assert.True(t, trie.isValid())
+	assert.True(t, expected.Equal(trie, defaultComparator))
+	assert.Equal(t, expected.height(), trie.height())
+}
@@ -0,0 +1,128 @@
+package ipv6
+
+import (
+	"fmt"
+)
+
+// SubnetIterator produces the subnets of a prefix one at a time in
+// lexigraphical order without computing them all up front. Get one from
+// Prefix.Subnets. The zero value produces nothing.
+type SubnetIterator struct {
+	next Prefix
+	last Address
+	more bool
+}
+
+// Next returns the next subnet. When there are none left, ok is false and the
+// prefix must be ignored.
+func (me *SubnetIterator) Next() (prefix Prefix, ok bool) {
+	if !me.more {
+		return Prefix{}, false
+	}
+	prefix = me.next
+	if prefix.addr == me.last {
+		me.more = false
+	} else {
+		me.next.addr.ui, _ = me.next.addr.ui.add(Uint128{0, 1}.leftShift(addressSize - int(prefix.length)))
+	}
+	return prefix, true
+}
+
+// Subnets returns an iterator over the subnets of this prefix with the given
+// length. There are NumPrefixes(length) of them although that may be too many
+// to count. The host bits in the address are ignored. It is an error if the
+// length is shorter than this prefix's or greater than 128.
+func (me Prefix) Subnets(length uint32) (SubnetIterator, error) {
+	if err := me.checkSubnetLength(length); err != nil {
+		return SubnetIterator{}, err
+	}
+	first := me.Network().addr
+	return SubnetIterator{
+		next: Prefix{first, length},
+		last: Address{first.ui.or(me.Mask().ui.complement().and(lengthToMask(int(length)).ui))},
+		more: true,
+	}, nil
+}
+
+// Subnet returns the subnet of this prefix with the given length at the given
+// index. Index 0 is the first subnet and NumPrefixes(length) - 1 is the last.
+// If there are too many subnets to count in a uint64, any index is valid. The
+// host bits in the address are ignored. It is an error if the length is
+// shorter than this prefix's or greater than 128 or if the index is out of
+// range.
+func (me Prefix) Subnet(length uint32, index uint64) (Prefix, error) {
+	if err := me.checkSubnetLength(length); err != nil {
+		return Prefix{}, err
+	}
+	// NumPrefixes fails when the count doesn't fit
+	if count, err := me.NumPrefixes(length); err == nil && index >= count {
+		return Prefix{}, fmt.Errorf("subnet index %d is out of range: %s has %d /%d subnets", index, me, count, length)
+	}
+	addr := me.Network().addr.ui.or(Uint128{0, index}.leftShift(addressSize - int(length)))
+	return Prefix{Address{addr}, length}, nil
+}
+
+// checkSubnetLength returns an error if length isn't valid for subnets of
+// this prefix
+func (me Prefix) checkSubnetLength(length uint32) error {
+	switch {
+	case length > 128:
+		return fmt.Errorf("length is greater than 128")
+	case length < me.length:
+		return fmt.Errorf("subnet length /%d is shorter than the prefix %s", length, me)
+	}
+	return nil
+}
+
+// Supernet returns the prefix with the given length that contains this one.
+// The result has no host bits set. It is an error if the length is longer than
+// this prefix's.
+func (me Prefix) Supernet(length uint32) (Prefix, error) {
+	if length > me.length {
+		return Prefix{}, fmt.Errorf("supernet length /%d is longer than the prefix %s", length, me)
+	}
+	return Prefix{me.addr, length}.Network(), nil
+}
+
+// Parent returns the prefix one bit shorter that contains this one. It is the
+// inverse of Halves. The result has no host bits set. If this is a /0, ok is
+// false and the result must be ignored.
+func (me Prefix) Parent() (parent Prefix, ok bool) {
+	if me.length == 0 {
+		return Prefix{}, false
+	}
+	return Prefix{me.addr, me.length - 1}.Network(), true
+}
+
+// Sibling returns the other half of this prefix's parent. The result has no
+// host bits set. If this is a /0, ok is false and the result must be ignored.
+func (me Prefix) Sibling() (sibling Prefix, ok bool) {
+	if me.length == 0 {
+		return Prefix{}, false
+	}
+	network := me.Network()
+	network.addr.ui = network.addr.ui.xor(Uint128{0, 1}.leftShift(addressSize - int(me.length)))
+	return network, true
+}
+
+// IsAligned returns true if the address has no host bits set. That is, it is
+// the first address in the prefix.
+func (me Prefix) IsAligned() bool {
+	return me.addr.ui.and(me.Mask().ui.complement()).IsZero()
+}
+
+// Index returns the index of this prefix among the subnets of the given parent
+// with the same length. It is the inverse of Subnet. The host bits in the
+// address are ignored. It is an error if this prefix isn't contained in the
+// parent or if the index doesn't fit in a uint64.
+func (me Prefix) Index(parent Prefix) (uint64, error) {
+	mask := parent.Mask().ui
+	if me.length < parent.length || me.addr.ui.and(mask) != parent.addr.ui.and(mask) {
+		return 0, fmt.Errorf("prefix %s is not contained in %s", me, parent)
+	}
+	index := me.addr.ui.and(mask.complement()).rightShift(addressSize - int(me.length))
+	if index.high != 0 {
+		return 0, fmt.Errorf("index of %s in %s overflows a uint64", me, parent)
+	}
+	return index.low, nil
+}
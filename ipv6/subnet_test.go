@@ -0,0 +1,160 @@
+package ipv6
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixSubnets(t *testing.T) {
+	tests := []struct {
+		prefix  Prefix
+		length  uint32
+		subnets []Prefix
+	}{
+		{_p("2001:db8::/48"), 48, []Prefix{_p("2001:db8::/48")}},
+		{_p("2001:db8::/48"), 50, []Prefix{
+			_p("2001:db8::/50"),
+			_p("2001:db8:0:4000::/50"),
+			_p("2001:db8:0:8000::/50"),
+			_p("2001:db8:0:c000::/50"),
+		}},
+		{_p("2001:db8::1/63"), 64, []Prefix{_p("2001:db8::/64"), _p("2001:db8:0:1::/64")}},
+		{_p("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"), 128, []Prefix{
+			_p("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128"),
+			_p("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"),
+		}},
+		{_p("::/0"), 0, []Prefix{_p("::/0")}},
+		{_p("::/0"), 1, []Prefix{_p("::/1"), _p("8000::/1")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			iterator, err := tt.prefix.Subnets(tt.length)
+			assert.Nil(t, err)
+			var subnets []Prefix
+			for subnet, ok := iterator.Next(); ok; subnet, ok = iterator.Next() {
+				subnets = append(subnets, subnet)
+			}
+			assert.Equal(t, tt.subnets, subnets)
+
+			count, _ := tt.prefix.NumPrefixes(tt.length)
+			assert.Equal(t, count, uint64(len(subnets)))
+		})
+	}
+
+	_, err := _p("2001:db8::/48").Subnets(47)
+	assert.NotNil(t, err)
+	_, err = _p("2001:db8::/48").Subnets(129)
+	assert.NotNil(t, err)
+
+	var zero SubnetIterator
+	_, ok := zero.Next()
+	assert.False(t, ok)
+}
+
+func TestPrefixSubnetsLazy(t *testing.T) {
+	iterator, err := _p("::/0").Subnets(128)
+	assert.Nil(t, err)
+	for i := uint64(0); i < 3; i++ {
+		subnet, ok := iterator.Next()
+		assert.True(t, ok)
+		assert.Equal(t, Prefix{Address{Uint128{0, i}}, 128}, subnet)
+	}
+}
+
+func TestPrefixSubnet(t *testing.T) {
+	p := _p("2001:db8::17/48")
+
+	subnet, err := p.Subnet(64, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("2001:db8::/64"), subnet)
+
+	subnet, err = p.Subnet(64, 0xffff)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("2001:db8:0:ffff::/64"), subnet)
+
+	// There are too many to count so any index works
+	subnet, err = p.Subnet(128, math.MaxUint64)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("2001:db8::ffff:ffff:ffff:ffff/128"), subnet)
+
+	_, err = p.Subnet(64, 0x10000)
+	assert.NotNil(t, err)
+	_, err = p.Subnet(47, 0)
+	assert.NotNil(t, err)
+	_, err = p.Subnet(129, 0)
+	assert.NotNil(t, err)
+}
+
+func TestPrefixSupernet(t *testing.T) {
+	supernet, err := _p("2001:db8:1:2::3/128").Supernet(48)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("2001:db8:1::/48"), supernet)
+
+	supernet, err = _p("2001:db8::/32").Supernet(0)
+	assert.Nil(t, err)
+	assert.Equal(t, _p("::/0"), supernet)
+
+	_, err = _p("2001:db8::/32").Supernet(33)
+	assert.NotNil(t, err)
+}
+
+func TestPrefixParentSibling(t *testing.T) {
+	tests := []struct {
+		prefix, parent, sibling Prefix
+	}{
+		{_p("2001:db8::/33"), _p("2001:db8::/32"), _p("2001:db8:8000::/33")},
+		{_p("2001:db8:8000::/33"), _p("2001:db8::/32"), _p("2001:db8::/33")},
+		{_p("2001:db8::1/128"), _p("2001:db8::/127"), _p("2001:db8::/128")},
+		{_p("2001:db8:0:1::/64"), _p("2001:db8::/63"), _p("2001:db8::/64")},
+		{_p("8000::/1"), _p("::/0"), _p("::/1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix.String(), func(t *testing.T) {
+			parent, ok := tt.prefix.Parent()
+			assert.True(t, ok)
+			assert.Equal(t, tt.parent, parent)
+
+			sibling, ok := tt.prefix.Sibling()
+			assert.True(t, ok)
+			assert.Equal(t, tt.sibling, sibling)
+		})
+	}
+
+	_, ok := _p("::/0").Parent()
+	assert.False(t, ok)
+	_, ok = _p("::/0").Sibling()
+	assert.False(t, ok)
+}
+
+func TestPrefixIsAligned(t *testing.T) {
+	assert.True(t, _p("2001:db8::/32").IsAligned())
+	assert.True(t, _p("2001:db8::1/128").IsAligned())
+	assert.True(t, _p("::/0").IsAligned())
+	assert.False(t, _p("2001:db8::1/64").IsAligned())
+}
+
+func TestPrefixIndex(t *testing.T) {
+	parent := _p("2001:db8::/48")
+	for _, index := range []uint64{0, 1, 0x1234, 0xffff} {
+		subnet, err := parent.Subnet(64, index)
+		assert.Nil(t, err)
+		i, err := subnet.Index(parent)
+		assert.Nil(t, err)
+		assert.Equal(t, index, i)
+	}
+
+	i, err := _p("2001:db8::ffff:ffff:ffff:ffff/128").Index(_p("2001:db8::/64"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), i)
+
+	_, err = _p("2001:db8:0:1::/128").Index(_p("2001:db8::/63"))
+	assert.NotNil(t, err)
+	_, err = _p("2001:db9::/64").Index(parent)
+	assert.NotNil(t, err)
+	_, err = _p("2001:db8::/47").Index(parent)
+	assert.NotNil(t, err)
+}
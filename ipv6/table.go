@@ -3,6 +3,12 @@
 
 package ipv6
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 // Table_ is a mutable version of Table, allowing inserting, replacing, or
 // removing elements in various ways. You can use it as a Table builder or on
 // its own.
@@ -320,3 +326,105 @@ func (me Table[T]) Map(mapper func(Prefix, T) T) Table[T] {
 		},
 	}
 }
+
+// MarshalJSON implements json.Marshaler. The table is encoded as a JSON object
+// mapping each prefix, in CIDR notation, to its value. Entries appear in the
+// same lexigraphical order as Walk. Values are encoded with json.Marshal so T
+// controls its own representation.
+func (me Table[T]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	buf.WriteByte('{')
+	me.Walk(func(p Prefix, value T) bool {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		var data []byte
+		data, err = json.Marshal(p.String())
+		if err != nil {
+			return false
+		}
+		buf.Write(data)
+		buf.WriteByte(':')
+		data, err = json.Marshal(value)
+		if err != nil {
+			err = fmt.Errorf("failed to marshal value for %s: %w", p, err)
+			return false
+		}
+		buf.Write(data)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// tableJSONEntry is the element of the array form of a Table's JSON encoding.
+type tableJSONEntry struct {
+	Prefix *Prefix         `json:"prefix"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts either the JSON object
+// produced by MarshalJSON or a JSON array of objects, each with a "prefix" and
+// a "value" field. Values are decoded with json.Unmarshal. It is an error for
+// two entries to have the same prefix. A JSON null leaves the table unchanged.
+//
+// If the table was created with a custom comparator, the result keeps it.
+func (me *Table[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	t_ := Table[T]{tableX{eq: me.t.eq}}.Table_()
+	insert := func(prefix Prefix, raw json.RawMessage) error {
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("failed to unmarshal value for %s: %w", prefix, err)
+		}
+		if !t_.Insert(prefix, value) {
+			return fmt.Errorf("duplicate prefix in table: %s", prefix)
+		}
+		return nil
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for str, raw := range entries {
+			prefix, err := PrefixFromString(str)
+			if err != nil {
+				return err
+			}
+			if err = insert(prefix, raw); err != nil {
+				return err
+			}
+		}
+
+	case bytes.HasPrefix(data, []byte("[")):
+		var entries []tableJSONEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Prefix == nil {
+				return fmt.Errorf("table entry is missing a prefix")
+			}
+			if err := insert(*entry.Prefix, entry.Value); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("failed to unmarshal table: expected a JSON object or array")
+	}
+
+	*me = t_.Table()
+	return nil
+}
@@ -4,6 +4,7 @@
 package ipv6
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
@@ -369,7 +370,7 @@ func TestTableTnsert(t *testing.T) {
 	m := NewTable_[bool]()
 	assert.Equal(t, int64(0), m.t.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	succeeded := m.Insert(key, true)
 	assert.True(t, succeeded)
 	assert.Equal(t, int64(1), m.t.m.trie.NumNodes())
@@ -380,7 +381,7 @@ func TestTableTnsertOrUpdate(t *testing.T) {
 	m := NewTable_[bool]()
 	assert.Equal(t, int64(0), m.t.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.InsertOrUpdate(key, true)
 	assert.Equal(t, int64(1), m.t.m.trie.NumNodes())
 	value, match, matchedKey := m.LongestMatch(key)
@@ -403,7 +404,7 @@ func TestTableTUpdate(t *testing.T) {
 	m := NewTable_[bool]()
 	assert.Equal(t, int64(0), m.t.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(key, false)
 
 	succeeded := m.Update(key, true)
@@ -430,7 +431,7 @@ func TestTableTGetOrInsert(t *testing.T) {
 	m := NewTable_[bool]()
 	assert.Equal(t, int64(0), m.t.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	value := m.GetOrInsert(key, true)
 	assert.True(t, value)
 	assert.Equal(t, int64(1), m.t.m.trie.NumNodes())
@@ -440,17 +441,17 @@ func TestTableTGetOrInsert(t *testing.T) {
 func TestTableTMatch(t *testing.T) {
 	m := NewTable_[bool]()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
 	t.Run("None", func(t *testing.T) {
-		_, found, _ := m.LongestMatch(Prefix{Address{uint128{0x2001, 0x0100000}}, 112})
+		_, found, _ := m.LongestMatch(Prefix{Address{Uint128{0x2001, 0x0100000}}, 112})
 		assert.False(t, found)
 		assert.True(t, m.t.m.trie.isValid())
 	})
 
 	t.Run("Exact", func(t *testing.T) {
-		prefix := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		prefix := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		value, found, key := m.LongestMatch(prefix)
 		assert.Equal(t, prefix, key)
 		assert.True(t, found)
@@ -460,7 +461,7 @@ func TestTableTMatch(t *testing.T) {
 	})
 
 	t.Run("Contains", func(t *testing.T) {
-		prefix := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+		prefix := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 		value, found, key := m.LongestMatch(prefix)
 		assert.NotEqual(t, prefix, key)
 		assert.True(t, found)
@@ -474,10 +475,10 @@ func TestTableTRemovePrefix(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		m := NewTable_[bool]()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		succeeded := m.Remove(key)
 		assert.True(t, succeeded)
 		assert.Equal(t, int64(0), m.t.m.trie.NumNodes())
@@ -487,10 +488,10 @@ func TestTableTRemovePrefix(t *testing.T) {
 	t.Run("Not Found", func(t *testing.T) {
 		m := NewTable_[bool]()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0100000}}, 112}
+		key := Prefix{Address{Uint128{0x2001, 0x0100000}}, 112}
 		succeeded := m.Remove(key)
 		assert.False(t, succeeded)
 		assert.Equal(t, int64(1), m.t.m.trie.NumNodes())
@@ -500,10 +501,10 @@ func TestTableTRemovePrefix(t *testing.T) {
 	t.Run("Not Exact", func(t *testing.T) {
 		m := NewTable_[bool]()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+		key := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 		succeeded := m.Remove(key)
 		assert.False(t, succeeded)
 		assert.Equal(t, int64(1), m.t.m.trie.NumNodes())
@@ -514,7 +515,7 @@ func TestTableTRemovePrefix(t *testing.T) {
 func TestTableTWalk(t *testing.T) {
 	m := NewTable_[bool]()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
 	found := false
@@ -531,10 +532,10 @@ func TestTableTWalk(t *testing.T) {
 func TestTableTWalkAggregates(t *testing.T) {
 	m := NewTable_[bool]()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
-	secondKey := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+	secondKey := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 	m.Insert(secondKey, true)
 
 	found := false
@@ -555,11 +556,11 @@ func TestTableTEqual(t *testing.T) {
 	assert.True(t, a.t.m.trie.Equal(b.t.m.trie, ieq))
 	assert.True(t, b.t.m.trie.Equal(a.t.m.trie, ieq))
 
-	a.Insert(Prefix{Address{uint128{0x2001, 0x0180001}}, 112}, true)
+	a.Insert(Prefix{Address{Uint128{0x2001, 0x0180001}}, 112}, true)
 	assert.False(t, a.t.m.trie.Equal(b.t.m.trie, ieq))
 	assert.False(t, b.t.m.trie.Equal(a.t.m.trie, ieq))
 
-	b.Insert(Prefix{Address{uint128{0x2001, 0x0180000}}, 112}, true)
+	b.Insert(Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}, true)
 	assert.False(t, a.t.m.trie.Equal(b.t.m.trie, ieq))
 	assert.False(t, b.t.m.trie.Equal(a.t.m.trie, ieq))
 }
@@ -961,3 +962,91 @@ func TestTableVariousComparators(t *testing.T) {
 		})
 	}
 }
+
+func TestTableMarshalJSON(t *testing.T) {
+	table := Table[int]{}.Build(func(t_ Table_[int]) bool {
+		t_.Insert(_p("2001:db8::/32"), 1)
+		t_.Insert(_p("2001:db8::/48"), 2)
+		t_.Insert(_a("2001:db8::1"), 3)
+		t_.Insert(_p("fd00::/8"), 4)
+		return true
+	})
+
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"2001:db8::/32":1,"2001:db8::/48":2,"2001:db8::1/128":3,"fd00::/8":4}`, string(data))
+
+	var decoded Table[int]
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, int64(4), decoded.NumEntries())
+	assert.True(t, table.Diff(decoded, nil, func(Prefix, int) bool { return false }, func(Prefix, int) bool { return false }, nil))
+
+	data, err = json.Marshal(Table[int]{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestTableUnmarshalJSON(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		var table Table[string]
+		err := json.Unmarshal([]byte(`[{"prefix":"2001:db8::/32","value":"a"},{"prefix":"2001:db8::1/128","value":"b"}]`), &table)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), table.NumEntries())
+		value, found, _ := table.LongestMatch(_a("2001:db8::1"))
+		assert.True(t, found)
+		assert.Equal(t, "b", value)
+	})
+	t.Run("struct values", func(t *testing.T) {
+		type route struct {
+			NextHop Address `json:"next_hop"`
+			Metric  int     `json:"metric"`
+		}
+		var table Table[route]
+		err := json.Unmarshal([]byte(`{"::/0":{"next_hop":"2001:db8::1","metric":10}}`), &table)
+		assert.Nil(t, err)
+		value, found := table.Get(Prefix{})
+		assert.True(t, found)
+		assert.Equal(t, route{_a("2001:db8::1"), 10}, value)
+	})
+	t.Run("null", func(t *testing.T) {
+		table := Table[int]{}.Build(func(t_ Table_[int]) bool {
+			t_.Insert(_p("2001:db8::/32"), 1)
+			return true
+		})
+		assert.Nil(t, json.Unmarshal([]byte(`null`), &table))
+		assert.Equal(t, int64(1), table.NumEntries())
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`[{"prefix":"2001:db8::/32","value":1},{"prefix":"2001:db8::/32","value":2}]`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("missing prefix", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`[{"value":1}]`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("bad prefix", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`{"2001:db8::/129":1}`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("bad value", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`{"2001:db8::/32":"one"}`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("not a table", func(t *testing.T) {
+		var table Table[int]
+		err := json.Unmarshal([]byte(`"2001:db8::/32"`), &table)
+		assert.NotNil(t, err)
+	})
+	t.Run("custom comparator", func(t *testing.T) {
+		table := NewTableCustomCompare_(func(a, b []int) bool {
+			return len(a) == len(b)
+		}).Table()
+		err := json.Unmarshal([]byte(`{"2001:db8::/48":[1],"2001:db8:1::/48":[2]}`), &table)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), table.Aggregate().NumEntries())
+	})
+}
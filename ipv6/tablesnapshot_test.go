@@ -0,0 +1,121 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stringCodec is a ValueCodec for variable length values
+type stringCodec struct{}
+
+func (stringCodec) EncodeValue(w io.Writer, value string) error {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(value)))
+	if _, err := w.Write(length[:n]); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, value)
+	return err
+}
+
+func (stringCodec) DecodeValue(r io.Reader) (string, error) {
+	length, err := binary.ReadUvarint(r.(io.ByteReader))
+	if err != nil {
+		return "", err
+	}
+	value := make([]byte, length)
+	_, err = io.ReadFull(r, value)
+	return string(value), err
+}
+
+func TestTableEncodeRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	table := Table[uint32]{}.Build(func(t_ Table_[uint32]) bool {
+		t_.Insert(Prefix{}, 0)
+		for i := 0; i < 10000; i++ {
+			t_.InsertOrUpdate(Prefix{AddressFromUint64(r.Uint64(), r.Uint64()), uint32(8 + r.Intn(121))}.Network(), r.Uint32())
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	assert.Nil(t, table.Encode(&buf, BinaryCodec[uint32]{}))
+
+	decoded, err := TableFromReader[uint32](&buf, BinaryCodec[uint32]{})
+	assert.Nil(t, err)
+	assert.True(t, decoded.t.trie.isValid())
+	assert.Equal(t, table.NumEntries(), decoded.NumEntries())
+	assert.True(t, table.t.trie.Equal(decoded.t.trie, defaultComparator))
+
+	// The decoded table is fully usable
+	t_ := decoded.Table_()
+	assert.True(t, t_.Insert(_p("2001:db8:1::/127"), 1) || t_.Update(_p("2001:db8:1::/127"), 1))
+	assert.Equal(t, table.Aggregate().NumEntries(), decoded.Aggregate().NumEntries())
+}
+
+func TestTableEncodeCustomCodec(t *testing.T) {
+	table := Table[string]{}.Build(func(t_ Table_[string]) bool {
+		t_.Insert(_p("2001:db8::/32"), "ten")
+		t_.Insert(_p("2001:db8:1::/48"), "")
+		t_.Insert(_a("2001:db8:1::1"), "host")
+		return true
+	})
+
+	var buf bytes.Buffer
+	assert.Nil(t, table.Encode(&buf, stringCodec{}))
+	assert.Equal(t, 8+(5+4)+(7+1)+(17+5), buf.Len())
+
+	decoded, err := TableFromReader[string](&buf, stringCodec{})
+	assert.Nil(t, err)
+	value, found, _ := decoded.LongestMatch(_a("2001:db8:1::1"))
+	assert.True(t, found)
+	assert.Equal(t, "host", value)
+	value, found, _ = decoded.LongestMatch(_a("2001:db8:1::2"))
+	assert.True(t, found)
+	assert.Equal(t, "", value)
+	value, found, _ = decoded.LongestMatch(_a("2001:db8:2::1"))
+	assert.True(t, found)
+	assert.Equal(t, "ten", value)
+}
+
+type failingCodec struct{}
+
+func (failingCodec) EncodeValue(w io.Writer, value int32) error {
+	return fmt.Errorf("nope")
+}
+
+func (failingCodec) DecodeValue(r io.Reader) (int32, error) {
+	return 0, fmt.Errorf("nope")
+}
+
+func TestTableEncodeErrors(t *testing.T) {
+	table := Table[int32]{}.Build(func(t_ Table_[int32]) bool {
+		t_.Insert(_p("2001:db8::/32"), 1)
+		return true
+	})
+
+	var buf bytes.Buffer
+	assert.NotNil(t, table.Encode(&buf, failingCodec{}))
+
+	buf.Reset()
+	assert.Nil(t, table.Encode(&buf, BinaryCodec[int32]{}))
+	_, err := TableFromReader[int32](bytes.NewReader(buf.Bytes()), failingCodec{})
+	assert.NotNil(t, err)
+
+	_, err = TableFromReader[int32](bytes.NewReader(buf.Bytes()[:buf.Len()-1]), BinaryCodec[int32]{})
+	assert.NotNil(t, err)
+
+	buf.Reset()
+	assert.Nil(t, _p("2001:db8::/32").Set().Encode(&buf))
+	_, err = TableFromReader[int32](&buf, BinaryCodec[int32]{})
+	assert.NotNil(t, err)
+}
@@ -379,7 +379,7 @@ func TestTableXnsert(t *testing.T) {
 	m := newTableX_()
 	assert.Equal(t, int64(0), m.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0}}, 112}
 	succeeded := m.Insert(key, true)
 	assert.True(t, succeeded)
 	assert.Equal(t, int64(1), m.m.trie.NumNodes())
@@ -390,7 +390,7 @@ func TestTableXnsertOrUpdate(t *testing.T) {
 	m := newTableX_()
 	assert.Equal(t, int64(0), m.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0}}, 112}
 	m.InsertOrUpdate(key, true)
 	assert.Equal(t, int64(1), m.m.trie.NumNodes())
 	value, match, matchedKey := m.LongestMatch(key)
@@ -413,7 +413,7 @@ func TestTableUpdate(t *testing.T) {
 	m := newTableX_()
 	assert.Equal(t, int64(0), m.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0}}, 112}
 	m.Insert(key, false)
 
 	succeeded := m.Update(key, true)
@@ -440,7 +440,7 @@ func TestTableGetOrInsert(t *testing.T) {
 	m := newTableX_()
 	assert.Equal(t, int64(0), m.m.trie.NumNodes())
 
-	key := Prefix{Address{uint128{0x2001, 0x0}}, 112}
+	key := Prefix{Address{Uint128{0x2001, 0x0}}, 112}
 	value := m.GetOrInsert(key, true)
 	assert.True(t, value.(bool))
 	assert.Equal(t, int64(1), m.m.trie.NumNodes())
@@ -450,17 +450,17 @@ func TestTableGetOrInsert(t *testing.T) {
 func TestTableMatch(t *testing.T) {
 	m := newTableX_()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
 	t.Run("None", func(t *testing.T) {
-		_, found, _ := m.LongestMatch(Prefix{Address{uint128{0x2001, 0x010000}}, 112})
+		_, found, _ := m.LongestMatch(Prefix{Address{Uint128{0x2001, 0x010000}}, 112})
 		assert.False(t, found)
 		assert.True(t, m.m.trie.isValid())
 	})
 
 	t.Run("Exact", func(t *testing.T) {
-		prefix := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		prefix := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		value, found, key := m.LongestMatch(prefix)
 		assert.Equal(t, prefix, key)
 		assert.True(t, found)
@@ -470,7 +470,7 @@ func TestTableMatch(t *testing.T) {
 	})
 
 	t.Run("Contains", func(t *testing.T) {
-		prefix := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+		prefix := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 		value, found, key := m.LongestMatch(prefix)
 		assert.True(t, found)
 		assert.NotEqual(t, prefix, key)
@@ -484,10 +484,10 @@ func TestTableRemovePrefix(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		m := newTableX_()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		key := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		succeeded := m.Remove(key)
 		assert.True(t, succeeded)
 		assert.Equal(t, int64(0), m.m.trie.NumNodes())
@@ -497,10 +497,10 @@ func TestTableRemovePrefix(t *testing.T) {
 	t.Run("Not Found", func(t *testing.T) {
 		m := newTableX_()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0100000}}, 112}
+		key := Prefix{Address{Uint128{0x2001, 0x0100000}}, 112}
 		succeeded := m.Remove(key)
 		assert.False(t, succeeded)
 		assert.Equal(t, int64(1), m.m.trie.NumNodes())
@@ -510,10 +510,10 @@ func TestTableRemovePrefix(t *testing.T) {
 	t.Run("Not Exact", func(t *testing.T) {
 		m := newTableX_()
 
-		insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+		insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 		m.Insert(insertKey, true)
 
-		key := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+		key := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 		succeeded := m.Remove(key)
 		assert.False(t, succeeded)
 		assert.Equal(t, int64(1), m.m.trie.NumNodes())
@@ -524,7 +524,7 @@ func TestTableRemovePrefix(t *testing.T) {
 func TestTableWalk(t *testing.T) {
 	m := newTableX_()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
 	found := false
@@ -541,10 +541,10 @@ func TestTableWalk(t *testing.T) {
 func TestTableWalkAggregates(t *testing.T) {
 	m := newTableX_()
 
-	insertKey := Prefix{Address{uint128{0x2001, 0x0180000}}, 112}
+	insertKey := Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}
 	m.Insert(insertKey, true)
 
-	secondKey := Prefix{Address{uint128{0x2001, 0x0180017}}, 128}
+	secondKey := Prefix{Address{Uint128{0x2001, 0x0180017}}, 128}
 	m.Insert(secondKey, true)
 
 	found := false
@@ -569,11 +569,11 @@ func TestTableEqual(t *testing.T) {
 	assert.True(t, a.m.trie.Equal(b.m.trie, ieq))
 	assert.True(t, b.m.trie.Equal(a.m.trie, ieq))
 
-	a.Insert(Prefix{Address{uint128{0x2001, 0x0180001}}, 112}, true)
+	a.Insert(Prefix{Address{Uint128{0x2001, 0x0180001}}, 112}, true)
 	assert.False(t, a.m.trie.Equal(b.m.trie, ieq))
 	assert.False(t, b.m.trie.Equal(a.m.trie, ieq))
 
-	b.Insert(Prefix{Address{uint128{0x2001, 0x0180000}}, 112}, true)
+	b.Insert(Prefix{Address{Uint128{0x2001, 0x0180000}}, 112}, true)
 	assert.False(t, a.m.trie.Equal(b.m.trie, ieq))
 	assert.False(t, b.m.trie.Equal(a.m.trie, ieq))
 }
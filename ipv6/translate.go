@@ -0,0 +1,116 @@
+package ipv6
+
+import (
+	"gopkg.in/addrs.v1/ipv4"
+)
+
+var (
+	// ipv4MappedPrefix is ::ffff:0:0/96 from RFC 4291 section 2.5.5.2
+	ipv4MappedPrefix = Prefix{Address{Uint128{0, 0xffff00000000}}, 96}
+	// ipv4CompatiblePrefix is ::/96 from RFC 4291 section 2.5.5.1
+	ipv4CompatiblePrefix = Prefix{Address{}, 96}
+)
+
+// IPv4MappedAddress returns the IPv4-mapped IPv6 address (e.g.
+// ::ffff:203.0.113.17) which represents the given IPv4 address
+func IPv4MappedAddress(addr ipv4.Address) Address {
+	return Address{ipv4MappedPrefix.addr.ui.or(Uint128{0, uint64(addr.Uint32())})}
+}
+
+// IPv4CompatibleAddress returns the IPv4-compatible IPv6 address (e.g.
+// ::203.0.113.17) which represents the given IPv4 address. These addresses
+// are deprecated by RFC 4291 and should only be used when dealing with legacy
+// systems.
+func IPv4CompatibleAddress(addr ipv4.Address) Address {
+	return Address{Uint128{0, uint64(addr.Uint32())}}
+}
+
+// IsIPv4Mapped returns true if this is an IPv4-mapped address (::ffff:0:0/96)
+func (me Address) IsIPv4Mapped() bool {
+	return ipv4MappedPrefix.containsAddress(me)
+}
+
+// IsIPv4Compatible returns true if this is an IPv4-compatible address
+// (::/96). The unspecified (::) and loopback (::1) addresses are also in that
+// block but are not considered IPv4-compatible.
+func (me Address) IsIPv4Compatible() bool {
+	return ipv4CompatiblePrefix.containsAddress(me) && me.ui.low > 1
+}
+
+// Unmap returns the IPv4 address represented by this IPv4-mapped address. If
+// this is not an IPv4-mapped address, ok is false and the result must be
+// ignored.
+func (me Address) Unmap() (addr ipv4.Address, ok bool) {
+	if !me.IsIPv4Mapped() {
+		return ipv4.Address{}, false
+	}
+	return ipv4.AddressFromUint32(uint32(me.ui.low)), true
+}
+
+// UnmapCompatible returns the IPv4 address represented by this
+// IPv4-compatible address. If this is not an IPv4-compatible address as
+// defined by IsIPv4Compatible, ok is false and the result must be ignored.
+func (me Address) UnmapCompatible() (addr ipv4.Address, ok bool) {
+	if !me.IsIPv4Compatible() {
+		return ipv4.Address{}, false
+	}
+	return ipv4.AddressFromUint32(uint32(me.ui.low)), true
+}
+
+// IPv4MappedPrefix returns the prefix of IPv4-mapped addresses representing
+// all of the addresses in the given IPv4 prefix. Any host bits are kept.
+func IPv4MappedPrefix(prefix ipv4.Prefix) Prefix {
+	return Prefix{
+		IPv4MappedAddress(prefix.Address()),
+		uint32(96 + prefix.Length()),
+	}
+}
+
+// Unmap returns the IPv4 prefix represented by this prefix if it is made up of
+// IPv4-mapped addresses (it is ::ffff:0:0/96 or is contained by it). Otherwise,
+// ok is false and the result must be ignored.
+func (me Prefix) Unmap() (prefix ipv4.Prefix, ok bool) {
+	if me.length < ipv4MappedPrefix.length {
+		return ipv4.Prefix{}, false
+	}
+	addr, ok := me.addr.Unmap()
+	if !ok {
+		return ipv4.Prefix{}, false
+	}
+	return ipv4PrefixFromAddressLength(addr, int(me.length-ipv4MappedPrefix.length)), true
+}
+
+// IPv4MappedSet returns the set of IPv4-mapped addresses representing all of
+// the addresses in the given IPv4 set
+func IPv4MappedSet(set ipv4.Set) Set {
+	s := NewSet_()
+	set.WalkPrefixes(func(prefix ipv4.Prefix) bool {
+		s.Insert(IPv4MappedPrefix(prefix))
+		return true
+	})
+	return s.Set()
+}
+
+// Unmap returns the set of IPv4 addresses represented by the IPv4-mapped
+// addresses in this set. Any other addresses in the set are ignored.
+func (me Set) Unmap() ipv4.Set {
+	s := ipv4.NewSet_()
+	me.Intersection(ipv4MappedPrefix).WalkPrefixes(func(prefix Prefix) bool {
+		p, _ := prefix.Unmap()
+		s.Insert(p)
+		return true
+	})
+	return s.Set()
+}
+
+// containsAddress returns true if the address is in the prefix
+func (me Prefix) containsAddress(addr Address) bool {
+	return addr.ui.and(me.Mask().ui) == me.addr.ui.and(me.Mask().ui)
+}
+
+// ipv4PrefixFromAddressLength returns an ipv4.Prefix from an address and a
+// length which must be between 0 and 32
+func ipv4PrefixFromAddressLength(addr ipv4.Address, length int) ipv4.Prefix {
+	mask, _ := ipv4.MaskFromLength(length)
+	return ipv4.PrefixFromAddressMask(addr, mask)
+}
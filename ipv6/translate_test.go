@@ -0,0 +1,126 @@
+package ipv6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/addrs.v1/ipv4"
+)
+
+func _a4(str string) ipv4.Address {
+	addr, _ := ipv4.AddressFromString(str)
+	return addr
+}
+
+func _p4(cidr string) ipv4.Prefix {
+	prefix, _ := ipv4.PrefixFromString(cidr)
+	return prefix
+}
+
+func TestIPv4MappedAddress(t *testing.T) {
+	mapped := IPv4MappedAddress(_a4("203.0.113.17"))
+	assert.Equal(t, _a("::ffff:203.0.113.17"), mapped)
+	assert.True(t, mapped.IsIPv4Mapped())
+	assert.False(t, mapped.IsIPv4Compatible())
+
+	addr, ok := mapped.Unmap()
+	assert.True(t, ok)
+	assert.Equal(t, _a4("203.0.113.17"), addr)
+
+	_, ok = mapped.UnmapCompatible()
+	assert.False(t, ok)
+}
+
+func TestIPv4CompatibleAddress(t *testing.T) {
+	compat := IPv4CompatibleAddress(_a4("203.0.113.17"))
+	assert.Equal(t, _a("::cb00:7111"), compat)
+	assert.True(t, compat.IsIPv4Compatible())
+	assert.False(t, compat.IsIPv4Mapped())
+
+	addr, ok := compat.UnmapCompatible()
+	assert.True(t, ok)
+	assert.Equal(t, _a4("203.0.113.17"), addr)
+
+	_, ok = compat.Unmap()
+	assert.False(t, ok)
+}
+
+func TestIsIPv4Mapped(t *testing.T) {
+	tests := []struct {
+		address    Address
+		mapped     bool
+		compatible bool
+	}{
+		{_a("::"), false, false},
+		{_a("::1"), false, false},
+		{_a("::2"), false, true},
+		{_a("::ffff:0:0"), true, false},
+		{_a("::ffff:ffff:ffff"), true, false},
+		{_a("::1:ffff:0:0"), false, false},
+		{_a("::fffe:ffff:ffff"), false, false},
+		{_a("2001:db8::1"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			assert.Equal(t, tt.mapped, tt.address.IsIPv4Mapped())
+			assert.Equal(t, tt.compatible, tt.address.IsIPv4Compatible())
+		})
+	}
+}
+
+func TestIPv4MappedPrefix(t *testing.T) {
+	tests := []struct {
+		v4 ipv4.Prefix
+		v6 Prefix
+	}{
+		{_p4("0.0.0.0/0"), _p("::ffff:0:0/96")},
+		{_p4("10.0.0.0/8"), _p("::ffff:a00:0/104")},
+		{_p4("203.0.113.17/24"), _p("::ffff:cb00:7111/120")},
+		{_p4("203.0.113.17/32"), _p("::ffff:cb00:7111/128")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.v4.String(), func(t *testing.T) {
+			assert.Equal(t, tt.v6, IPv4MappedPrefix(tt.v4))
+
+			v4, ok := tt.v6.Unmap()
+			assert.True(t, ok)
+			assert.Equal(t, tt.v4, v4)
+		})
+	}
+
+	t.Run("not mapped", func(t *testing.T) {
+		_, ok := _p("::/0").Unmap()
+		assert.False(t, ok)
+		_, ok = _p("::ffff:0:0/95").Unmap()
+		assert.False(t, ok)
+		_, ok = _p("2001:db8::/120").Unmap()
+		assert.False(t, ok)
+	})
+}
+
+func TestIPv4MappedSet(t *testing.T) {
+	s := ipv4.NewSet_()
+	s.Insert(_p4("10.0.0.0/8"))
+	s.Insert(_p4("192.168.0.0/16"))
+	s.Insert(_a4("203.0.113.17"))
+	v4 := s.Set()
+
+	v6 := IPv4MappedSet(v4)
+	assert.Equal(t, v4.NumAddresses(), v6.NumAddresses().Int64())
+	assert.True(t, v6.Contains(_p("::ffff:a00:0/104")))
+	assert.True(t, v6.Contains(_p("::ffff:c0a8:0/112")))
+	assert.True(t, v6.Contains(_a("::ffff:203.0.113.17")))
+	assert.True(t, v4.Equal(v6.Unmap()))
+
+	t.Run("unmap ignores others", func(t *testing.T) {
+		s := NewSet_()
+		s.Insert(_p("::/0"))
+		assert.True(t, ipv4.Set{}.Union(_p4("0.0.0.0/0")).Equal(s.Set().Unmap()))
+
+		s = NewSet_()
+		s.Insert(_p("2001:db8::/32"))
+		assert.True(t, s.Set().Unmap().IsEmpty())
+	})
+}
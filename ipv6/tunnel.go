@@ -0,0 +1,156 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"fmt"
+
+	"gopkg.in/addrs.v1/ipv4"
+)
+
+// Tunnel identifies a transition mechanism which embeds an IPv4 address in an
+// IPv6 address
+type Tunnel int
+
+const (
+	// TunnelNone means that no IPv4 address is embedded
+	TunnelNone Tunnel = iota
+	// Tunnel6to4 is 6to4 (RFC 3056) which embeds the IPv4 address of the
+	// site's border router in a 2002::/16 address
+	Tunnel6to4
+	// TunnelTeredo is Teredo (RFC 4380) which embeds the server's IPv4
+	// address and the client's obfuscated external IPv4 address and port in a
+	// 2001::/32 address
+	TunnelTeredo
+	// TunnelISATAP is ISATAP (RFC 5214) which embeds the IPv4 address of the
+	// interface in the interface identifier
+	TunnelISATAP
+)
+
+// String returns the name of the mechanism
+func (me Tunnel) String() string {
+	switch me {
+	case TunnelNone:
+		return "none"
+	case Tunnel6to4:
+		return "6to4"
+	case TunnelTeredo:
+		return "Teredo"
+	case TunnelISATAP:
+		return "ISATAP"
+	}
+	return fmt.Sprintf("Tunnel(%d)", int(me))
+}
+
+// EmbeddedIPv4 describes the IPv4 information found in an IPv6 address
+type EmbeddedIPv4 struct {
+	// Tunnel is the transition mechanism
+	Tunnel Tunnel
+	// Address is the embedded IPv4 address: the border router for 6to4, the
+	// client's external address for Teredo (already de-obfuscated), and the
+	// interface's address for ISATAP
+	Address ipv4.Address
+	// Server is the address of the Teredo server. It is zero otherwise.
+	Server ipv4.Address
+	// Port is the client's external UDP port for Teredo (already
+	// de-obfuscated). It is zero otherwise.
+	Port uint16
+	// Flags is the Teredo flags field. It is zero otherwise.
+	Flags uint16
+}
+
+var (
+	// sixToFourPrefix is 2002::/16 from RFC 3056
+	sixToFourPrefix = Prefix{Address{Uint128{0x2002000000000000, 0}}, 16}
+	// teredoPrefix is 2001::/32 from RFC 4380
+	teredoPrefix = Prefix{Address{Uint128{0x2001000000000000, 0}}, 32}
+)
+
+const (
+	// isatapID is the upper 32 bits of an ISATAP interface identifier when
+	// the IPv4 address is not globally unique
+	isatapID = 0x00005efe
+	// isatapUniversalID is the same with the universal/local bit set for a
+	// globally unique IPv4 address
+	isatapUniversalID = 0x02005efe
+)
+
+// EmbeddedIPv4 decodes the IPv4 address embedded in this address by 6to4,
+// Teredo or ISATAP. If none of them apply, found is false and info.Tunnel is
+// TunnelNone. An ISATAP interface identifier can appear under any /64,
+// including a 6to4 one. In that case, 6to4 is reported because it is what
+// routes the packet over IPv4 first. Call ISATAP to get the interface's
+// address too.
+func (me Address) EmbeddedIPv4() (info EmbeddedIPv4, found bool) {
+	switch {
+	case teredoPrefix.containsAddress(me):
+		return EmbeddedIPv4{
+			Tunnel:  TunnelTeredo,
+			Address: ipv4.AddressFromUint32(^uint32(me.ui.low)),
+			Server:  ipv4.AddressFromUint32(uint32(me.ui.high)),
+			Port:    ^uint16(me.ui.low >> 32),
+			Flags:   uint16(me.ui.low >> 48),
+		}, true
+	case sixToFourPrefix.containsAddress(me):
+		return EmbeddedIPv4{
+			Tunnel:  Tunnel6to4,
+			Address: ipv4.AddressFromUint32(uint32(me.ui.high >> 16)),
+		}, true
+	}
+	if addr, ok := me.ISATAP(); ok {
+		return EmbeddedIPv4{
+			Tunnel:  TunnelISATAP,
+			Address: addr,
+		}, true
+	}
+	return EmbeddedIPv4{}, false
+}
+
+// ISATAP returns the IPv4 address embedded in this address if its interface
+// identifier is an ISATAP one (::0:5efe:a.b.c.d or ::200:5efe:a.b.c.d).
+// Otherwise, ok is false and the result must be ignored.
+func (me Address) ISATAP() (addr ipv4.Address, ok bool) {
+	switch me.ui.low >> 32 {
+	case isatapID, isatapUniversalID:
+		return ipv4.AddressFromUint32(uint32(me.ui.low)), true
+	}
+	return ipv4.Address{}, false
+}
+
+// SixToFourPrefix returns the 6to4 /48 prefix (2002:a.b.c.d::/48) for a site
+// whose border router has the given IPv4 address
+func SixToFourPrefix(router ipv4.Address) Prefix {
+	return Prefix{
+		Address{sixToFourPrefix.addr.ui.or(Uint128{uint64(router.Uint32()) << 16, 0})},
+		48,
+	}
+}
+
+// TeredoAddress returns the Teredo address for a client using the given
+// server whose external (mapped) address and port are given. The client's
+// address and port are obfuscated as required by RFC 4380.
+func TeredoAddress(server, client ipv4.Address, port, flags uint16) Address {
+	return Address{Uint128{
+		teredoPrefix.addr.ui.high | uint64(server.Uint32()),
+		uint64(flags)<<48 | uint64(^port)<<32 | uint64(^client.Uint32()),
+	}}
+}
+
+// ISATAPAddress returns the address made from the given /64 (or shorter)
+// prefix and an ISATAP interface identifier for the given IPv4 address. As
+// described in RFC 5214, the universal/local bit is set if the IPv4 address
+// is globally reachable.
+func ISATAPAddress(prefix Prefix, addr ipv4.Address) (Address, error) {
+	if prefix.length > 64 {
+		return Address{}, fmt.Errorf("ISATAP prefix must be /64 or shorter: %s", prefix)
+	}
+	id := uint64(isatapID)
+	if addr.IsGloballyReachable() {
+		id = isatapUniversalID
+	}
+	return Address{Uint128{
+		prefix.Network().addr.ui.high,
+		id<<32 | uint64(addr.Uint32()),
+	}}, nil
+}
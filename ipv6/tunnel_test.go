@@ -0,0 +1,91 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedIPv4(t *testing.T) {
+	tests := []struct {
+		address Address
+		found   bool
+		info    EmbeddedIPv4
+	}{
+		{_a("2001:db8::1"), false, EmbeddedIPv4{}},
+		{_a("::ffff:192.0.2.1"), false, EmbeddedIPv4{}},
+		{_a("2002:c000:22d::1"), true, EmbeddedIPv4{Tunnel: Tunnel6to4, Address: _a4("192.0.2.45")}},
+		{_a("2002:c000:22d:1:200:5efe:cb00:7111"), true, EmbeddedIPv4{Tunnel: Tunnel6to4, Address: _a4("192.0.2.45")}},
+		// This is the example from RFC 4380 section 4
+		{_a("2001:0:4136:e378:8000:63bf:3fff:fdd2"), true, EmbeddedIPv4{
+			Tunnel:  TunnelTeredo,
+			Address: _a4("192.0.2.45"),
+			Server:  _a4("65.54.227.120"),
+			Port:    40000,
+			Flags:   0x8000,
+		}},
+		{_a("fe80::5efe:c0a8:101"), true, EmbeddedIPv4{Tunnel: TunnelISATAP, Address: _a4("192.168.1.1")}},
+		{_a("2001:db8:1:2:200:5efe:cb00:7111"), true, EmbeddedIPv4{Tunnel: TunnelISATAP, Address: _a4("203.0.113.17")}},
+		{_a("2001:db8:1:2:100:5efe:cb00:7111"), false, EmbeddedIPv4{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address.String(), func(t *testing.T) {
+			info, found := tt.address.EmbeddedIPv4()
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.info, info)
+		})
+	}
+}
+
+func TestISATAP(t *testing.T) {
+	addr, ok := _a("2002:c000:22d:1:200:5efe:cb00:7111").ISATAP()
+	assert.True(t, ok)
+	assert.Equal(t, _a4("203.0.113.17"), addr)
+
+	_, ok = _a("2002:c000:22d::1").ISATAP()
+	assert.False(t, ok)
+}
+
+func TestTunnelString(t *testing.T) {
+	assert.Equal(t, "none", TunnelNone.String())
+	assert.Equal(t, "6to4", Tunnel6to4.String())
+	assert.Equal(t, "Teredo", TunnelTeredo.String())
+	assert.Equal(t, "ISATAP", TunnelISATAP.String())
+	assert.Equal(t, "Tunnel(7)", Tunnel(7).String())
+}
+
+func TestSixToFourPrefix(t *testing.T) {
+	prefix := SixToFourPrefix(_a4("192.0.2.45"))
+	assert.Equal(t, _p("2002:c000:22d::/48"), prefix)
+
+	info, found := prefix.Address().EmbeddedIPv4()
+	assert.True(t, found)
+	assert.Equal(t, Tunnel6to4, info.Tunnel)
+	assert.Equal(t, _a4("192.0.2.45"), info.Address)
+}
+
+func TestTeredoAddress(t *testing.T) {
+	addr := TeredoAddress(_a4("65.54.227.120"), _a4("192.0.2.45"), 40000, 0x8000)
+	assert.Equal(t, _a("2001:0:4136:e378:8000:63bf:3fff:fdd2"), addr)
+}
+
+func TestISATAPAddress(t *testing.T) {
+	addr, err := ISATAPAddress(_p("fe80::/64"), _a4("192.168.1.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, _a("fe80::5efe:c0a8:101"), addr)
+
+	addr, err = ISATAPAddress(_p("2001:db8:1:2::/64"), _a4("198.51.99.17"))
+	assert.Nil(t, err)
+	assert.Equal(t, _a("2001:db8:1:2:200:5efe:c633:6311"), addr)
+
+	addr, err = ISATAPAddress(_p("2001:db8:1:2::1/48"), _a4("10.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, _a("2001:db8:1::5efe:a00:1"), addr)
+
+	_, err = ISATAPAddress(_p("2001:db8:1:2::/80"), _a4("10.0.0.1"))
+	assert.NotNil(t, err)
+}
@@ -13,23 +13,34 @@ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLI
 package ipv6
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/big"
 	"math/bits"
+	"strconv"
 )
 
-type uint128 struct {
+// Uint128 is an unsigned 128 bit integer. It is used to represent an IPv6
+// address as an integer and to count addresses and prefixes in IPv6 sets.
+// The zero value is 0.
+type Uint128 struct {
 	high, low uint64
 }
 
-// uint128FromBytes returns the the uint128 converted from a array of 16 bytes
-func uint128FromBytes(s []byte) (uint128, error) {
+// Uint128FromUint64 returns the Uint128 with the given high and low 64 bits
+func Uint128FromUint64(high, low uint64) Uint128 {
+	return Uint128{high, low}
+}
+
+// uint128FromBytes returns the the Uint128 converted from a array of 16 bytes
+func uint128FromBytes(s []byte) (Uint128, error) {
 	if s == nil {
-		return uint128{}, fmt.Errorf("failed to parse nil uint128 bytes")
+		return Uint128{}, fmt.Errorf("failed to parse nil Uint128 bytes")
 	}
 	if len(s) != 16 {
-		return uint128{}, fmt.Errorf("failed to parse uint128 because slice size is not equal to 16")
+		return Uint128{}, fmt.Errorf("failed to parse Uint128 because slice size is not equal to 16")
 	}
-	return uint128{
+	return Uint128{
 		high: uint64(s[0])<<56 |
 			uint64(s[1])<<48 |
 			uint64(s[2])<<40 |
@@ -49,8 +60,23 @@ func uint128FromBytes(s []byte) (uint128, error) {
 	}, nil
 }
 
-// toBytes returns a slice representation of 16 bytes of the uint128
-func (me uint128) toBytes() []byte {
+// uint128FromArray returns the Uint128 converted from an array of 16 bytes
+func uint128FromArray(a [16]byte) Uint128 {
+	return Uint128{
+		high: binary.BigEndian.Uint64(a[:8]),
+		low:  binary.BigEndian.Uint64(a[8:]),
+	}
+}
+
+// toArray returns an array representation of 16 bytes of the Uint128
+func (me Uint128) toArray() (a [16]byte) {
+	binary.BigEndian.PutUint64(a[:8], me.high)
+	binary.BigEndian.PutUint64(a[8:], me.low)
+	return
+}
+
+// toBytes returns a slice representation of 16 bytes of the Uint128
+func (me Uint128) toBytes() []byte {
 	bytes := []byte{
 		byte(0xff & (me.high >> 56)),
 		byte(0xff & (me.high >> 48)),
@@ -72,18 +98,38 @@ func (me uint128) toBytes() []byte {
 	return bytes
 }
 
-// uint64 returns the uint128 as two uint64
-func (me uint128) uint64() (uint64, uint64) {
+// Uint64 returns the Uint128 as two uint64s, the high and low bits
+func (me Uint128) Uint64() (uint64, uint64) {
 	return me.high, me.low
 }
 
+// IsZero returns true if the value is 0
+func (me Uint128) IsZero() bool {
+	return me == Uint128{}
+}
+
+// Big returns the value as a new *big.Int
+func (me Uint128) Big() *big.Int {
+	b := new(big.Int).SetUint64(me.high)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(me.low))
+}
+
+// String returns the value in decimal
+func (me Uint128) String() string {
+	if me.high == 0 {
+		return strconv.FormatUint(me.low, 10)
+	}
+	return me.Big().String()
+}
+
 // onesCount returns the number of one bits ("population count") in x.
-func (me uint128) onesCount() int {
+func (me Uint128) onesCount() int {
 	return bits.OnesCount64(me.high) + bits.OnesCount64(me.low)
 }
 
 // leadingZeros returns the number of leading zero bits in x; the result is 128 for x == 0.
-func (me uint128) leadingZeros() int {
+func (me Uint128) leadingZeros() int {
 	leadingZeros := bits.LeadingZeros64(me.high)
 	if leadingZeros == 64 {
 		leadingZeros += bits.LeadingZeros64(me.low)
@@ -91,6 +137,15 @@ func (me uint128) leadingZeros() int {
 	return leadingZeros
 }
 
+// trailingZeros returns the number of trailing zero bits in x; the result is 128 for x == 0.
+func (me Uint128) trailingZeros() int {
+	trailingZeros := bits.TrailingZeros64(me.low)
+	if trailingZeros == 64 {
+		trailingZeros += bits.TrailingZeros64(me.high)
+	}
+	return trailingZeros
+}
+
 // compare returns comparison of two uint128s and returns:
 //
 //	O if equal
@@ -98,7 +153,7 @@ func (me uint128) leadingZeros() int {
 // -1 if me is less than other
 //
 //	1 if me is greater than other
-func (me uint128) compare(other uint128) int {
+func (me Uint128) compare(other Uint128) int {
 	if me == other {
 		return 0
 	}
@@ -108,48 +163,65 @@ func (me uint128) compare(other uint128) int {
 	return 1
 }
 
-// addUint64 returns sum of uint128 with x (uint64)
-func (me uint128) addUint64(x uint64) uint128 {
+// addUint64 returns sum of Uint128 with x (uint64)
+func (me Uint128) addUint64(x uint64) Uint128 {
 	low := me.low + x
 	high := me.high
 	if me.low > low {
 		high++
 	}
-	return uint128{high, low}
+	return Uint128{high, low}
 }
 
-// addUint64 returns difference of uint128 with x (uint64)
-func (me uint128) subtractUint64(x uint64) uint128 {
+// subtractUint64 returns difference of Uint128 with x (uint64)
+func (me Uint128) subtractUint64(x uint64) Uint128 {
 	low := me.low - x
 	high := me.high
 	if me.low < low {
 		high--
 	}
-	return uint128{high, low}
+	return Uint128{high, low}
+}
+
+// add returns the sum of Uint128 with x (Uint128) and whether it overflowed
+func (me Uint128) add(x Uint128) (Uint128, bool) {
+	low, carry := bits.Add64(me.low, x.low, 0)
+	high, carry := bits.Add64(me.high, x.high, carry)
+	return Uint128{high, low}, carry != 0
+}
+
+// subtract returns the difference of Uint128 with x (Uint128)
+func (me Uint128) subtract(x Uint128) Uint128 {
+	low := me.low - x.low
+	high := me.high - x.high
+	if me.low < x.low {
+		high--
+	}
+	return Uint128{high, low}
 }
 
 // and returns a bitwise AND with x
-func (me uint128) and(x uint128) uint128 {
-	return uint128{me.high & x.high, me.low & x.low}
+func (me Uint128) and(x Uint128) Uint128 {
+	return Uint128{me.high & x.high, me.low & x.low}
 }
 
 // or returns a bitwise OR with x
-func (me uint128) or(x uint128) uint128 {
-	return uint128{me.high | x.high, me.low | x.low}
+func (me Uint128) or(x Uint128) Uint128 {
+	return Uint128{me.high | x.high, me.low | x.low}
 }
 
 // xor returns a bitwise XOR with x
-func (me uint128) xor(x uint128) uint128 {
-	return uint128{me.high ^ x.high, me.low ^ x.low}
+func (me Uint128) xor(x Uint128) Uint128 {
+	return Uint128{me.high ^ x.high, me.low ^ x.low}
 }
 
 // complement returns the bitwise complement
-func (me uint128) complement() uint128 {
-	return uint128{^me.high, ^me.low}
+func (me Uint128) complement() Uint128 {
+	return Uint128{^me.high, ^me.low}
 }
 
 // leftShift returns the bitwise shift left by bits
-func (me uint128) leftShift(bits int) uint128 {
+func (me Uint128) leftShift(bits int) Uint128 {
 	high := me.high
 	low := me.low
 	if bits >= 128 {
@@ -163,11 +235,11 @@ func (me uint128) leftShift(bits int) uint128 {
 		high |= low >> (64 - bits)
 		low <<= bits
 	}
-	return uint128{high, low}
+	return Uint128{high, low}
 }
 
 // rightShift returns the bitwise shift right by bits
-func (me uint128) rightShift(bits int) uint128 {
+func (me Uint128) rightShift(bits int) Uint128 {
 	high := me.high
 	low := me.low
 	if bits >= 128 {
@@ -181,5 +253,5 @@ func (me uint128) rightShift(bits int) uint128 {
 		low |= high << (64 - bits)
 		high >>= bits
 	}
-	return uint128{high, low}
+	return Uint128{high, low}
 }
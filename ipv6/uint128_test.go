@@ -10,7 +10,7 @@ func TestUint128FromBytes(t *testing.T) {
 	tests := []struct {
 		description string
 		bytes       []byte
-		expected    uint128
+		expected    Uint128
 		isErr       bool
 	}{
 		{
@@ -26,7 +26,7 @@ func TestUint128FromBytes(t *testing.T) {
 		{
 			description: "valid",
 			bytes:       []byte{0x20, 0x1, 0xd, 0xb8, 0x85, 0xa3, 0x0, 0x0, 0x0, 0x0, 0x8a, 0x2e, 0x3, 0x70, 0x74, 0x34},
-			expected:    uint128{0x20010db885a30000, 0x8a2e03707434},
+			expected:    Uint128{0x20010db885a30000, 0x8a2e03707434},
 		},
 	}
 
@@ -45,56 +45,64 @@ func TestUint128FromBytes(t *testing.T) {
 
 func TestUint128ToBytes(t *testing.T) {
 	uint128Bytes := []byte{0x20, 0x1, 0xd, 0xb8, 0x85, 0xa3, 0x0, 0x0, 0x0, 0x0, 0x8a, 0x2e, 0x3, 0x70, 0x74, 0x34}
-	assert.Equal(t, uint128{0x20010db885a30000, 0x8a2e03707434}.toBytes(), uint128Bytes)
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x8a2e03707434}.toBytes(), uint128Bytes)
 }
 
 func TestLeadingZero(t *testing.T) {
-	assert.Equal(t, 128, uint128{0x0, 0x0}.leadingZeros())
-	assert.Equal(t, 100, uint128{0x0, 0x000000000F000000}.leadingZeros())
-	assert.Equal(t, 84, uint128{0x0, 0x000e0000000000}.leadingZeros())
-	assert.Equal(t, 64, uint128{0x0, 0xF000000000000000}.leadingZeros())
-	assert.Equal(t, 36, uint128{0x000000000FFFFFFF, 0x0}.leadingZeros())
-	assert.Equal(t, 0, uint128{0xF000000000000000, 0x0}.leadingZeros())
+	assert.Equal(t, 128, Uint128{0x0, 0x0}.leadingZeros())
+	assert.Equal(t, 100, Uint128{0x0, 0x000000000F000000}.leadingZeros())
+	assert.Equal(t, 84, Uint128{0x0, 0x000e0000000000}.leadingZeros())
+	assert.Equal(t, 64, Uint128{0x0, 0xF000000000000000}.leadingZeros())
+	assert.Equal(t, 36, Uint128{0x000000000FFFFFFF, 0x0}.leadingZeros())
+	assert.Equal(t, 0, Uint128{0xF000000000000000, 0x0}.leadingZeros())
+}
+
+func TestTrailingZero(t *testing.T) {
+	assert.Equal(t, 128, Uint128{0x0, 0x0}.trailingZeros())
+	assert.Equal(t, 0, Uint128{0x0, 0x1}.trailingZeros())
+	assert.Equal(t, 24, Uint128{0x0, 0x000000000F000000}.trailingZeros())
+	assert.Equal(t, 64, Uint128{0x1, 0x0}.trailingZeros())
+	assert.Equal(t, 127, Uint128{0x8000000000000000, 0x0}.trailingZeros())
 }
 
 func TestOnesCount(t *testing.T) {
-	assert.Equal(t, 0, uint128{0x0, 0x0}.onesCount())
-	assert.Equal(t, 19, uint128{0x0, 0x8a2e03707434}.onesCount())
-	assert.Equal(t, 35, uint128{0x20010db885a30000, 0x8a2e03707434}.onesCount())
-	assert.Equal(t, 61, uint128{0x20010FFFFa30000, 0x8a2e0FFFFFFFF}.onesCount())
-	assert.Equal(t, 80, uint128{0x20010db8FFFFFFF, 0x8FFFFF707FFFF}.onesCount())
-	assert.Equal(t, 128, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.onesCount())
+	assert.Equal(t, 0, Uint128{0x0, 0x0}.onesCount())
+	assert.Equal(t, 19, Uint128{0x0, 0x8a2e03707434}.onesCount())
+	assert.Equal(t, 35, Uint128{0x20010db885a30000, 0x8a2e03707434}.onesCount())
+	assert.Equal(t, 61, Uint128{0x20010FFFFa30000, 0x8a2e0FFFFFFFF}.onesCount())
+	assert.Equal(t, 80, Uint128{0x20010db8FFFFFFF, 0x8FFFFF707FFFF}.onesCount())
+	assert.Equal(t, 128, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.onesCount())
 }
 
 func TestCompare(t *testing.T) {
 	tests := []struct {
 		description string
-		num         uint128
+		num         Uint128
 		expected    int
-		comparedTo  uint128
+		comparedTo  Uint128
 	}{
 		{
 			description: "equal",
-			num:         uint128{0x20010db885a30000, 0x8a2e03707434},
-			comparedTo:  uint128{0x20010db885a30000, 0x8a2e03707434},
+			num:         Uint128{0x20010db885a30000, 0x8a2e03707434},
+			comparedTo:  Uint128{0x20010db885a30000, 0x8a2e03707434},
 			expected:    0,
 		},
 		{
 			description: "high less than",
-			num:         uint128{0x20010db885a30000, 0x8a2e03707434},
-			comparedTo:  uint128{0x20010db885a30001, 0x8a2e03707434},
+			num:         Uint128{0x20010db885a30000, 0x8a2e03707434},
+			comparedTo:  Uint128{0x20010db885a30001, 0x8a2e03707434},
 			expected:    -1,
 		},
 		{
 			description: "low less than",
-			num:         uint128{0x20010db885a30000, 0x8a2e03707434},
-			comparedTo:  uint128{0x20010db885a30000, 0x8a2e03707435},
+			num:         Uint128{0x20010db885a30000, 0x8a2e03707434},
+			comparedTo:  Uint128{0x20010db885a30000, 0x8a2e03707435},
 			expected:    -1,
 		},
 		{
 			description: "greater than",
-			num:         uint128{0x20010db885a30000, 0x8a2e03707435},
-			comparedTo:  uint128{0x20010db885a30000, 0x8a2e03707434},
+			num:         Uint128{0x20010db885a30000, 0x8a2e03707435},
+			comparedTo:  Uint128{0x20010db885a30000, 0x8a2e03707434},
 			expected:    1,
 		},
 	}
@@ -106,37 +114,37 @@ func TestCompare(t *testing.T) {
 }
 
 func TestComplement(t *testing.T) {
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, uint128{0x0, 0x0}.complement())
-	assert.Equal(t, uint128{0xDFFEF2477A5CFFFF, 0xFFFF75D1FC8F8BCB}, uint128{0x20010db885a30000, 0x8a2e03707434}.complement())
-	assert.Equal(t, uint128{0xFF15D400005CF286, 0xFFF75D1F00000000}, uint128{0x0ea2bFFFFa30d79, 0x8a2e0FFFFFFFF}.complement())
-	assert.Equal(t, uint128{0xFFFFFFFFF0000000, 0xFFFFFFFFFFFFFFFF}, uint128{0x000000000FFFFFFF, 0x0}.complement())
-	assert.Equal(t, uint128{0x0, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.complement())
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, Uint128{0x0, 0x0}.complement())
+	assert.Equal(t, Uint128{0xDFFEF2477A5CFFFF, 0xFFFF75D1FC8F8BCB}, Uint128{0x20010db885a30000, 0x8a2e03707434}.complement())
+	assert.Equal(t, Uint128{0xFF15D400005CF286, 0xFFF75D1F00000000}, Uint128{0x0ea2bFFFFa30d79, 0x8a2e0FFFFFFFF}.complement())
+	assert.Equal(t, Uint128{0xFFFFFFFFF0000000, 0xFFFFFFFFFFFFFFFF}, Uint128{0x000000000FFFFFFF, 0x0}.complement())
+	assert.Equal(t, Uint128{0x0, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.complement())
 }
 
 func TestAnd(t *testing.T) {
 	tests := []struct {
 		description string
-		num         uint128
-		andWith     uint128
-		expected    uint128
+		num         Uint128
+		andWith     Uint128
+		expected    Uint128
 	}{
 		{
 			description: "same",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			andWith:     uint128{0x20010db885a30000, 0x00008a2e03707434},
-			expected:    uint128{0x20010db885a30000, 0x00008a2e03707434},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			andWith:     Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			expected:    Uint128{0x20010db885a30000, 0x00008a2e03707434},
 		},
 		{
 			description: "different",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			andWith:     uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
-			expected:    uint128{0x20010c8885020000, 0x00008a0e03701034},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			andWith:     Uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
+			expected:    Uint128{0x20010c8885020000, 0x00008a0e03701034},
 		},
 		{
 			description: "extreme",
-			num:         uint128{0x0, 0x0},
-			andWith:     uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
-			expected:    uint128{0x0, 0x0},
+			num:         Uint128{0x0, 0x0},
+			andWith:     Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+			expected:    Uint128{0x0, 0x0},
 		},
 	}
 	for _, tt := range tests {
@@ -149,27 +157,27 @@ func TestAnd(t *testing.T) {
 func TestOr(t *testing.T) {
 	tests := []struct {
 		description string
-		num         uint128
-		orWith      uint128
-		expected    uint128
+		num         Uint128
+		orWith      Uint128
+		expected    Uint128
 	}{
 		{
 			description: "same",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			orWith:      uint128{0x20010db885a30000, 0x00008a2e03707434},
-			expected:    uint128{0x20010db885a30000, 0x00008a2e03707434},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			orWith:      Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			expected:    Uint128{0x20010db885a30000, 0x00008a2e03707434},
 		},
 		{
 			description: "different",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			orWith:      uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
-			expected:    uint128{0x27810ff88fb3c000, 0x11008a7fa37d7d34},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			orWith:      Uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
+			expected:    Uint128{0x27810ff88fb3c000, 0x11008a7fa37d7d34},
 		},
 		{
 			description: "extreme",
-			num:         uint128{0x0, 0x0},
-			orWith:      uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
-			expected:    uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+			num:         Uint128{0x0, 0x0},
+			orWith:      Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+			expected:    Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
 		},
 	}
 	for _, tt := range tests {
@@ -182,27 +190,27 @@ func TestOr(t *testing.T) {
 func TestXor(t *testing.T) {
 	tests := []struct {
 		description string
-		num         uint128
-		orWith      uint128
-		expected    uint128
+		num         Uint128
+		orWith      Uint128
+		expected    Uint128
 	}{
 		{
 			description: "same",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			orWith:      uint128{0x20010db885a30000, 0x00008a2e03707434},
-			expected:    uint128{0x0, 0x0},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			orWith:      Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			expected:    Uint128{0x0, 0x0},
 		},
 		{
 			description: "different",
-			num:         uint128{0x20010db885a30000, 0x00008a2e03707434},
-			orWith:      uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
-			expected:    uint128{0x78003700ab1c000, 0x11000071a00d6d00},
+			num:         Uint128{0x20010db885a30000, 0x00008a2e03707434},
+			orWith:      Uint128{0x27810ec88f12c000, 0x11008a5fa37d1934},
+			expected:    Uint128{0x78003700ab1c000, 0x11000071a00d6d00},
 		},
 		{
 			description: "extreme",
-			num:         uint128{0x0, 0x0},
-			orWith:      uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
-			expected:    uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+			num:         Uint128{0x0, 0x0},
+			orWith:      Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
+			expected:    Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF},
 		},
 	}
 	for _, tt := range tests {
@@ -213,39 +221,72 @@ func TestXor(t *testing.T) {
 }
 
 func TestLeftShift(t *testing.T) {
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(0))
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFF00000000}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(32))
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFF800000000000}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(47))
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(64))
-	assert.Equal(t, uint128{0xFFFFFFFFFFFF8000, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(79))
-	assert.Equal(t, uint128{0xFFFFFFF000000000, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(100))
-	assert.Equal(t, uint128{0x0, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(128))
-	assert.Equal(t, uint128{0x0, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(129))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(0))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFF00000000}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(32))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFF800000000000}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(47))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(64))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFF8000, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(79))
+	assert.Equal(t, Uint128{0xFFFFFFF000000000, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(100))
+	assert.Equal(t, Uint128{0x0, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(128))
+	assert.Equal(t, Uint128{0x0, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.leftShift(129))
 }
 
 func TestRightShift(t *testing.T) {
-	assert.Equal(t, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(0))
-	assert.Equal(t, uint128{0x00000000FFFFFFFF, 0xFFFFFFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(32))
-	assert.Equal(t, uint128{0x000000000001FFFF, 0xFFFFFFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(47))
-	assert.Equal(t, uint128{0x0, 0xFFFFFFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(64))
-	assert.Equal(t, uint128{0x0, 0x0001FFFFFFFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(79))
-	assert.Equal(t, uint128{0x0, 0x000000000FFFFFFF}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(100))
-	assert.Equal(t, uint128{0x0, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(128))
-	assert.Equal(t, uint128{0x0, 0x0}, uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(129))
+	assert.Equal(t, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(0))
+	assert.Equal(t, Uint128{0x00000000FFFFFFFF, 0xFFFFFFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(32))
+	assert.Equal(t, Uint128{0x000000000001FFFF, 0xFFFFFFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(47))
+	assert.Equal(t, Uint128{0x0, 0xFFFFFFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(64))
+	assert.Equal(t, Uint128{0x0, 0x0001FFFFFFFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(79))
+	assert.Equal(t, Uint128{0x0, 0x000000000FFFFFFF}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(100))
+	assert.Equal(t, Uint128{0x0, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(128))
+	assert.Equal(t, Uint128{0x0, 0x0}, Uint128{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}.rightShift(129))
 }
 
 func TestAddUint64(t *testing.T) {
-	assert.Equal(t, uint128{0x20010db885a30001, 0x00008a2e03707433}, uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0xFFFFFFFFFFFFFFFF))
-	assert.Equal(t, uint128{0, 0x00008a2e03707433}, uint128{0xffffffffffffffff, 0x00008a2e03707434}.addUint64(0xFFFFFFFFFFFFFFFF))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2f03707433}, uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0x00000000FFFFFFFF))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2e03707435}, uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(1))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2e03707434}, uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0))
+	assert.Equal(t, Uint128{0x20010db885a30001, 0x00008a2e03707433}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0xFFFFFFFFFFFFFFFF))
+	assert.Equal(t, Uint128{0, 0x00008a2e03707433}, Uint128{0xffffffffffffffff, 0x00008a2e03707434}.addUint64(0xFFFFFFFFFFFFFFFF))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2f03707433}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0x00000000FFFFFFFF))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2e03707435}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(1))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2e03707434}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.addUint64(0))
 }
 
 func TestSubtractUint64(t *testing.T) {
-	assert.Equal(t, uint128{0x20010db885a2ffff, 0x00008a2e03707435}, uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0xFFFFFFFFFFFFFFFF))
-	assert.Equal(t, uint128{0xffffffffffffffff, 0x00008a2e03707435}, uint128{0, 0x00008a2e03707434}.subtractUint64(0xFFFFFFFFFFFFFFFF))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2d03707435}, uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0x00000000FFFFFFFF))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2e03707433}, uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(1))
-	assert.Equal(t, uint128{0x20010db885a30000, 0x00008a2e03707434}, uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0))
+	assert.Equal(t, Uint128{0x20010db885a2ffff, 0x00008a2e03707435}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0xFFFFFFFFFFFFFFFF))
+	assert.Equal(t, Uint128{0xffffffffffffffff, 0x00008a2e03707435}, Uint128{0, 0x00008a2e03707434}.subtractUint64(0xFFFFFFFFFFFFFFFF))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2d03707435}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0x00000000FFFFFFFF))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2e03707433}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(1))
+	assert.Equal(t, Uint128{0x20010db885a30000, 0x00008a2e03707434}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtractUint64(0))
+}
+
+func TestSubtract(t *testing.T) {
+	assert.Equal(t, Uint128{0x20010db885a2ffff, 0x00008a2e03707435}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtract(Uint128{0, 0xFFFFFFFFFFFFFFFF}))
+	assert.Equal(t, Uint128{0x0000000000000001, 0x0000000000000000}, Uint128{0x20010db885a30001, 0x00008a2e03707434}.subtract(Uint128{0x20010db885a30000, 0x00008a2e03707434}))
+	assert.Equal(t, Uint128{0xffffffffffffffff, 0xffffffffffffffff}, Uint128{0, 0}.subtract(Uint128{0, 1}))
+	assert.Equal(t, Uint128{0, 0}, Uint128{0x20010db885a30000, 0x00008a2e03707434}.subtract(Uint128{0x20010db885a30000, 0x00008a2e03707434}))
+}
+
+func TestUint128Exported(t *testing.T) {
+	ui := Uint128FromUint64(0x20010db885a30000, 0x00008a2e03707434)
+	high, low := ui.Uint64()
+	assert.Equal(t, uint64(0x20010db885a30000), high)
+	assert.Equal(t, uint64(0x00008a2e03707434), low)
+	assert.False(t, ui.IsZero())
+	assert.True(t, Uint128{}.IsZero())
+
+	assert.Equal(t, "0", Uint128{}.String())
+	assert.Equal(t, "18446744073709551615", Uint128FromUint64(0, 0xffffffffffffffff).String())
+	assert.Equal(t, "18446744073709551616", Uint128FromUint64(1, 0).String())
+	assert.Equal(t, "340282366920938463463374607431768211455", Uint128FromUint64(0xffffffffffffffff, 0xffffffffffffffff).String())
+
+	assert.Equal(t, ui, AddressFromUint128(ui).Uint128())
+}
+
+func TestAdd(t *testing.T) {
+	sum, overflow := Uint128{0, 0xffffffffffffffff}.add(Uint128{0, 1})
+	assert.Equal(t, Uint128{1, 0}, sum)
+	assert.False(t, overflow)
+
+	sum, overflow = Uint128{0x8000000000000000, 0}.add(Uint128{0x8000000000000000, 0})
+	assert.Equal(t, Uint128{0, 0}, sum)
+	assert.True(t, overflow)
 }
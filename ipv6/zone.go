@@ -0,0 +1,133 @@
+package ipv6
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ZonedAddress is an Address plus a zone identifier as described in RFC 4007
+// (e.g. fe80::1%eth0). The zone says which link (usually an interface name
+// or index) a link-local address belongs to. An empty zone means there is
+// none.
+//
+// It is comparable and can be used as a map key. Two zoned addresses are only
+// equal if both the addresses and the zones are equal.
+//
+// It deliberately doesn't implement PrefixI or SetI. Tables and sets don't
+// know about zones so passing one to them would silently drop the zone and
+// mix up addresses from different links. Use a ZonedTable for zone-aware
+// lookups or call Address() to ignore the zone explicitly.
+type ZonedAddress struct {
+	addr Address
+	zone string
+}
+
+// WithZone returns a ZonedAddress with this address and the given zone. An
+// empty zone means there is none.
+func (me Address) WithZone(zone string) ZonedAddress {
+	return ZonedAddress{me, zone}
+}
+
+// ZonedAddressFromString parses an address in colon notation followed by an
+// optional zone introduced by '%' (e.g. fe80::1%eth0). Everything after the
+// first '%' is the zone and it can't be empty. Errors that occur while parsing
+// are of type *ParseError.
+func ZonedAddressFromString(address string) (ZonedAddress, error) {
+	s, zone := address, ""
+	if i := strings.IndexByte(address, '%'); i >= 0 {
+		s, zone = address[:i], address[i+1:]
+		if zone == "" {
+			return ZonedAddress{}, &ParseError{Input: address, Pos: len(address), Msg: "zone is empty"}
+		}
+	}
+	addr, pos, msg := parseAddress(s, false)
+	if msg != "" {
+		return ZonedAddress{}, &ParseError{Input: address, Pos: pos, Msg: msg}
+	}
+	return ZonedAddress{addr, zone}, nil
+}
+
+// ZonedAddressFromNetIPAddr converts a *net.IPAddr to a ZonedAddress. Its IP
+// must be a 16 byte IPv6 address.
+func ZonedAddressFromNetIPAddr(addr *net.IPAddr) (ZonedAddress, error) {
+	if addr == nil {
+		return ZonedAddress{}, fmt.Errorf("failed to convert nil *net.IPAddr")
+	}
+	a, err := AddressFromNetIP(addr.IP)
+	if err != nil {
+		return ZonedAddress{}, err
+	}
+	return ZonedAddress{a, addr.Zone}, nil
+}
+
+// ZonedAddressFromNetipAddr converts a netip.Addr, including its zone, to a
+// ZonedAddress. The netip.Addr must be an IPv6 address.
+func ZonedAddressFromNetipAddr(addr netip.Addr) (ZonedAddress, error) {
+	a, err := AddressFromNetipAddr(addr)
+	if err != nil {
+		return ZonedAddress{}, err
+	}
+	return ZonedAddress{a, addr.Zone()}, nil
+}
+
+// Address returns the address without the zone
+func (me ZonedAddress) Address() Address {
+	return me.addr
+}
+
+// Zone returns the zone or "" if there is none
+func (me ZonedAddress) Zone() string {
+	return me.zone
+}
+
+// ToNetIPAddr returns a *net.IPAddr with the address and zone
+func (me ZonedAddress) ToNetIPAddr() *net.IPAddr {
+	return &net.IPAddr{IP: me.addr.ToNetIP(), Zone: me.zone}
+}
+
+// ToNetipAddr returns the netip.Addr representation of the address with the
+// zone
+func (me ZonedAddress) ToNetipAddr() netip.Addr {
+	return me.addr.ToNetipAddr().WithZone(me.zone)
+}
+
+// String returns the address in canonical notation followed by '%' and the
+// zone if there is one
+func (me ZonedAddress) String() string {
+	if me.zone == "" {
+		return me.addr.String()
+	}
+	return me.addr.String() + "%" + me.zone
+}
+
+// MarshalText implements encoding.TextMarshaler using the same format as
+// String()
+func (me ZonedAddress) MarshalText() ([]byte, error) {
+	return []byte(me.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using
+// ZonedAddressFromString
+func (me *ZonedAddress) UnmarshalText(text []byte) error {
+	address, err := ZonedAddressFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*me = address
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The address is encoded as a JSON
+// string.
+func (me ZonedAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the address
+// unchanged.
+func (me *ZonedAddress) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, me)
+}
@@ -0,0 +1,118 @@
+package ipv6
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonedAddressFromString(t *testing.T) {
+	tests := []struct {
+		input   string
+		address Address
+		zone    string
+		str     string
+	}{
+		{"fe80::1%eth0", _a("fe80::1"), "eth0", "fe80::1%eth0"},
+		{"fe80::1%2", _a("fe80::1"), "2", "fe80::1%2"},
+		{"FE80:0::1%en0%1", _a("fe80::1"), "en0%1", "fe80::1%en0%1"},
+		{"2001:db8::1", _a("2001:db8::1"), "", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			zoned, err := ZonedAddressFromString(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.address, zoned.Address())
+			assert.Equal(t, tt.zone, zoned.Zone())
+			assert.Equal(t, tt.str, zoned.String())
+			assert.Equal(t, tt.address.WithZone(tt.zone), zoned)
+		})
+	}
+}
+
+func TestZonedAddressFromStringErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"fe80::1%", 8},
+		{"%eth0", 0},
+		{"fe80::g%eth0", 6},
+		{"10.0.0.1%eth0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ZonedAddressFromString(tt.input)
+			assert.NotNil(t, err)
+			if parseErr, ok := err.(*ParseError); assert.True(t, ok) {
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+			}
+		})
+	}
+}
+
+func TestZonedAddressComparable(t *testing.T) {
+	a := _a("fe80::1").WithZone("eth0")
+	assert.True(t, a == _a("fe80::1").WithZone("eth0"))
+	assert.False(t, a == _a("fe80::1").WithZone("eth1"))
+	assert.False(t, a == _a("fe80::2").WithZone("eth0"))
+
+	m := map[ZonedAddress]int{a: 1}
+	assert.Equal(t, 1, m[_a("fe80::1").WithZone("eth0")])
+	assert.Equal(t, 0, m[_a("fe80::1").WithZone("eth1")])
+}
+
+func TestZonedAddressNetIPAddr(t *testing.T) {
+	zoned := _a("fe80::1").WithZone("eth0")
+	ipAddr := zoned.ToNetIPAddr()
+	assert.Equal(t, &net.IPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0"}, ipAddr)
+
+	converted, err := ZonedAddressFromNetIPAddr(ipAddr)
+	assert.Nil(t, err)
+	assert.Equal(t, zoned, converted)
+
+	_, err = ZonedAddressFromNetIPAddr(nil)
+	assert.NotNil(t, err)
+	_, err = ZonedAddressFromNetIPAddr(&net.IPAddr{IP: net.IP{10, 0, 0, 1}})
+	assert.NotNil(t, err)
+}
+
+func TestZonedAddressNetipAddr(t *testing.T) {
+	zoned := _a("fe80::1").WithZone("eth0")
+	assert.Equal(t, netip.MustParseAddr("fe80::1%eth0"), zoned.ToNetipAddr())
+	assert.Equal(t, netip.MustParseAddr("fe80::1"), _a("fe80::1").WithZone("").ToNetipAddr())
+
+	converted, err := ZonedAddressFromNetipAddr(netip.MustParseAddr("fe80::1%eth0"))
+	assert.Nil(t, err)
+	assert.Equal(t, zoned, converted)
+
+	_, err = ZonedAddressFromNetipAddr(netip.MustParseAddr("10.0.0.1"))
+	assert.NotNil(t, err)
+}
+
+func TestZonedAddressJSON(t *testing.T) {
+	zoned := _a("fe80::1").WithZone("eth0")
+	data, err := json.Marshal(zoned)
+	assert.Nil(t, err)
+	assert.Equal(t, `"fe80::1%eth0"`, string(data))
+
+	var decoded ZonedAddress
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, zoned, decoded)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"fe80::1%"`), &decoded))
+}
+
+func TestZonedAddressNotPrefixI(t *testing.T) {
+	var zoned interface{} = _a("fe80::1").WithZone("eth0")
+	_, ok := zoned.(PrefixI)
+	assert.False(t, ok)
+	_, ok = zoned.(SetI)
+	assert.False(t, ok)
+}
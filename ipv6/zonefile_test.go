@@ -0,0 +1,117 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseZonesFromTable(t *testing.T) {
+	table := NewTable_[string]()
+	table.Insert(_a("2001:db8:1::1"), "www.example.com.")
+	table.Insert(_a("2001:db8:1:0:1::1"), "mail.example.com.")
+	table.Insert(_a("2001:db8:2::1"), "gw.example.com.")
+	table.Insert(_p("2001:db8:4::/63"), "pool-${0,4,x}.example.com.")
+
+	tests := []struct {
+		length int
+		names  []string
+	}{
+		{64, []string{
+			"0.0.0.0.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"0.0.0.0.2.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"0.0.0.0.4.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"1.0.0.0.4.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		}},
+		{48, []string{
+			"1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"2.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+			"4.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		}},
+	}
+
+	for _, tt := range tests {
+		zones, err := ReverseZonesFromTable(table.Table(), tt.length)
+		assert.Nil(t, err)
+		var names []string
+		for _, zone := range zones {
+			names = append(names, zone.Name)
+		}
+		assert.Equal(t, tt.names, names, "/%d", tt.length)
+	}
+
+	for _, length := range []int{-4, 62, 128} {
+		_, err := ReverseZonesFromTable(table.Table(), length)
+		assert.NotNil(t, err, "/%d", length)
+	}
+}
+
+func TestWriteReverseZone(t *testing.T) {
+	table := NewTable_[string]()
+	table.Insert(_a("2001:db8:1::1"), "www.example.com")
+	table.Insert(_p("2001:db8:1::/111"), "pool-${0,4,x}.example.com.")
+
+	var b bytes.Buffer
+	err := WriteReverseZone(&b, _p("2001:db8:1::/64").ReverseZones()[0], table.Table(), ZoneFileOptions{
+		TTL:       3600,
+		PrimaryNS: "ns1.example.com.",
+		Mailbox:   "hostmaster.example.com.",
+		Serial:    1,
+		Refresh:   7200,
+		Retry:     900,
+		Expire:    1209600,
+		Minimum:   300,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `$ORIGIN 0.0.0.0.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.
+$TTL 3600
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		1	; serial
+		7200	; refresh
+		900	; retry
+		1209600	; expire
+		300	; minimum
+		)
+@	IN	NS	ns1.example.com.
+$GENERATE 0-0 ${0,7,n}.0.0.0.0.0.0.0.0.0.0.0.0 PTR pool-${0,4,x}.example.com.
+1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0	IN	PTR	www.example.com.
+$GENERATE 2-65535 ${0,7,n}.0.0.0.0.0.0.0.0.0.0.0.0 PTR pool-${0,4,x}.example.com.
+$GENERATE 0-65535 ${0,7,n}.1.0.0.0.0.0.0.0.0.0.0.0 PTR pool-${0,4,x}.example.com.
+`, b.String())
+
+	t.Run("small zone", func(t *testing.T) {
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("2001:db8:1::/120").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.Nil(t, err)
+		assert.Contains(t, b.String(), `
+$GENERATE 0-0 ${0,3,n} PTR pool-${0,4,x}.example.com.
+1.0	IN	PTR	www.example.com.
+$GENERATE 2-255 ${0,3,n} PTR pool-${0,4,x}.example.com.
+`)
+	})
+
+	t.Run("single address", func(t *testing.T) {
+		table := NewTable_[string]()
+		table.Insert(_p("2001:db8::/64"), "host.example.")
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("2001:db8::1/128").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.Nil(t, err)
+		assert.Contains(t, b.String(), "\n@\tIN\tPTR\thost.example.\n")
+		assert.NotContains(t, b.String(), "$GENERATE")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		table := NewTable_[string]()
+		table.Insert(_p("2001:db8:1::/64"), "pool.example.com.")
+
+		var b bytes.Buffer
+		err := WriteReverseZone(&b, _p("2001:db8:1::/64").ReverseZones()[0], table.Table(), ZoneFileOptions{})
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, b.Len())
+	})
+}
@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+// ZonedTable holds a separate Table for each zone. Link-local prefixes like
+// fe80::/64 exist on every link so a single table can't tell them apart. Look
+// them up with a ZonedAddress to use the table for its zone. The table for the
+// empty zone holds entries that aren't specific to any link.
+//
+// The zero value is an empty ZonedTable which can be read but not assigned to.
+// Use make() to create one that can be filled in.
+type ZonedTable[T any] map[string]Table[T]
+
+// Get returns the value associated with the host prefix (/128) of the address
+// in the table for its zone. If there is no such entry, ok is false and the
+// value must be ignored.
+func (me ZonedTable[T]) Get(addr ZonedAddress) (value T, ok bool) {
+	return me[addr.zone].Get(addr.addr)
+}
+
+// LongestMatch returns the value associated with the longest prefix
+// containing the address in the table for its zone. If there is no match,
+// found is false and the other results must be ignored.
+func (me ZonedTable[T]) LongestMatch(addr ZonedAddress) (value T, found bool, prefix Prefix) {
+	return me[addr.zone].LongestMatch(addr.addr)
+}
@@ -0,0 +1,55 @@
+//go:build go1.18
+// +build go1.18
+
+package ipv6
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonedTable(t *testing.T) {
+	eth0 := NewTable_[string]()
+	eth0.Insert(_p("fe80::/64"), "eth0 link")
+	eth0.Insert(_a("fe80::1"), "eth0 router")
+	eth1 := NewTable_[string]()
+	eth1.Insert(_p("fe80::/64"), "eth1 link")
+	global := NewTable_[string]()
+	global.Insert(_p("2001:db8::/32"), "documentation")
+
+	tables := ZonedTable[string]{
+		"eth0": eth0.Table(),
+		"eth1": eth1.Table(),
+		"":     global.Table(),
+	}
+
+	value, found, prefix := tables.LongestMatch(_a("fe80::1").WithZone("eth0"))
+	assert.True(t, found)
+	assert.Equal(t, "eth0 router", value)
+	assert.Equal(t, _p("fe80::1/128"), prefix)
+
+	value, found, prefix = tables.LongestMatch(_a("fe80::1").WithZone("eth1"))
+	assert.True(t, found)
+	assert.Equal(t, "eth1 link", value)
+	assert.Equal(t, _p("fe80::/64"), prefix)
+
+	value, found, _ = tables.LongestMatch(_a("2001:db8::1").WithZone(""))
+	assert.True(t, found)
+	assert.Equal(t, "documentation", value)
+
+	_, found, _ = tables.LongestMatch(_a("fe80::1").WithZone("eth2"))
+	assert.False(t, found)
+	_, found, _ = tables.LongestMatch(_a("fe80::1").WithZone(""))
+	assert.False(t, found)
+
+	value, ok := tables.Get(_a("fe80::1").WithZone("eth0"))
+	assert.True(t, ok)
+	assert.Equal(t, "eth0 router", value)
+	_, ok = tables.Get(_a("fe80::1").WithZone("eth1"))
+	assert.False(t, ok)
+
+	var empty ZonedTable[string]
+	_, found, _ = empty.LongestMatch(_a("fe80::1").WithZone("eth0"))
+	assert.False(t, found)
+}